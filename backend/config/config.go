@@ -12,13 +12,21 @@ import (
 
 // Config holds all application configuration.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	OAuth    OAuthConfig
-	CORS     CORSConfig
-	Log      LogConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	JWT       JWTConfig
+	OAuth     OAuthConfig
+	CORS      CORSConfig
+	Log       LogConfig
+	Holiday   HolidayConfig
+	Catalog   CatalogConfig
+	Report    ReportConfig
+	Notify    NotificationConfig
+	Webhook   WebhookConfig
+	Digest    DigestConfig
+	Recommend RecommendationConfig
+	Push      PushConfig
 }
 
 // ServerConfig holds HTTP server settings.
@@ -41,8 +49,10 @@ type DatabaseConfig struct {
 	ConnMaxLifetime time.Duration
 }
 
-// RedisConfig holds Redis connection settings.
+// RedisConfig holds Redis connection settings for the aggregate cache.
+// When disabled or unreachable, the cache is kept in process memory.
 type RedisConfig struct {
+	Enabled  bool
 	Host     string
 	Port     string
 	Password string
@@ -70,6 +80,76 @@ type LogConfig struct {
 	Format string
 }
 
+// HolidayConfig holds public holiday calendar settings.
+// An empty DataFile uses the bundled Korean public holiday list.
+type HolidayConfig struct {
+	DataFile string
+}
+
+// CatalogConfig holds well-known service catalog settings.
+// An empty DataFile uses the bundled catalog.
+type CatalogConfig struct {
+	DataFile string
+}
+
+// ReportConfig holds report export settings.
+// FontFile is a TrueType font with Hangul glyphs used for PDF text; PDF
+// exports are disabled when it is empty.
+type ReportConfig struct {
+	FontFile string
+}
+
+// NotificationConfig holds reminder scheduler and delivery settings.
+type NotificationConfig struct {
+	Enabled      bool
+	Interval     time.Duration
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	SMTP         SMTPConfig
+}
+
+// SMTPConfig holds outgoing email settings. An empty Host disables email delivery.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// WebhookConfig holds outbound webhook delivery settings.
+// Failed deliveries are retried after RetryBackoff × 2^(attempt-1), capped at MaxBackoff.
+type WebhookConfig struct {
+	Enabled             bool
+	PollInterval        time.Duration
+	BillingScanInterval time.Duration
+	MaxAttempts         int
+	RetryBackoff        time.Duration
+	MaxBackoff          time.Duration
+	Timeout             time.Duration
+}
+
+// DigestConfig holds spending digest scheduler settings.
+type DigestConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// RecommendationConfig holds settings for the scheduler that writes cancel
+// recommendations to the inbox.
+type RecommendationConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// PushConfig holds browser Web Push settings. Subject is the VAPID contact
+// (a mailto: or https: URL) sent to push services.
+type PushConfig struct {
+	Subject string
+	TTL     time.Duration
+	Timeout time.Duration
+}
+
 // OAuthConfig holds OAuth provider settings.
 type OAuthConfig struct {
 	Google OAuthProviderConfig
@@ -85,6 +165,11 @@ type OAuthProviderConfig struct {
 	RedirectURL  string
 }
 
+// Addr returns the Redis host:port address.
+func (r *RedisConfig) Addr() string {
+	return r.Host + ":" + r.Port
+}
+
 // DSN returns the PostgreSQL connection string.
 func (d *DatabaseConfig) DSN() string {
 	return "host=" + d.Host +
@@ -134,6 +219,7 @@ func Load() *Config {
 			ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
 		},
 		Redis: RedisConfig{
+			Enabled:  getEnvBool("REDIS_ENABLED", true),
 			Host:     getEnv("REDIS_HOST", "localhost"),
 			Port:     getEnv("REDIS_PORT", "6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
@@ -154,6 +240,50 @@ func Load() *Config {
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "text"),
 		},
+		Holiday: HolidayConfig{
+			DataFile: getEnv("HOLIDAY_DATA_FILE", ""),
+		},
+		Catalog: CatalogConfig{
+			DataFile: getEnv("SERVICE_CATALOG_FILE", ""),
+		},
+		Report: ReportConfig{
+			FontFile: getEnv("REPORT_FONT_FILE", ""),
+		},
+		Notify: NotificationConfig{
+			Enabled:      getEnvBool("NOTIFICATION_ENABLED", true),
+			Interval:     getEnvDuration("NOTIFICATION_INTERVAL", 1*time.Hour),
+			MaxAttempts:  getEnvInt("NOTIFICATION_MAX_ATTEMPTS", 3),
+			RetryBackoff: getEnvDuration("NOTIFICATION_RETRY_BACKOFF", 2*time.Second),
+			SMTP: SMTPConfig{
+				Host:     getEnv("SMTP_HOST", ""),
+				Port:     getEnv("SMTP_PORT", "587"),
+				Username: getEnv("SMTP_USER", ""),
+				Password: getEnv("SMTP_PASSWORD", ""),
+				From:     getEnv("EMAIL_FROM", "SubKeep <no-reply@subkeep.app>"),
+			},
+		},
+		Webhook: WebhookConfig{
+			Enabled:             getEnvBool("WEBHOOK_ENABLED", true),
+			PollInterval:        getEnvDuration("WEBHOOK_POLL_INTERVAL", 15*time.Second),
+			BillingScanInterval: getEnvDuration("WEBHOOK_BILLING_SCAN_INTERVAL", 1*time.Hour),
+			MaxAttempts:         getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			RetryBackoff:        getEnvDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+			MaxBackoff:          getEnvDuration("WEBHOOK_MAX_BACKOFF", 6*time.Hour),
+			Timeout:             getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		Digest: DigestConfig{
+			Enabled:  getEnvBool("DIGEST_ENABLED", true),
+			Interval: getEnvDuration("DIGEST_INTERVAL", 15*time.Minute),
+		},
+		Recommend: RecommendationConfig{
+			Enabled:  getEnvBool("RECOMMENDATION_ENABLED", true),
+			Interval: getEnvDuration("RECOMMENDATION_INTERVAL", 6*time.Hour),
+		},
+		Push: PushConfig{
+			Subject: getEnv("VAPID_SUBJECT", "mailto:support@subkeep.app"),
+			TTL:     getEnvDuration("PUSH_TTL", 24*time.Hour),
+			Timeout: getEnvDuration("PUSH_TIMEOUT", 10*time.Second),
+		},
 		OAuth: OAuthConfig{
 			Google: OAuthProviderConfig{
 				ClientID:     getEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
@@ -213,6 +343,20 @@ func getEnvInt(key string, defaultVal int) int {
 	return n
 }
 
+// getEnvBool returns a boolean environment variable or a default value.
+func getEnvBool(key string, defaultVal bool) bool {
+	val := getEnv(key, "")
+	if val == "" {
+		return defaultVal
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		slog.Warn("invalid boolean env var, using default", "key", key, "value", val, "default", defaultVal)
+		return defaultVal
+	}
+	return b
+}
+
 // getEnvDuration returns a time.Duration environment variable or a default.
 // Accepts formats: "24h", "30m", "5" (interpreted as minutes for DB_CONN_MAX_LIFETIME).
 func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/subkeep/backend/config"
+)
+
+// redisPingTimeout bounds the connectivity check at startup.
+const redisPingTimeout = 3 * time.Second
+
+// ConnectRedis opens a Redis client and verifies the server is reachable.
+func ConnectRedis(cfg *config.RedisConfig) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr(),
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisPingTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	slog.Info("redis connected", "addr", cfg.Addr(), "db", cfg.DB)
+	return client, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/subkeep/backend/models"
 	"github.com/subkeep/backend/services"
 	"github.com/subkeep/backend/utils"
 )
@@ -239,12 +240,43 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 		return utils.Error(c, utils.ErrNotFound("사용자를 찾을 수 없습니다"))
 	}
 
-	return utils.Success(c, fiber.Map{
+	return utils.Success(c, userProfile(user))
+}
+
+// UpdateMe handles PATCH /api/v1/auth/me.
+// It updates the authenticated user's profile settings such as timezone.
+func (h *AuthHandler) UpdateMe(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(string)
+	if !ok || userID == "" {
+		return utils.Error(c, utils.ErrUnauthorized("인증이 필요합니다"))
+	}
+
+	var req services.UpdateProfileRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("failed to parse profile update request", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	user, err := h.authService.UpdateProfile(userID, &req)
+	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("사용자 정보를 수정할 수 없습니다"))
+	}
+
+	return utils.Success(c, userProfile(user))
+}
+
+// userProfile builds the public profile representation of a user.
+func userProfile(user *models.User) fiber.Map {
+	return fiber.Map{
 		"id":        user.ID,
 		"email":     user.Email,
 		"nickname":  user.Nickname,
 		"avatarUrl": user.AvatarURL,
 		"provider":  user.Provider,
+		"timezone":  user.Location().String(),
 		"createdAt": user.CreatedAt,
-	})
+	}
 }
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// BudgetHandler handles budget-related HTTP requests.
+type BudgetHandler struct {
+	service *services.BudgetService
+}
+
+// NewBudgetHandler creates a new BudgetHandler.
+func NewBudgetHandler(service *services.BudgetService) *BudgetHandler {
+	return &BudgetHandler{service: service}
+}
+
+// GetAll handles GET /api/v1/budgets.
+func (h *BudgetHandler) GetAll(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	budgets, svcErr := h.service.GetBudgets(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, budgets)
+}
+
+// GetProgress handles GET /api/v1/budgets/progress.
+func (h *BudgetHandler) GetProgress(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	progress, svcErr := h.service.GetProgress(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, progress)
+}
+
+// Create handles POST /api/v1/budgets.
+func (h *BudgetHandler) Create(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.CreateBudgetRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("예산 생성 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	budget, svcErr := h.service.CreateBudget(userID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, budget)
+}
+
+// Update handles PUT /api/v1/budgets/:id.
+func (h *BudgetHandler) Update(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	budgetID := c.Params("id")
+	if budgetID == "" {
+		return utils.Error(c, utils.ErrBadRequest("예산 ID가 필요합니다"))
+	}
+
+	var req services.UpdateBudgetRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("예산 수정 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	budget, svcErr := h.service.UpdateBudget(userID, budgetID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, budget)
+}
+
+// Delete handles DELETE /api/v1/budgets/:id.
+func (h *BudgetHandler) Delete(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	budgetID := c.Params("id")
+	if budgetID == "" {
+		return utils.Error(c, utils.ErrBadRequest("예산 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.DeleteBudget(userID, budgetID); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
@@ -27,9 +27,9 @@ func (h *CalendarHandler) GetMonthlyCalendar(c *fiber.Ctx) error {
 		return utils.Error(c, err.(*utils.AppError))
 	}
 
-	now := time.Now()
-	year := now.Year()
-	month := int(now.Month())
+	today := h.service.Today(userID)
+	year := today.Year()
+	month := int(today.Month())
 
 	// Parse optional year parameter.
 	if yearStr := c.Query("year"); yearStr != "" {
@@ -156,3 +156,70 @@ func (h *CalendarHandler) GetUpcomingPayments(c *fiber.Ctx) error {
 
 	return utils.Success(c, payments)
 }
+
+// GetRangeCalendar handles GET /api/v1/calendar/range.
+// Query params: from, to (YYYY-MM-DD, inclusive, up to 24 months apart).
+func (h *CalendarHandler) GetRangeCalendar(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	fromStr := c.Query("from")
+	if fromStr == "" {
+		return utils.Error(c, utils.ErrBadRequest("from은 필수 파라미터입니다"))
+	}
+	from, parseErr := time.Parse("2006-01-02", fromStr)
+	if parseErr != nil {
+		return utils.Error(c, utils.ErrBadRequest("from 형식이 올바르지 않습니다 (YYYY-MM-DD)"))
+	}
+
+	toStr := c.Query("to")
+	if toStr == "" {
+		return utils.Error(c, utils.ErrBadRequest("to는 필수 파라미터입니다"))
+	}
+	to, parseErr := time.Parse("2006-01-02", toStr)
+	if parseErr != nil {
+		return utils.Error(c, utils.ErrBadRequest("to 형식이 올바르지 않습니다 (YYYY-MM-DD)"))
+	}
+
+	calendar, svcErr := h.service.GetRangeCalendar(userID, from, to)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("캘린더 데이터를 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, calendar)
+}
+
+// GetYearlyOverview handles GET /api/v1/calendar/yearly.
+func (h *CalendarHandler) GetYearlyOverview(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	year := h.service.Today(userID).Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, parseErr := strconv.Atoi(yearStr)
+		if parseErr != nil {
+			return utils.Error(c, utils.ErrBadRequest("year는 숫자여야 합니다"))
+		}
+		year = parsed
+	}
+	if year < 2000 || year > 2100 {
+		return utils.Error(c, utils.ErrBadRequest("year는 2000~2100 범위여야 합니다"))
+	}
+
+	overview, svcErr := h.service.GetYearlyOverview(userID, year)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("연간 캘린더를 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, overview)
+}
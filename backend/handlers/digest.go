@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// DigestHandler handles spending digest HTTP requests.
+type DigestHandler struct {
+	service *services.DigestService
+}
+
+// NewDigestHandler creates a new DigestHandler.
+func NewDigestHandler(service *services.DigestService) *DigestHandler {
+	return &DigestHandler{service: service}
+}
+
+// Preview handles GET /api/v1/digest/preview.
+// Query params: frequency (weekly|monthly, default weekly), format (json|html|text, default json).
+func (h *DigestHandler) Preview(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	format := c.Query("format", "json")
+	if format != "json" && format != "html" && format != "text" {
+		return utils.Error(c, utils.ErrBadRequest("format은 json, html, text 중 하나여야 합니다"))
+	}
+
+	frequency := models.DigestFrequency(c.Query("frequency", string(models.DigestFrequencyWeekly)))
+	digest, svcErr := h.service.Preview(userID, frequency)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	if format == "json" {
+		return utils.Success(c, digest)
+	}
+
+	_, text, html, renderErr := h.service.Render(digest)
+	if renderErr != nil {
+		slog.Error("다이제스트 렌더링 실패", "userID", userID, "error", renderErr)
+		return utils.Error(c, utils.ErrInternal("다이제스트를 생성할 수 없습니다"))
+	}
+	if format == "html" {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(html)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return c.SendString(text)
+}
+
+// GetSchedule handles GET /api/v1/digest/schedule.
+func (h *DigestHandler) GetSchedule(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	schedule, svcErr := h.service.GetSchedule(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, schedule)
+}
+
+// UpdateSchedule handles PUT /api/v1/digest/schedule.
+func (h *DigestHandler) UpdateSchedule(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.UpdateDigestScheduleRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("다이제스트 일정 수정 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	schedule, svcErr := h.service.UpdateSchedule(userID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, schedule)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// ForecastHandler handles cash-flow forecast HTTP requests.
+type ForecastHandler struct {
+	service *services.ForecastService
+}
+
+// NewForecastHandler creates a new ForecastHandler.
+func NewForecastHandler(service *services.ForecastService) *ForecastHandler {
+	return &ForecastHandler{service: service}
+}
+
+// GetCashFlowForecast handles POST /api/v1/forecast/cash-flow.
+func (h *ForecastHandler) GetCashFlowForecast(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.CashFlowForecastRequest
+	if err := c.BodyParser(&req); err != nil {
+		slog.Warn("현금 흐름 예측 요청 파싱 실패", "error", err)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	forecast, svcErr := h.service.GetCashFlowForecast(userID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("현금 흐름 예측을 계산할 수 없습니다"))
+	}
+
+	return utils.Success(c, forecast)
+}
+
+// GetSpendingProjection handles GET /api/v1/forecast/projection.
+func (h *ForecastHandler) GetSpendingProjection(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	projection, svcErr := h.service.GetSpendingProjection(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("지출 전망을 계산할 수 없습니다"))
+	}
+
+	return utils.Success(c, projection)
+}
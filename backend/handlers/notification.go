@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// NotificationHandler handles in-app notification inbox HTTP requests.
+type NotificationHandler struct {
+	service *services.InboxService
+}
+
+// NewNotificationHandler creates a new NotificationHandler.
+func NewNotificationHandler(service *services.InboxService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// List handles GET /api/v1/notifications.
+func (h *NotificationHandler) List(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	unreadOnly, _ := strconv.ParseBool(c.Query("unread", "false"))
+
+	page, svcErr := h.service.List(userID, services.InboxListQuery{
+		Cursor:     c.Query("cursor"),
+		Limit:      limit,
+		UnreadOnly: unreadOnly,
+		Type:       c.Query("type"),
+	})
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.CursorPaginated(c, page.Notifications, page.Limit, page.NextCursor)
+}
+
+// UnreadCount handles GET /api/v1/notifications/unread-count.
+func (h *NotificationHandler) UnreadCount(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	result, svcErr := h.service.UnreadCount(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, result)
+}
+
+// MarkRead handles POST /api/v1/notifications/:id/read.
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	return h.setRead(c, true)
+}
+
+// MarkUnread handles DELETE /api/v1/notifications/:id/read.
+func (h *NotificationHandler) MarkUnread(c *fiber.Ctx) error {
+	return h.setRead(c, false)
+}
+
+// MarkAllRead handles POST /api/v1/notifications/read-all.
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	result, svcErr := h.service.MarkAllRead(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, result)
+}
+
+// setRead updates the read state of the notification in the :id parameter.
+func (h *NotificationHandler) setRead(c *fiber.Ctx, read bool) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	notificationID := c.Params("id")
+	if notificationID == "" {
+		return utils.Error(c, utils.ErrBadRequest("알림 ID가 필요합니다"))
+	}
+
+	var (
+		result interface{}
+		svcErr error
+	)
+	if read {
+		result, svcErr = h.service.MarkRead(userID, notificationID)
+	} else {
+		result, svcErr = h.service.MarkUnread(userID, notificationID)
+	}
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, result)
+}
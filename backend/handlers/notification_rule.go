@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// NotificationRuleHandler handles notification rule HTTP requests.
+type NotificationRuleHandler struct {
+	service *services.NotificationRuleService
+}
+
+// NewNotificationRuleHandler creates a new NotificationRuleHandler.
+func NewNotificationRuleHandler(service *services.NotificationRuleService) *NotificationRuleHandler {
+	return &NotificationRuleHandler{service: service}
+}
+
+// GetAll handles GET /api/v1/notification-rules.
+func (h *NotificationRuleHandler) GetAll(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	rules, svcErr := h.service.GetRules(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, rules)
+}
+
+// Create handles POST /api/v1/notification-rules.
+func (h *NotificationRuleHandler) Create(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.CreateNotificationRuleRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("알림 규칙 생성 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	rule, svcErr := h.service.CreateRule(userID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, rule)
+}
+
+// Update handles PUT /api/v1/notification-rules/:id.
+func (h *NotificationRuleHandler) Update(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	ruleID := c.Params("id")
+	if ruleID == "" {
+		return utils.Error(c, utils.ErrBadRequest("알림 규칙 ID가 필요합니다"))
+	}
+
+	var req services.UpdateNotificationRuleRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("알림 규칙 수정 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	rule, svcErr := h.service.UpdateRule(userID, ruleID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, rule)
+}
+
+// Delete handles DELETE /api/v1/notification-rules/:id.
+func (h *NotificationRuleHandler) Delete(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	ruleID := c.Params("id")
+	if ruleID == "" {
+		return utils.Error(c, utils.ErrBadRequest("알림 규칙 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.DeleteRule(userID, ruleID); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
+
+// GetDeliveries handles GET /api/v1/notification-deliveries.
+// Query params: page, perPage.
+func (h *NotificationRuleHandler) GetDeliveries(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("perPage", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 || perPage > 100 {
+		perPage = 20
+	}
+
+	deliveries, total, svcErr := h.service.GetDeliveries(userID, page, perPage)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Paginated(c, deliveries, page, perPage, total)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// PlanAlternativeHandler handles alternative plan price HTTP requests.
+type PlanAlternativeHandler struct {
+	service *services.PlanAlternativeService
+}
+
+// NewPlanAlternativeHandler creates a new PlanAlternativeHandler.
+func NewPlanAlternativeHandler(service *services.PlanAlternativeService) *PlanAlternativeHandler {
+	return &PlanAlternativeHandler{service: service}
+}
+
+// GetAll handles GET /api/v1/subscriptions/:id/plan-alternatives.
+func (h *PlanAlternativeHandler) GetAll(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	subID := c.Params("id")
+	if subID == "" {
+		return utils.Error(c, utils.ErrBadRequest("구독 ID가 필요합니다"))
+	}
+
+	alts, svcErr := h.service.GetAlternatives(userID, subID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, alts)
+}
+
+// Set handles PUT /api/v1/subscriptions/:id/plan-alternatives/:cycle.
+func (h *PlanAlternativeHandler) Set(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	subID := c.Params("id")
+	if subID == "" {
+		return utils.Error(c, utils.ErrBadRequest("구독 ID가 필요합니다"))
+	}
+
+	var req services.SetPlanAlternativeRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("대체 요금제 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	alt, svcErr := h.service.SetAlternative(userID, subID, c.Params("cycle"), &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, alt)
+}
+
+// Delete handles DELETE /api/v1/subscriptions/:id/plan-alternatives/:cycle.
+func (h *PlanAlternativeHandler) Delete(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	subID := c.Params("id")
+	if subID == "" {
+		return utils.Error(c, utils.ErrBadRequest("구독 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.DeleteAlternative(userID, subID, c.Params("cycle")); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// PushHandler handles browser push subscription HTTP requests.
+type PushHandler struct {
+	service *services.PushService
+}
+
+// NewPushHandler creates a new PushHandler.
+func NewPushHandler(service *services.PushService) *PushHandler {
+	return &PushHandler{service: service}
+}
+
+// GetPublicKey handles GET /api/v1/push/vapid-public-key.
+func (h *PushHandler) GetPublicKey(c *fiber.Ctx) error {
+	return utils.Success(c, h.service.GetPublicKey())
+}
+
+// GetSubscriptions handles GET /api/v1/push/subscriptions.
+func (h *PushHandler) GetSubscriptions(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	subs, svcErr := h.service.GetSubscriptions(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, subs)
+}
+
+// Subscribe handles POST /api/v1/push/subscriptions.
+func (h *PushHandler) Subscribe(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.CreatePushSubscriptionRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("푸시 구독 등록 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	sub, svcErr := h.service.Subscribe(userID, &req, c.Get(fiber.HeaderUserAgent))
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, sub)
+}
+
+// Unsubscribe handles DELETE /api/v1/push/subscriptions/:id.
+func (h *PushHandler) Unsubscribe(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	subscriptionID := c.Params("id")
+	if subscriptionID == "" {
+		return utils.Error(c, utils.ErrBadRequest("푸시 구독 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.Unsubscribe(userID, subscriptionID); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
+
+// SendTest handles POST /api/v1/push/test.
+func (h *PushHandler) SendTest(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	result, svcErr := h.service.SendTest(c.UserContext(), userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, result)
+}
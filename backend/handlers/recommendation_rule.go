@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// RecommendationRuleHandler handles recommendation rule-related HTTP requests.
+type RecommendationRuleHandler struct {
+	service *services.RecommendationRuleService
+}
+
+// NewRecommendationRuleHandler creates a new RecommendationRuleHandler.
+func NewRecommendationRuleHandler(service *services.RecommendationRuleService) *RecommendationRuleHandler {
+	return &RecommendationRuleHandler{service: service}
+}
+
+// GetAll handles GET /api/v1/recommendation-rules.
+func (h *RecommendationRuleHandler) GetAll(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	rules, svcErr := h.service.GetRules(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, rules)
+}
+
+// Create handles POST /api/v1/recommendation-rules.
+func (h *RecommendationRuleHandler) Create(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.CreateRecommendationRuleRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("해지 추천 규칙 생성 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	rule, svcErr := h.service.CreateRule(userID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, rule)
+}
+
+// Update handles PUT /api/v1/recommendation-rules/:id.
+func (h *RecommendationRuleHandler) Update(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	ruleID := c.Params("id")
+	if ruleID == "" {
+		return utils.Error(c, utils.ErrBadRequest("규칙 ID가 필요합니다"))
+	}
+
+	var req services.UpdateRecommendationRuleRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("해지 추천 규칙 수정 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	rule, svcErr := h.service.UpdateRule(userID, ruleID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, rule)
+}
+
+// Delete handles DELETE /api/v1/recommendation-rules/:id.
+func (h *RecommendationRuleHandler) Delete(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	ruleID := c.Params("id")
+	if ruleID == "" {
+		return utils.Error(c, utils.ErrBadRequest("규칙 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.DeleteRule(userID, ruleID); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
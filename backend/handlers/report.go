@@ -1,6 +1,12 @@
 package handlers
 
 import (
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/subkeep/backend/services"
@@ -9,12 +15,13 @@ import (
 
 // ReportHandler handles report-related HTTP requests.
 type ReportHandler struct {
-	service *services.ReportService
+	service  *services.ReportService
+	exporter *services.ReportExporter
 }
 
 // NewReportHandler creates a new ReportHandler.
-func NewReportHandler(service *services.ReportService) *ReportHandler {
-	return &ReportHandler{service: service}
+func NewReportHandler(service *services.ReportService, exporter *services.ReportExporter) *ReportHandler {
+	return &ReportHandler{service: service, exporter: exporter}
 }
 
 // GetOverview handles GET /api/v1/reports/overview.
@@ -34,3 +41,262 @@ func (h *ReportHandler) GetOverview(c *fiber.Ctx) error {
 
 	return utils.Success(c, overview)
 }
+
+// GetSavings handles GET /api/v1/reports/savings.
+func (h *ReportHandler) GetSavings(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	report, svcErr := h.service.GetSavings(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("절약 기록을 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, report)
+}
+
+// GetValueQuadrant handles GET /api/v1/reports/value-quadrant.
+func (h *ReportHandler) GetValueQuadrant(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	report, svcErr := h.service.GetValueQuadrant(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("가치 사분면을 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, report)
+}
+
+// GetYearInReview handles GET /api/v1/reports/year-in-review.
+// Query params: year (defaults to the current year).
+func (h *ReportHandler) GetYearInReview(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	year := h.service.Today(userID).Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, parseErr := strconv.Atoi(yearStr)
+		if parseErr != nil {
+			return utils.Error(c, utils.ErrBadRequest("year는 숫자여야 합니다"))
+		}
+		year = parsed
+	}
+	if year < 2000 || year > 2100 {
+		return utils.Error(c, utils.ErrBadRequest("year는 2000~2100 범위여야 합니다"))
+	}
+
+	report, svcErr := h.service.GetYearInReview(userID, year)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("연간 리포트를 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, report)
+}
+
+// GetPeriod handles GET /api/v1/reports/period.
+// Query params: from, to (YYYY-MM-DD, inclusive), compare (previous, year;
+// defaults to previous), compareFrom, compareTo (YYYY-MM-DD, optional,
+// override compare).
+func (h *ReportHandler) GetPeriod(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	from, appErr := parseDateQuery(c, "from")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+	if from == nil {
+		return utils.Error(c, utils.ErrBadRequest("from은 필수 파라미터입니다"))
+	}
+	to, appErr := parseDateQuery(c, "to")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+	if to == nil {
+		return utils.Error(c, utils.ErrBadRequest("to는 필수 파라미터입니다"))
+	}
+	compareFrom, appErr := parseDateQuery(c, "compareFrom")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+	compareTo, appErr := parseDateQuery(c, "compareTo")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+
+	report, svcErr := h.service.GetPeriodReport(userID, *from, *to, c.Query("compare"), compareFrom, compareTo)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("기간 리포트를 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, report)
+}
+
+// Export handles GET /api/v1/reports/export.
+// Query params: format (csv, xlsx, pdf), from, to (YYYY-MM-DD, optional).
+// The file is rendered to a temporary file before it is sent; XLSX and PDF
+// documents are assembled in memory while rendering. PDF export is refused
+// with 400 when no report font is configured.
+func (h *ReportHandler) Export(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	from, appErr := parseDateQuery(c, "from")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+	to, appErr := parseDateQuery(c, "to")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+
+	file, svcErr := h.exporter.Export(userID, c.Query("format", services.ReportExportCSV), from, to)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("리포트를 내보낼 수 없습니다"))
+	}
+
+	return sendReportFile(c, userID, file)
+}
+
+// GetShareReceivables handles GET /api/v1/reports/receivables.
+// Query params: from, to (YYYY-MM-DD, inclusive; default to the current month).
+func (h *ReportHandler) GetShareReceivables(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	from, appErr := parseDateQuery(c, "from")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+	to, appErr := parseDateQuery(c, "to")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+
+	report, svcErr := h.service.GetShareReceivables(userID, from, to)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("공유 정산 리포트를 조회할 수 없습니다"))
+	}
+
+	return utils.Success(c, report)
+}
+
+// ExportShareReceivables handles GET /api/v1/reports/receivables/export.
+// Query params: format (csv, xlsx, pdf; defaults to csv), from, to
+// (YYYY-MM-DD, inclusive; default to the current month). The file is sent
+// as in Export.
+func (h *ReportHandler) ExportShareReceivables(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	from, appErr := parseDateQuery(c, "from")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+	to, appErr := parseDateQuery(c, "to")
+	if appErr != nil {
+		return utils.Error(c, appErr)
+	}
+
+	file, svcErr := h.exporter.ExportShareReceivables(userID, c.Query("format", services.ReportExportCSV), from, to)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal("공유 정산 리포트를 내보낼 수 없습니다"))
+	}
+
+	return sendReportFile(c, userID, file)
+}
+
+// sendReportFile renders an exported report to a temporary file and streams
+// it to the client as an attachment. Rendering finishes before the response
+// starts, so a failure is still answered with an error status.
+func sendReportFile(c *fiber.Ctx, userID string, file *services.ReportFile) error {
+	tmp, err := os.CreateTemp("", "subkeep-report-*")
+	if err != nil {
+		slog.Error("리포트 임시 파일 생성 실패", "userID", userID, "error", err)
+		return utils.Error(c, utils.ErrInternal("리포트를 내보낼 수 없습니다"))
+	}
+	body := &tempFileBody{File: tmp}
+
+	if err := file.Write(tmp); err != nil {
+		_ = body.Close()
+		slog.Error("리포트 내보내기 실패", "userID", userID, "error", err)
+		return utils.Error(c, utils.ErrInternal("리포트를 내보낼 수 없습니다"))
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = body.Close()
+		slog.Error("리포트 임시 파일 읽기 실패", "userID", userID, "error", err)
+		return utils.Error(c, utils.ErrInternal("리포트를 내보낼 수 없습니다"))
+	}
+
+	c.Attachment(file.Filename)
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	return c.SendStream(body, int(size))
+}
+
+// tempFileBody is a response body that removes its file once the response
+// has been sent and the body closed.
+type tempFileBody struct {
+	*os.File
+}
+
+// Close closes and removes the file.
+func (b *tempFileBody) Close() error {
+	err := b.File.Close()
+	if removeErr := os.Remove(b.Name()); removeErr != nil {
+		slog.Warn("리포트 임시 파일 삭제 실패", "file", b.Name(), "error", removeErr)
+	}
+	return err
+}
+
+// parseDateQuery parses an optional YYYY-MM-DD query parameter.
+func parseDateQuery(c *fiber.Ctx, name string) (*time.Time, *utils.AppError) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, utils.ErrBadRequest(name + " 형식이 올바르지 않습니다 (YYYY-MM-DD)")
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// SinkingFundHandler handles sinking-fund HTTP requests.
+type SinkingFundHandler struct {
+	service *services.SinkingFundService
+}
+
+// NewSinkingFundHandler creates a new SinkingFundHandler.
+func NewSinkingFundHandler(service *services.SinkingFundService) *SinkingFundHandler {
+	return &SinkingFundHandler{service: service}
+}
+
+// GetPlan handles GET /api/v1/sinking-funds.
+func (h *SinkingFundHandler) GetPlan(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	plan, svcErr := h.service.GetPlan(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, plan)
+}
+
+// AddContribution handles POST /api/v1/sinking-funds/:subscriptionId/contributions.
+func (h *SinkingFundHandler) AddContribution(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	subID := c.Params("subscriptionId")
+	if subID == "" {
+		return utils.Error(c, utils.ErrBadRequest("구독 ID가 필요합니다"))
+	}
+
+	var req services.CreateSinkingFundContributionRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("적립 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	contribution, svcErr := h.service.AddContribution(userID, subID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, contribution)
+}
+
+// DeleteContribution handles DELETE /api/v1/sinking-funds/contributions/:id.
+func (h *SinkingFundHandler) DeleteContribution(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	contributionID := c.Params("id")
+	if contributionID == "" {
+		return utils.Error(c, utils.ErrBadRequest("적립 내역 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.DeleteContribution(userID, contributionID); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
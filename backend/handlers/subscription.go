@@ -12,39 +12,60 @@ import (
 	"github.com/subkeep/backend/utils"
 )
 
-// SubscriptionResponse enriches a subscription with computed monetary fields.
+// SubscriptionResponse enriches a subscription with computed monetary fields
+// and its value for money score from 0 to 100. The personal amount it has
+// charged since its start date is only included in detail responses and in
+// lists sorted by it.
 type SubscriptionResponse struct {
 	models.Subscription
-	MonthlyAmount int `json:"monthlyAmount"`
-	AnnualAmount  int `json:"annualAmount"`
+	MonthlyAmount int  `json:"monthlyAmount"`
+	AnnualAmount  int  `json:"annualAmount"`
+	ValueScore    int  `json:"valueScore"`
+	LifetimeSpend *int `json:"lifetimeSpend,omitempty"`
+}
+
+// SubscriptionHandler handles subscription-related HTTP requests.
+type SubscriptionHandler struct {
+	service *services.SubscriptionService
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler.
+func NewSubscriptionHandler(service *services.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{service: service}
 }
 
 // toSubscriptionResponse converts a Subscription to a SubscriptionResponse.
-func toSubscriptionResponse(sub *models.Subscription) *SubscriptionResponse {
-	return &SubscriptionResponse{
-		Subscription:  *sub,
-		MonthlyAmount: sub.MonthlyAmount(),
-		AnnualAmount:  sub.AnnualAmount(),
-	}
+func (h *SubscriptionHandler) toSubscriptionResponse(userID string, sub *models.Subscription) *SubscriptionResponse {
+	return h.toSubscriptionResponses(userID, []*models.Subscription{sub})[0]
 }
 
 // toSubscriptionResponses converts a slice of subscriptions.
-func toSubscriptionResponses(subs []*models.Subscription) []*SubscriptionResponse {
+func (h *SubscriptionHandler) toSubscriptionResponses(userID string, subs []*models.Subscription) []*SubscriptionResponse {
+	scores := h.service.ValueScores(userID, subs)
 	responses := make([]*SubscriptionResponse, len(subs))
 	for i, sub := range subs {
-		responses[i] = toSubscriptionResponse(sub)
+		responses[i] = &SubscriptionResponse{
+			Subscription:  *sub,
+			MonthlyAmount: sub.MonthlyAmount(),
+			AnnualAmount:  sub.AnnualAmount(),
+			ValueScore:    scores[sub.ID.String()],
+		}
 	}
 	return responses
 }
 
-// SubscriptionHandler handles subscription-related HTTP requests.
-type SubscriptionHandler struct {
-	service *services.SubscriptionService
-}
-
-// NewSubscriptionHandler creates a new SubscriptionHandler.
-func NewSubscriptionHandler(service *services.SubscriptionService) *SubscriptionHandler {
-	return &SubscriptionHandler{service: service}
+// withLifetimeSpend fills in the lifetime spend of the responses.
+func (h *SubscriptionHandler) withLifetimeSpend(userID string, responses []*SubscriptionResponse) []*SubscriptionResponse {
+	subs := make([]*models.Subscription, len(responses))
+	for i, resp := range responses {
+		subs[i] = &resp.Subscription
+	}
+	spend := h.service.LifetimeSpend(userID, subs)
+	for _, resp := range responses {
+		amount := spend[resp.ID.String()]
+		resp.LifetimeSpend = &amount
+	}
+	return responses
 }
 
 // getUserID extracts the authenticated user ID from Fiber context.
@@ -84,7 +105,10 @@ func (h *SubscriptionHandler) GetAll(c *fiber.Ctx) error {
 		return utils.Error(c, utils.ErrInternal(""))
 	}
 
-	responses := toSubscriptionResponses(subs)
+	responses := h.toSubscriptionResponses(userID, subs)
+	if filter.SortBy == services.SubscriptionSortLifetimeSpend {
+		responses = h.withLifetimeSpend(userID, responses)
+	}
 
 	// Apply filter defaults for pagination meta.
 	filter.Defaults()
@@ -113,7 +137,7 @@ func (h *SubscriptionHandler) Create(c *fiber.Ctx) error {
 		return utils.Error(c, utils.ErrInternal(""))
 	}
 
-	return utils.Created(c, toSubscriptionResponse(sub))
+	return utils.Created(c, h.toSubscriptionResponse(userID, sub))
 }
 
 // GetByID handles GET /api/v1/subscriptions/:id.
@@ -136,7 +160,8 @@ func (h *SubscriptionHandler) GetByID(c *fiber.Ctx) error {
 		return utils.Error(c, utils.ErrInternal(""))
 	}
 
-	return utils.Success(c, toSubscriptionResponse(sub))
+	responses := h.withLifetimeSpend(userID, h.toSubscriptionResponses(userID, []*models.Subscription{sub}))
+	return utils.Success(c, responses[0])
 }
 
 // Update handles PUT /api/v1/subscriptions/:id.
@@ -165,7 +190,7 @@ func (h *SubscriptionHandler) Update(c *fiber.Ctx) error {
 		return utils.Error(c, utils.ErrInternal(""))
 	}
 
-	return utils.Success(c, toSubscriptionResponse(sub))
+	return utils.Success(c, h.toSubscriptionResponse(userID, sub))
 }
 
 // Delete handles DELETE /api/v1/subscriptions/:id.
@@ -239,5 +264,5 @@ func (h *SubscriptionHandler) UpdateSatisfaction(c *fiber.Ctx) error {
 		return utils.Error(c, utils.ErrInternal(""))
 	}
 
-	return utils.Success(c, toSubscriptionResponse(sub))
+	return utils.Success(c, h.toSubscriptionResponse(userID, sub))
 }
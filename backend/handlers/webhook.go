@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/services"
+	"github.com/subkeep/backend/utils"
+)
+
+// WebhookHandler handles webhook endpoint and delivery log HTTP requests.
+type WebhookHandler struct {
+	service *services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(service *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// GetAll handles GET /api/v1/webhooks.
+func (h *WebhookHandler) GetAll(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	endpoints, svcErr := h.service.GetEndpoints(userID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, endpoints)
+}
+
+// Create handles POST /api/v1/webhooks.
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	var req services.CreateWebhookEndpointRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("웹훅 엔드포인트 생성 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	endpoint, svcErr := h.service.CreateEndpoint(userID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, endpoint)
+}
+
+// Update handles PUT /api/v1/webhooks/:id.
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	endpointID := c.Params("id")
+	if endpointID == "" {
+		return utils.Error(c, utils.ErrBadRequest("웹훅 엔드포인트 ID가 필요합니다"))
+	}
+
+	var req services.UpdateWebhookEndpointRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		slog.Debug("웹훅 엔드포인트 수정 요청 파싱 실패", "error", parseErr)
+		return utils.Error(c, utils.ErrBadRequest("요청 본문을 파싱할 수 없습니다"))
+	}
+
+	endpoint, svcErr := h.service.UpdateEndpoint(userID, endpointID, &req)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, endpoint)
+}
+
+// Delete handles DELETE /api/v1/webhooks/:id.
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	endpointID := c.Params("id")
+	if endpointID == "" {
+		return utils.Error(c, utils.ErrBadRequest("웹훅 엔드포인트 ID가 필요합니다"))
+	}
+
+	if svcErr := h.service.DeleteEndpoint(userID, endpointID); svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.NoContent(c)
+}
+
+// RotateSecret handles POST /api/v1/webhooks/:id/rotate-secret.
+func (h *WebhookHandler) RotateSecret(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	endpointID := c.Params("id")
+	if endpointID == "" {
+		return utils.Error(c, utils.ErrBadRequest("웹훅 엔드포인트 ID가 필요합니다"))
+	}
+
+	endpoint, svcErr := h.service.RotateSecret(userID, endpointID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Success(c, endpoint)
+}
+
+// GetDeliveries handles GET /api/v1/webhooks/deliveries.
+// Query params: endpointId, status (pending|succeeded|failed), page, perPage.
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("perPage", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 || perPage > 100 {
+		perPage = 20
+	}
+
+	status := c.Query("status")
+	switch models.WebhookDeliveryStatus(status) {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliverySucceeded, models.WebhookDeliveryFailed:
+	default:
+		return utils.Error(c, utils.ErrBadRequest("status는 pending, succeeded, failed 중 하나여야 합니다"))
+	}
+
+	filter := repositories.WebhookDeliveryFilter{
+		EndpointID: c.Query("endpointId"),
+		Status:     status,
+		Page:       page,
+		PerPage:    perPage,
+	}
+
+	deliveries, total, svcErr := h.service.GetDeliveries(userID, filter)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Paginated(c, deliveries, page, perPage, total)
+}
+
+// Redeliver handles POST /api/v1/webhooks/deliveries/:id/redeliver.
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return utils.Error(c, err.(*utils.AppError))
+	}
+
+	deliveryID := c.Params("id")
+	if deliveryID == "" {
+		return utils.Error(c, utils.ErrBadRequest("웹훅 발송 ID가 필요합니다"))
+	}
+
+	delivery, svcErr := h.service.Redeliver(c.UserContext(), userID, deliveryID)
+	if svcErr != nil {
+		if appErr, ok := svcErr.(*utils.AppError); ok {
+			return utils.Error(c, appErr)
+		}
+		return utils.Error(c, utils.ErrInternal(""))
+	}
+
+	return utils.Created(c, delivery)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -92,18 +93,144 @@ func main() {
 	catRepo := repositories.NewCategoryRepository(db)
 	shareGroupRepo := repositories.NewShareGroupRepository(db)
 	subShareRepo := repositories.NewSubscriptionShareRepository(db)
+	priceChangeRepo := repositories.NewPriceChangeRepository(db)
+	statusChangeRepo := repositories.NewStatusChangeRepository(db)
+	satisfactionChangeRepo := repositories.NewSatisfactionChangeRepository(db)
+	notificationRuleRepo := repositories.NewNotificationRuleRepository(db)
+	notificationDeliveryRepo := repositories.NewNotificationDeliveryRepository(db)
+	webhookEndpointRepo := repositories.NewWebhookEndpointRepository(db)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository(db)
+	digestScheduleRepo := repositories.NewDigestScheduleRepository(db)
+	vapidKeyRepo := repositories.NewVAPIDKeyRepository(db)
+	pushSubscriptionRepo := repositories.NewPushSubscriptionRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	budgetRepo := repositories.NewBudgetRepository(db)
+	sinkingFundRepo := repositories.NewSinkingFundRepository(db)
+	cancellationSavingRepo := repositories.NewCancellationSavingRepository(db)
+	recommendationRuleRepo := repositories.NewRecommendationRuleRepository(db)
+	planAlternativeRepo := repositories.NewPlanAlternativeRepository(db)
+
+	// Load public holiday calendar for business-day adjustment.
+	holidays, err := services.LoadHolidayCalendar(cfg.Holiday.DataFile)
+	if err != nil {
+		slog.Error("failed to load holiday calendar", "file", cfg.Holiday.DataFile, "error", err)
+		os.Exit(1)
+	}
+
+	// Load the catalog of well-known services for functional grouping.
+	catalog, err := services.LoadServiceCatalog(cfg.Catalog.DataFile)
+	if err != nil {
+		slog.Error("failed to load service catalog", "file", cfg.Catalog.DataFile, "error", err)
+		os.Exit(1)
+	}
+
+	// VAPID keys identify this server to browser push services.
+	vapidKeys, err := services.LoadOrCreateVAPIDKeys(vapidKeyRepo)
+	if err != nil {
+		slog.Error("failed to load VAPID keys", "error", err)
+		os.Exit(1)
+	}
+
+	// Computed dashboard, report and calendar aggregates are cached in Redis,
+	// or in process memory when Redis is disabled or unreachable.
+	cacheBackend, closeCache := newCacheBackend(&cfg.Redis)
+	defer closeCache()
+	aggregateCache := services.NewAggregateCache(cacheBackend, cfg.Redis.TTL)
+
+	// Resolve "today" in each user's own time zone.
+	userClock := services.NewUserClock(services.SystemClock(), userRepo)
+
+	// Outbound webhooks receive lifecycle events from the services below.
+	webhookDispatcher := services.NewWebhookDispatcher(
+		webhookEndpointRepo, webhookDeliveryRepo, subRepo, subShareRepo,
+		holidays, userClock, nil, cfg.Webhook,
+	)
+
+	// The in-app inbox receives entries from the services below.
+	inboxService := services.NewInboxService(notificationRepo, nil)
 
 	// Initialize services.
-	authService := services.NewAuthService(userRepo, cfg.JWT)
+	authService := services.NewAuthService(userRepo, cfg.JWT, userClock)
 	oauthService := services.NewOAuthService(cfg.OAuth)
-	subService := services.NewSubscriptionService(subRepo)
-	dashboardService := services.NewDashboardService(subRepo, subShareRepo)
-	simService := services.NewSimulationService(subRepo, subShareRepo)
-	calendarService := services.NewCalendarService(subRepo, subShareRepo)
-	catService := services.NewCategoryService(catRepo)
-	shareGroupService := services.NewShareGroupService(shareGroupRepo)
-	subShareService := services.NewSubscriptionShareService(subShareRepo, subRepo, shareGroupRepo)
-	reportService := services.NewReportService(subRepo, subShareRepo)
+	subService := services.NewSubscriptionServiceWithDeps(subRepo, services.SubscriptionDeps{
+		PriceRepo:  priceChangeRepo,
+		Clock:      userClock,
+		Events:     webhookDispatcher,
+		Inbox:      inboxService,
+		Catalog:    catalog,
+		Cache:      aggregateCache,
+		ShareRepo:  subShareRepo,
+		StatusRepo: statusChangeRepo,
+		ScoreRepo:  satisfactionChangeRepo,
+	})
+	recommendationRuleService := services.NewRecommendationRuleService(recommendationRuleRepo, userClock, aggregateCache)
+	planAlternativeService := services.NewPlanAlternativeService(planAlternativeRepo, subRepo, aggregateCache)
+	dashboardService := services.NewDashboardServiceWithDeps(subRepo, subShareRepo, services.DashboardDeps{
+		Inbox:   inboxService,
+		Budgets: budgetRepo,
+		Rules:   recommendationRuleService,
+		Catalog: catalog,
+		AltRepo: planAlternativeRepo,
+		Cache:   aggregateCache,
+		Clock:   userClock,
+	})
+	simService := services.NewSimulationServiceWithDeps(subRepo, subShareRepo, services.SimulationDeps{
+		Clock:   userClock,
+		Events:  webhookDispatcher,
+		Budgets: budgetRepo,
+		Savings: cancellationSavingRepo,
+		Cache:   aggregateCache,
+	})
+	calendarService := services.NewCalendarServiceWithDeps(subRepo, subShareRepo, services.CalendarDeps{
+		Holidays: holidays,
+		Clock:    userClock,
+		Cache:    aggregateCache,
+	})
+	catService := services.NewCategoryServiceWithDeps(catRepo, services.CategoryDeps{
+		Cache: aggregateCache,
+	})
+	budgetService := services.NewBudgetService(budgetRepo, catRepo, subRepo, subShareRepo, aggregateCache)
+	sinkingFundService := services.NewSinkingFundService(sinkingFundRepo, subRepo, subShareRepo, userClock)
+	shareGroupService := services.NewShareGroupServiceWithDeps(shareGroupRepo, services.ShareGroupDeps{
+		Cache: aggregateCache,
+	})
+	subShareService := services.NewSubscriptionShareService(subShareRepo, subRepo, shareGroupRepo, webhookDispatcher, aggregateCache)
+	reportService := services.NewReportServiceWithDeps(subRepo, subShareRepo, services.ReportDeps{
+		Clock:      userClock,
+		Budgets:    budgetRepo,
+		Savings:    cancellationSavingRepo,
+		Cache:      aggregateCache,
+		PriceRepo:  priceChangeRepo,
+		StatusRepo: statusChangeRepo,
+		ScoreRepo:  satisfactionChangeRepo,
+	})
+	reportExporter, err := services.NewReportExporter(reportService, cfg.Report.FontFile)
+	if err != nil {
+		slog.Error("failed to load report font", "file", cfg.Report.FontFile, "error", err)
+		os.Exit(1)
+	}
+	forecastService := services.NewForecastService(subRepo, subShareRepo, holidays, userClock)
+	notificationRuleService := services.NewNotificationRuleService(notificationRuleRepo, notificationDeliveryRepo, subRepo)
+	webhookService := services.NewWebhookService(webhookEndpointRepo, webhookDeliveryRepo, webhookDispatcher)
+	digestService := services.NewDigestService(digestScheduleRepo, subRepo, dashboardService, calendarService, reportService, userClock)
+
+	// Notification channels. Email is only available when SMTP is configured.
+	pushNotifier := services.NewPushNotifier(pushSubscriptionRepo, vapidKeys, nil, nil, cfg.Push)
+	notifiers := map[models.NotificationChannel]services.Notifier{
+		models.NotificationChannelLog:     services.NewLogNotifier(),
+		models.NotificationChannelWebhook: services.NewWebhookNotifier(nil),
+		models.NotificationChannelPush:    pushNotifier,
+	}
+	if cfg.Notify.SMTP.Host != "" {
+		notifiers[models.NotificationChannelEmail] = services.NewSMTPNotifier(cfg.Notify.SMTP)
+	}
+	notificationScheduler := services.NewNotificationScheduler(
+		notificationRuleRepo, notificationDeliveryRepo, subRepo, priceChangeRepo, userRepo, subShareRepo,
+		notifiers, holidays, userClock, inboxService, cfg.Notify,
+	)
+	pushService := services.NewPushService(pushSubscriptionRepo, pushNotifier, vapidKeys)
+	digestScheduler := services.NewDigestScheduler(digestScheduleRepo, userRepo, digestService, notifiers, userClock, cfg.Digest)
+	recommendationScheduler := services.NewRecommendationScheduler(subRepo, dashboardService, cfg.Recommend)
 
 	// Initialize handlers.
 	authHandler := handlers.NewAuthHandler(authService, oauthService)
@@ -112,9 +239,19 @@ func main() {
 	simHandler := handlers.NewSimulationHandler(simService)
 	calendarHandler := handlers.NewCalendarHandler(calendarService)
 	catHandler := handlers.NewCategoryHandler(catService)
+	budgetHandler := handlers.NewBudgetHandler(budgetService)
+	sinkingFundHandler := handlers.NewSinkingFundHandler(sinkingFundService)
 	shareGroupHandler := handlers.NewShareGroupHandler(shareGroupService)
 	subShareHandler := handlers.NewSubscriptionShareHandler(subShareService)
-	reportHandler := handlers.NewReportHandler(reportService)
+	reportHandler := handlers.NewReportHandler(reportService, reportExporter)
+	forecastHandler := handlers.NewForecastHandler(forecastService)
+	notificationRuleHandler := handlers.NewNotificationRuleHandler(notificationRuleService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	digestHandler := handlers.NewDigestHandler(digestService)
+	pushHandler := handlers.NewPushHandler(pushService)
+	notificationHandler := handlers.NewNotificationHandler(inboxService)
+	recommendationRuleHandler := handlers.NewRecommendationRuleHandler(recommendationRuleService)
+	planAlternativeHandler := handlers.NewPlanAlternativeHandler(planAlternativeService)
 
 	// Health check endpoint.
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -130,23 +267,54 @@ func main() {
 			"database": fiber.Map{
 				"status": dbStatus,
 			},
+			"cache": aggregateCache.Stats(),
 		})
 	})
 
 	// Register route handlers.
 	routes.SetupRoutes(app, &routes.Handlers{
-		Auth:              authHandler,
-		Subscription:      subHandler,
-		Dashboard:         dashboardHandler,
-		Simulation:        simHandler,
-		Calendar:          calendarHandler,
-		Category:          catHandler,
-		ShareGroup:        shareGroupHandler,
-		SubscriptionShare: subShareHandler,
-		Report:            reportHandler,
-		AuthService:       authService,
+		Auth:               authHandler,
+		Subscription:       subHandler,
+		Dashboard:          dashboardHandler,
+		Simulation:         simHandler,
+		Calendar:           calendarHandler,
+		Category:           catHandler,
+		Budget:             budgetHandler,
+		SinkingFund:        sinkingFundHandler,
+		ShareGroup:         shareGroupHandler,
+		SubscriptionShare:  subShareHandler,
+		Report:             reportHandler,
+		Forecast:           forecastHandler,
+		NotificationRule:   notificationRuleHandler,
+		Webhook:            webhookHandler,
+		Digest:             digestHandler,
+		Push:               pushHandler,
+		Notification:       notificationHandler,
+		RecommendationRule: recommendationRuleHandler,
+		PlanAlternative:    planAlternativeHandler,
+		AuthService:        authService,
 	})
 
+	// Start background workers.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	if cfg.Notify.Enabled {
+		go notificationScheduler.Start(workerCtx)
+		slog.Info("notification scheduler started", "interval", cfg.Notify.Interval)
+	}
+	if cfg.Webhook.Enabled {
+		go webhookDispatcher.Start(workerCtx)
+		slog.Info("webhook dispatcher started", "pollInterval", cfg.Webhook.PollInterval)
+	}
+	if cfg.Digest.Enabled {
+		go digestScheduler.Start(workerCtx)
+		slog.Info("digest scheduler started", "interval", cfg.Digest.Interval)
+	}
+	if cfg.Recommend.Enabled {
+		go recommendationScheduler.Start(workerCtx)
+		slog.Info("recommendation scheduler started", "interval", cfg.Recommend.Interval)
+	}
+
 	// Graceful shutdown.
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -162,6 +330,7 @@ func main() {
 
 	sig := <-quit
 	slog.Info("shutting down server", "signal", sig.String())
+	stopWorkers()
 
 	shutdownTimeout := 10 * time.Second
 	if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
@@ -171,6 +340,28 @@ func main() {
 	slog.Info("server stopped gracefully")
 }
 
+// newCacheBackend connects to Redis for the aggregate cache, falling back to
+// an in-memory cache when Redis is disabled or unreachable. The returned
+// function releases the connection.
+func newCacheBackend(cfg *config.RedisConfig) (services.Cache, func()) {
+	if !cfg.Enabled {
+		slog.Info("redis disabled, using in-memory aggregate cache")
+		return services.NewMemoryCache(nil), func() {}
+	}
+
+	client, err := database.ConnectRedis(cfg)
+	if err != nil {
+		slog.Warn("failed to connect to redis, using in-memory aggregate cache", "addr", cfg.Addr(), "error", err)
+		return services.NewMemoryCache(nil), func() {}
+	}
+
+	return services.NewRedisCache(client), func() {
+		if closeErr := client.Close(); closeErr != nil {
+			slog.Error("failed to close redis connection", "error", closeErr)
+		}
+	}
+}
+
 // errorHandler is the custom Fiber error handler.
 func errorHandler(c *fiber.Ctx, err error) error {
 	// Handle AppError.
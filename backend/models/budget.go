@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultBudgetAlertPercent is the share of a budget at which progress is
+// reported as a warning.
+const DefaultBudgetAlertPercent = 80
+
+// Budget caps a user's monthly subscription spending, either overall
+// (CategoryID nil) or for a single category. Limits apply to personal amounts
+// after share splits.
+type Budget struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"userId"`
+	CategoryID   *uuid.UUID `gorm:"type:uuid;index" json:"categoryId"`
+	MonthlyLimit int        `gorm:"type:int;not null" json:"monthlyLimit" validate:"required,gt=0"`
+	AlertPercent int        `gorm:"type:int;not null;default:80" json:"alertPercent" validate:"gte=1,lte=100"`
+	CreatedAt    time.Time  `gorm:"not null" json:"createdAt"`
+	UpdatedAt    time.Time  `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	User     User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Category *Category `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE" json:"category,omitempty"`
+}
+
+// TableName overrides the default table name.
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (b *Budget) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsOverall reports whether the budget covers all subscriptions.
+func (b *Budget) IsOverall() bool {
+	return b.CategoryID == nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CancellationSaving records a subscription cancelled through a simulation and
+// the personal monthly amount that the cancellation removed. ServiceName is a
+// snapshot so the ledger stays readable after the subscription is gone.
+type CancellationSaving struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index" json:"subscriptionId"`
+	ServiceName    string    `gorm:"type:varchar(100);not null" json:"serviceName"`
+	MonthlyAmount  int       `gorm:"type:int;not null" json:"monthlyAmount"`
+	CancelledOn    time.Time `gorm:"type:date;not null" json:"cancelledOn"`
+	CreatedAt      time.Time `gorm:"not null" json:"createdAt"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (CancellationSaving) TableName() string {
+	return "cancellation_savings"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (c *CancellationSaving) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
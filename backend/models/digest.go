@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DigestFrequency represents how often a spending digest is delivered.
+type DigestFrequency string
+
+const (
+	DigestFrequencyWeekly  DigestFrequency = "weekly"
+	DigestFrequencyMonthly DigestFrequency = "monthly"
+)
+
+// DigestSchedule is a user's spending digest delivery preference.
+// Weekly digests go out on DayOfWeek (0 = Sunday), monthly digests on
+// DayOfMonth, both at Hour in the user's time zone.
+//
+// LastPeriodKey identifies the last period delivered so a digest is sent once
+// per period; LastSnapshot holds the subscriptions as of that digest and is
+// the baseline for the next digest's change list.
+type DigestSchedule struct {
+	ID            uuid.UUID           `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID        uuid.UUID           `gorm:"type:uuid;not null;uniqueIndex" json:"userId"`
+	Frequency     DigestFrequency     `gorm:"type:varchar(20);not null;default:'weekly'" json:"frequency" validate:"required,oneof=weekly monthly"`
+	DayOfWeek     int                 `gorm:"type:int;not null;default:1" json:"dayOfWeek" validate:"gte=0,lte=6"`
+	DayOfMonth    int                 `gorm:"type:int;not null;default:1" json:"dayOfMonth" validate:"gte=1,lte=28"`
+	Hour          int                 `gorm:"type:int;not null;default:9" json:"hour" validate:"gte=0,lte=23"`
+	Channel       NotificationChannel `gorm:"type:varchar(20);not null;default:'email'" json:"channel" validate:"required,oneof=email webhook log push"`
+	Target        *string             `gorm:"type:varchar(500)" json:"target"`
+	Enabled       bool                `gorm:"not null;default:true" json:"enabled"`
+	LastPeriodKey string              `gorm:"type:varchar(20);not null;default:''" json:"lastPeriodKey"`
+	LastSentAt    *time.Time          `json:"lastSentAt"`
+	LastSnapshot  string              `gorm:"type:text;not null;default:''" json:"-"`
+	CreatedAt     time.Time           `gorm:"not null" json:"createdAt"`
+	UpdatedAt     time.Time           `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (DigestSchedule) TableName() string {
+	return "digest_schedules"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (d *DigestSchedule) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationType categorises in-app inbox notifications.
+type NotificationType string
+
+const (
+	NotificationTypeBillingReminder NotificationType = "billing_reminder"
+	NotificationTypeTrialEnding     NotificationType = "trial_ending"
+	NotificationTypePriceChanged    NotificationType = "price_changed"
+	NotificationTypeShareSettlement NotificationType = "share_settlement"
+	NotificationTypeRecommendation  NotificationType = "recommendation"
+)
+
+// NotificationTypes lists every inbox notification type.
+var NotificationTypes = []NotificationType{
+	NotificationTypeBillingReminder,
+	NotificationTypeTrialEnding,
+	NotificationTypePriceChanged,
+	NotificationTypeShareSettlement,
+	NotificationTypeRecommendation,
+}
+
+// Notification is an entry in a user's in-app inbox.
+// DedupKey is unique per user so that the same event is stored only once even
+// when it is produced repeatedly. A nil ReadAt means the entry is unread.
+type Notification struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_notifications_user_dedup;index:idx_notifications_user_created,priority:1" json:"userId"`
+	Type           NotificationType `gorm:"type:varchar(30);not null" json:"type"`
+	Title          string           `gorm:"type:varchar(200);not null" json:"title"`
+	Body           string           `gorm:"type:text;not null" json:"body"`
+	SubscriptionID *uuid.UUID       `gorm:"type:uuid;index" json:"subscriptionId"`
+	DedupKey       string           `gorm:"type:varchar(200);not null;uniqueIndex:idx_notifications_user_dedup" json:"-"`
+	ReadAt         *time.Time       `json:"readAt"`
+	CreatedAt      time.Time        `gorm:"not null;index:idx_notifications_user_created,priority:2" json:"createdAt"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsRead reports whether the notification has been read.
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
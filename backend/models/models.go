@@ -2,6 +2,7 @@ package models
 
 import (
 	"log"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -24,6 +25,77 @@ const (
 	SubscriptionStatusCancelled SubscriptionStatus = "cancelled"
 )
 
+// BusinessDayAdjustment represents how a billing date falling on a weekend or
+// public holiday is shifted to the date the card charge is actually posted.
+type BusinessDayAdjustment string
+
+const (
+	BusinessDayAdjustmentNone     BusinessDayAdjustment = "none"
+	BusinessDayAdjustmentNext     BusinessDayAdjustment = "next_business_day"
+	BusinessDayAdjustmentPrevious BusinessDayAdjustment = "previous_business_day"
+)
+
+// FunctionalGroup classifies subscriptions by what they are used for, so that
+// services doing the same job can be detected regardless of category.
+type FunctionalGroup string
+
+const (
+	FunctionalGroupMusic        FunctionalGroup = "music"
+	FunctionalGroupVideo        FunctionalGroup = "video"
+	FunctionalGroupStorage      FunctionalGroup = "storage"
+	FunctionalGroupAIAssistant  FunctionalGroup = "ai_assistant"
+	FunctionalGroupProductivity FunctionalGroup = "productivity"
+	FunctionalGroupNews         FunctionalGroup = "news"
+	FunctionalGroupGaming       FunctionalGroup = "gaming"
+	FunctionalGroupEducation    FunctionalGroup = "education"
+	FunctionalGroupFitness      FunctionalGroup = "fitness"
+	FunctionalGroupShopping     FunctionalGroup = "shopping"
+)
+
+// functionalGroupLabels maps each functional group to its display name.
+var functionalGroupLabels = map[FunctionalGroup]string{
+	FunctionalGroupMusic:        "음악 스트리밍",
+	FunctionalGroupVideo:        "동영상 스트리밍",
+	FunctionalGroupStorage:      "클라우드 저장소",
+	FunctionalGroupAIAssistant:  "AI 어시스턴트",
+	FunctionalGroupProductivity: "생산성 도구",
+	FunctionalGroupNews:         "뉴스/매거진",
+	FunctionalGroupGaming:       "게임",
+	FunctionalGroupEducation:    "교육",
+	FunctionalGroupFitness:      "피트니스",
+	FunctionalGroupShopping:     "쇼핑 멤버십",
+}
+
+// IsValid reports whether g is a known functional group.
+func (g FunctionalGroup) IsValid() bool {
+	_, ok := functionalGroupLabels[g]
+	return ok
+}
+
+// Label returns the display name of the functional group.
+func (g FunctionalGroup) Label() string {
+	if label, ok := functionalGroupLabels[g]; ok {
+		return label
+	}
+	return string(g)
+}
+
+// DefaultTimezone is the IANA time zone assigned to users who have not chosen one.
+const DefaultTimezone = "Asia/Seoul"
+
+// kstOffset is the fixed UTC offset of Korea Standard Time, used when the
+// system time zone database is unavailable.
+const kstOffset = 9 * 60 * 60
+
+// DefaultLocation returns the *time.Location for DefaultTimezone.
+func DefaultLocation() *time.Location {
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		return time.FixedZone("KST", kstOffset)
+	}
+	return loc
+}
+
 // SplitType represents how a shared subscription cost is divided.
 type SplitType string
 
@@ -53,6 +125,22 @@ func AutoMigrateAll(db *gorm.DB) error {
 		&ShareGroup{},
 		&ShareMember{},
 		&SubscriptionShare{},
+		&PriceChange{},
+		&StatusChange{},
+		&SatisfactionChange{},
+		&NotificationRule{},
+		&NotificationDelivery{},
+		&WebhookEndpoint{},
+		&WebhookDelivery{},
+		&DigestSchedule{},
+		&VAPIDKey{},
+		&PushSubscription{},
+		&Notification{},
+		&Budget{},
+		&CancellationSaving{},
+		&RecommendationRule{},
+		&PlanAlternative{},
+		&SinkingFundContribution{},
 	)
 }
 
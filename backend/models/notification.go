@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationRuleType represents the kind of event a notification rule watches.
+type NotificationRuleType string
+
+const (
+	NotificationRuleBillingUpcoming NotificationRuleType = "billing_upcoming"
+	NotificationRuleTrialEnding     NotificationRuleType = "trial_ending"
+	NotificationRulePriceChanged    NotificationRuleType = "price_changed"
+
+	// NotificationEventDigest marks scheduled spending digests. It is an
+	// event name only and cannot be used as a rule type.
+	NotificationEventDigest NotificationRuleType = "digest"
+)
+
+// NotificationChannel represents how a notification is delivered.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+	NotificationChannelLog     NotificationChannel = "log"
+	NotificationChannelPush    NotificationChannel = "push"
+)
+
+// NotificationDeliveryStatus represents the outcome of a delivery attempt.
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryPending NotificationDeliveryStatus = "pending"
+	NotificationDeliverySent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryFailed  NotificationDeliveryStatus = "failed"
+)
+
+// NotificationRule is a user-defined alert condition.
+// A nil SubscriptionID applies the rule to all of the user's subscriptions.
+type NotificationRule struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID            `gorm:"type:uuid;not null;index" json:"userId"`
+	SubscriptionID *uuid.UUID           `gorm:"type:uuid;index" json:"subscriptionId"`
+	Type           NotificationRuleType `gorm:"type:varchar(30);not null" json:"type" validate:"required,oneof=billing_upcoming trial_ending price_changed"`
+	DaysBefore     int                  `gorm:"type:int;not null;default:0" json:"daysBefore" validate:"gte=0,lte=30"`
+	Channel        NotificationChannel  `gorm:"type:varchar(20);not null" json:"channel" validate:"required,oneof=email webhook log push"`
+	Target         *string              `gorm:"type:varchar(500)" json:"target"`
+	Enabled        bool                 `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt      time.Time            `gorm:"not null" json:"createdAt"`
+	UpdatedAt      time.Time            `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	User         User          `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Subscription *Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (NotificationRule) TableName() string {
+	return "notification_rules"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (r *NotificationRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// NotificationDelivery records a notification produced by a rule.
+// DedupKey is unique per rule and triggering event, so an event is never
+// delivered twice even across scheduler restarts.
+type NotificationDelivery struct {
+	ID             uuid.UUID                  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RuleID         uuid.UUID                  `gorm:"type:uuid;not null;index" json:"ruleId"`
+	UserID         uuid.UUID                  `gorm:"type:uuid;not null;index" json:"userId"`
+	SubscriptionID *uuid.UUID                 `gorm:"type:uuid" json:"subscriptionId"`
+	DedupKey       string                     `gorm:"type:varchar(255);not null;uniqueIndex" json:"dedupKey"`
+	Channel        NotificationChannel        `gorm:"type:varchar(20);not null" json:"channel"`
+	Target         string                     `gorm:"type:varchar(500)" json:"target"`
+	Subject        string                     `gorm:"type:varchar(255);not null" json:"subject"`
+	Body           string                     `gorm:"type:text;not null" json:"body"`
+	Status         NotificationDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts       int                        `gorm:"type:int;not null;default:0" json:"attempts"`
+	LastError      *string                    `gorm:"type:text" json:"lastError"`
+	SentAt         *time.Time                 `json:"sentAt"`
+	CreatedAt      time.Time                  `gorm:"not null" json:"createdAt"`
+	UpdatedAt      time.Time                  `gorm:"not null" json:"updatedAt"`
+}
+
+// TableName overrides the default table name.
+func (NotificationDelivery) TableName() string {
+	return "notification_deliveries"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (d *NotificationDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
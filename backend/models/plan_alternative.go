@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlanAlternative is a user-entered price of a subscription under another
+// billing cycle, e.g. the annual price of a service the user pays monthly.
+type PlanAlternative struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SubscriptionID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_plan_alternatives_sub_cycle" json:"subscriptionId"`
+	BillingCycle   BillingCycle `gorm:"type:varchar(20);not null;uniqueIndex:idx_plan_alternatives_sub_cycle" json:"billingCycle"`
+	Amount         int          `gorm:"type:int;not null" json:"amount"`
+	CreatedAt      time.Time    `gorm:"not null" json:"createdAt"`
+	UpdatedAt      time.Time    `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	Subscription Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (PlanAlternative) TableName() string {
+	return "plan_alternatives"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (p *PlanAlternative) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PriceChange records a change to a subscription's amount or billing cycle.
+// EffectiveDate is the user's local date on which the change was made.
+type PriceChange struct {
+	ID              uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SubscriptionID  uuid.UUID    `gorm:"type:uuid;not null;index" json:"subscriptionId"`
+	UserID          uuid.UUID    `gorm:"type:uuid;not null;index" json:"userId"`
+	OldAmount       int          `gorm:"type:int;not null" json:"oldAmount"`
+	NewAmount       int          `gorm:"type:int;not null" json:"newAmount"`
+	OldBillingCycle BillingCycle `gorm:"type:varchar(20);not null" json:"oldBillingCycle"`
+	NewBillingCycle BillingCycle `gorm:"type:varchar(20);not null" json:"newBillingCycle"`
+	EffectiveDate   time.Time    `gorm:"type:date;not null" json:"effectiveDate"`
+	CreatedAt       time.Time    `gorm:"not null" json:"createdAt"`
+
+	// Associations
+	Subscription Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (PriceChange) TableName() string {
+	return "price_changes"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (p *PriceChange) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VAPIDKey is the server's application-server key pair used to identify
+// itself to browser push services (RFC 8292). Keys are base64url-encoded
+// without padding: PublicKey is the 65-byte uncompressed P-256 point and
+// PrivateKey the 32-byte scalar.
+type VAPIDKey struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PublicKey  string    `gorm:"type:varchar(100);not null" json:"publicKey"`
+	PrivateKey string    `gorm:"type:varchar(100);not null" json:"-"`
+	CreatedAt  time.Time `gorm:"not null" json:"createdAt"`
+}
+
+// TableName overrides the default table name.
+func (VAPIDKey) TableName() string {
+	return "vapid_keys"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (k *VAPIDKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// PushSubscription is a browser push subscription registered by one of a
+// user's devices. P256dh and Auth are the base64url-encoded keys from the
+// browser's PushSubscription.getKey().
+type PushSubscription struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"userId"`
+	Endpoint   string     `gorm:"type:varchar(1000);not null;uniqueIndex" json:"endpoint"`
+	P256dh     string     `gorm:"type:varchar(200);not null" json:"-"`
+	Auth       string     `gorm:"type:varchar(100);not null" json:"-"`
+	DeviceName *string    `gorm:"type:varchar(100)" json:"deviceName"`
+	UserAgent  *string    `gorm:"type:varchar(500)" json:"userAgent"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `gorm:"not null" json:"createdAt"`
+	UpdatedAt  time.Time  `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (PushSubscription) TableName() string {
+	return "push_subscriptions"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (p *PushSubscription) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Built-in recommendation rule keys.
+const (
+	RecommendationRuleLowSatisfaction         = "low_satisfaction"
+	RecommendationRuleHighCostLowSatisfaction = "high_cost_low_satisfaction"
+)
+
+// RecommendationRule is a user-tunable condition for recommending that a
+// subscription be cancelled. Every non-nil condition must hold for the rule to
+// fire; a rule without conditions never fires.
+//
+// Key identifies a built-in rule; built-in rules can be tuned or disabled but
+// not deleted. ReasonTemplate may contain placeholders such as {serviceName}
+// that are filled in per subscription.
+type RecommendationRule struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_recommendation_rules_user_key" json:"userId"`
+	Key            *string   `gorm:"type:varchar(50);uniqueIndex:idx_recommendation_rules_user_key" json:"key"`
+	Name           string    `gorm:"type:varchar(100);not null" json:"name" validate:"required,min=1,max=100"`
+	Enabled        bool      `gorm:"not null;default:true" json:"enabled"`
+	Weight         float64   `gorm:"type:numeric(6,2);not null;default:1" json:"weight" validate:"gt=0,lte=100"`
+	ReasonTemplate string    `gorm:"type:varchar(200);not null" json:"reasonTemplate" validate:"required,max=200"`
+
+	// Conditions
+	MaxSatisfaction     *int     `gorm:"type:int" json:"maxSatisfaction" validate:"omitempty,gte=1,lte=5"`
+	MinMonthlyAmount    *int     `gorm:"type:int" json:"minMonthlyAmount" validate:"omitempty,gte=0"`
+	MaxMonthlyAmount    *int     `gorm:"type:int" json:"maxMonthlyAmount" validate:"omitempty,gte=0"`
+	MinCostPercentile   *float64 `gorm:"type:numeric(5,2)" json:"minCostPercentile" validate:"omitempty,gte=0,lt=100"`
+	MinTenureDays       *int     `gorm:"type:int" json:"minTenureDays" validate:"omitempty,gte=0"`
+	MinDaysSinceBilling *int     `gorm:"type:int" json:"minDaysSinceBilling" validate:"omitempty,gte=0"`
+
+	SortOrder int       `gorm:"type:int;not null;default:0" json:"sortOrder"`
+	CreatedAt time.Time `gorm:"not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (RecommendationRule) TableName() string {
+	return "recommendation_rules"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (r *RecommendationRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsBuiltIn reports whether the rule is one of the default rules.
+func (r *RecommendationRule) IsBuiltIn() bool {
+	return r.Key != nil
+}
+
+// HasConditions reports whether at least one condition is set.
+func (r *RecommendationRule) HasConditions() bool {
+	return r.MaxSatisfaction != nil || r.MinMonthlyAmount != nil || r.MaxMonthlyAmount != nil ||
+		r.MinCostPercentile != nil || r.MinTenureDays != nil || r.MinDaysSinceBilling != nil
+}
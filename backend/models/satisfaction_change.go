@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SatisfactionChange records a change to a subscription's satisfaction score,
+// so that reports can show how the user's opinion of it moved over time.
+// A nil score means the subscription was unrated.
+// EffectiveDate is the user's local date on which the change was made.
+type SatisfactionChange struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index" json:"subscriptionId"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	OldScore       *int      `gorm:"type:int" json:"oldScore"`
+	NewScore       *int      `gorm:"type:int" json:"newScore"`
+	EffectiveDate  time.Time `gorm:"type:date;not null" json:"effectiveDate"`
+	CreatedAt      time.Time `gorm:"not null" json:"createdAt"`
+
+	// Associations
+	Subscription Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (SatisfactionChange) TableName() string {
+	return "satisfaction_changes"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (c *SatisfactionChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
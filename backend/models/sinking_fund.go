@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SinkingFundContribution records money the user set aside towards the next
+// renewal of a long-cycle subscription.
+type SinkingFundContribution struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index" json:"subscriptionId"`
+	Amount         int       `gorm:"type:int;not null" json:"amount"`
+	ContributedOn  time.Time `gorm:"type:date;not null" json:"contributedOn"`
+	Note           *string   `gorm:"type:varchar(200)" json:"note"`
+	CreatedAt      time.Time `gorm:"not null" json:"createdAt"`
+
+	// Associations
+	User         User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Subscription Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (SinkingFundContribution) TableName() string {
+	return "sinking_fund_contributions"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (c *SinkingFundContribution) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusChange records a change to a subscription's status, so that reports
+// can tell which months a subscription was billed in.
+// EffectiveDate is the user's local date on which the change was made.
+type StatusChange struct {
+	ID             uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SubscriptionID uuid.UUID          `gorm:"type:uuid;not null;index" json:"subscriptionId"`
+	UserID         uuid.UUID          `gorm:"type:uuid;not null;index" json:"userId"`
+	OldStatus      SubscriptionStatus `gorm:"type:varchar(20);not null" json:"oldStatus"`
+	NewStatus      SubscriptionStatus `gorm:"type:varchar(20);not null" json:"newStatus"`
+	EffectiveDate  time.Time          `gorm:"type:date;not null" json:"effectiveDate"`
+	CreatedAt      time.Time          `gorm:"not null" json:"createdAt"`
+
+	// Associations
+	Subscription Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (StatusChange) TableName() string {
+	return "status_changes"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (c *StatusChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
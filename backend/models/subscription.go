@@ -24,6 +24,16 @@ type Subscription struct {
 	Note            *string            `gorm:"type:text" json:"note" validate:"omitempty,max=500"`
 	ServiceURL      *string            `gorm:"type:varchar(255)" json:"serviceUrl" validate:"omitempty,url,max=255"`
 	StartDate       time.Time          `gorm:"type:date;not null" json:"startDate" validate:"required"`
+	TrialEndDate    *time.Time         `gorm:"type:date" json:"trialEndDate"`
+	// A promotion bills PromoAmount instead of Amount for charges before PromoEndDate.
+	PromoAmount     *int               `gorm:"type:int" json:"promoAmount" validate:"omitempty,gte=0"`
+	PromoEndDate    *time.Time         `gorm:"type:date" json:"promoEndDate"`
+	// A scheduled pause skips charges from PauseStartDate until PauseEndDate,
+	// or indefinitely when PauseEndDate is nil.
+	PauseStartDate  *time.Time         `gorm:"type:date" json:"pauseStartDate"`
+	PauseEndDate    *time.Time         `gorm:"type:date" json:"pauseEndDate"`
+	BusinessDayAdjustment BusinessDayAdjustment `gorm:"type:varchar(30);not null;default:'none'" json:"businessDayAdjustment" validate:"omitempty,oneof=none next_business_day previous_business_day"`
+	FunctionalGroup *FunctionalGroup `gorm:"type:varchar(30);index" json:"functionalGroup"`
 	CreatedAt       time.Time          `gorm:"not null" json:"createdAt"`
 	UpdatedAt       time.Time          `gorm:"not null" json:"updatedAt"`
 	DeletedAt       gorm.DeletedAt     `gorm:"index" json:"deletedAt,omitempty"`
@@ -63,6 +73,23 @@ func (s *Subscription) MonthlyAmount() int {
 	}
 }
 
+// AmountOn returns the amount charged for a billing on date d, which is the
+// promotional amount while a promotion runs.
+func (s *Subscription) AmountOn(d time.Time) int {
+	if s.PromoAmount != nil && s.PromoEndDate != nil && d.Before(*s.PromoEndDate) {
+		return *s.PromoAmount
+	}
+	return s.Amount
+}
+
+// PauseScheduledOn reports whether a scheduled pause covers date d.
+func (s *Subscription) PauseScheduledOn(d time.Time) bool {
+	if s.PauseStartDate == nil || d.Before(*s.PauseStartDate) {
+		return false
+	}
+	return s.PauseEndDate == nil || d.Before(*s.PauseEndDate)
+}
+
 // AnnualAmount returns the annual-equivalent cost of this subscription.
 func (s *Subscription) AnnualAmount() int {
 	return s.MonthlyAmount() * 12
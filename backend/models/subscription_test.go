@@ -1,6 +1,9 @@
 package models
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestSubscription_MonthlyAmount(t *testing.T) {
 	tests := []struct {
@@ -131,3 +134,45 @@ func TestSubscription_AnnualAmount(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscription_AmountOn(t *testing.T) {
+	promo := 3000
+	promoEnd := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	s := &Subscription{Amount: 10000, PromoAmount: &promo, PromoEndDate: &promoEnd}
+
+	if got := s.AmountOn(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)); got != 3000 {
+		t.Errorf("AmountOn() during promotion = %d, want 3000", got)
+	}
+	if got := s.AmountOn(promoEnd); got != 10000 {
+		t.Errorf("AmountOn() after promotion = %d, want 10000", got)
+	}
+	if got := (&Subscription{Amount: 10000}).AmountOn(promoEnd); got != 10000 {
+		t.Errorf("AmountOn() without promotion = %d, want 10000", got)
+	}
+}
+
+func TestSubscription_PauseScheduledOn(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		sub  *Subscription
+		day  time.Time
+		want bool
+	}{
+		{"no pause", &Subscription{}, start, false},
+		{"before pause", &Subscription{PauseStartDate: &start, PauseEndDate: &end}, start.AddDate(0, 0, -1), false},
+		{"pause start", &Subscription{PauseStartDate: &start, PauseEndDate: &end}, start, true},
+		{"pause end resumes", &Subscription{PauseStartDate: &start, PauseEndDate: &end}, end, false},
+		{"open-ended pause", &Subscription{PauseStartDate: &start}, end.AddDate(1, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sub.PauseScheduledOn(tt.day); got != tt.want {
+				t.Errorf("PauseScheduledOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ type User struct {
 	Email          *string        `gorm:"type:varchar(255)" json:"email" validate:"omitempty,email,max=255"`
 	Nickname       *string        `gorm:"type:varchar(100)" json:"nickname" validate:"omitempty,max=100"`
 	AvatarURL      *string        `gorm:"type:text" json:"avatarUrl" validate:"omitempty,url"`
+	Timezone       string         `gorm:"type:varchar(64);not null;default:'Asia/Seoul'" json:"timezone" validate:"omitempty,timezone"`
 	CreatedAt      time.Time      `gorm:"not null" json:"createdAt"`
 	UpdatedAt      time.Time      `gorm:"not null" json:"updatedAt"`
 	LastLoginAt    *time.Time     `json:"lastLoginAt"`
@@ -31,10 +32,26 @@ func (User) TableName() string {
 	return "users"
 }
 
-// BeforeCreate sets a new UUID before inserting.
+// BeforeCreate sets a new UUID and the default timezone before inserting.
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == uuid.Nil {
 		u.ID = uuid.New()
 	}
+	if u.Timezone == "" {
+		u.Timezone = DefaultTimezone
+	}
 	return nil
 }
+
+// Location returns the user's time zone, falling back to DefaultLocation
+// when the stored name is empty or unknown.
+func (u *User) Location() *time.Location {
+	if u == nil || u.Timezone == "" {
+		return DefaultLocation()
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return DefaultLocation()
+	}
+	return loc
+}
@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestUser_Location(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     *User
+		expected string
+	}{
+		{"explicit timezone", &User{Timezone: "America/New_York"}, "America/New_York"},
+		{"empty timezone uses default", &User{}, DefaultTimezone},
+		{"unknown timezone uses default", &User{Timezone: "Mars/Olympus_Mons"}, DefaultTimezone},
+		{"nil user uses default", nil, DefaultTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.user.Location().String()
+			if got != tt.expected {
+				t.Errorf("Location() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookEventType identifies a lifecycle event delivered to webhook endpoints.
+type WebhookEventType string
+
+const (
+	WebhookEventSubscriptionCreated   WebhookEventType = "subscription.created"
+	WebhookEventSubscriptionUpdated   WebhookEventType = "subscription.updated"
+	WebhookEventSubscriptionCancelled WebhookEventType = "subscription.cancelled"
+	WebhookEventSubscriptionBilled    WebhookEventType = "subscription.billed"
+	WebhookEventShareLinked           WebhookEventType = "share.linked"
+	WebhookEventShareUpdated          WebhookEventType = "share.updated"
+	WebhookEventShareUnlinked         WebhookEventType = "share.unlinked"
+)
+
+// WebhookEventTypes lists every event type an endpoint can subscribe to.
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventSubscriptionCreated,
+	WebhookEventSubscriptionUpdated,
+	WebhookEventSubscriptionCancelled,
+	WebhookEventSubscriptionBilled,
+	WebhookEventShareLinked,
+	WebhookEventShareUpdated,
+	WebhookEventShareUnlinked,
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookEndpoint is a user-registered URL that receives signed event payloads.
+// Events is a comma-separated list of event types; empty means all events.
+type WebhookEndpoint struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	URL         string    `gorm:"type:varchar(500);not null" json:"url"`
+	Secret      string    `gorm:"type:varchar(100);not null" json:"-"`
+	Events      string    `gorm:"type:varchar(500);not null;default:''" json:"-"`
+	Description *string   `gorm:"type:varchar(100)" json:"description"`
+	Enabled     bool      `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt   time.Time `gorm:"not null" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (e *WebhookEndpoint) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// EventTypes returns the event types the endpoint subscribes to.
+// An empty list means the endpoint receives every event.
+func (e *WebhookEndpoint) EventTypes() []WebhookEventType {
+	if e.Events == "" {
+		return []WebhookEventType{}
+	}
+	parts := strings.Split(e.Events, ",")
+	types := make([]WebhookEventType, 0, len(parts))
+	for _, p := range parts {
+		types = append(types, WebhookEventType(p))
+	}
+	return types
+}
+
+// SetEventTypes stores the given event types on the endpoint.
+func (e *WebhookEndpoint) SetEventTypes(types []WebhookEventType) {
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = string(t)
+	}
+	e.Events = strings.Join(parts, ",")
+}
+
+// Subscribes reports whether the endpoint should receive the event type.
+func (e *WebhookEndpoint) Subscribes(eventType WebhookEventType) bool {
+	if e.Events == "" {
+		return true
+	}
+	for _, t := range e.EventTypes() {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is the delivery log entry for one event sent to one endpoint.
+// Redeliveries create a new entry pointing at the original via RedeliveryOf.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	EndpointID     uuid.UUID             `gorm:"type:uuid;not null;index" json:"endpointId"`
+	UserID         uuid.UUID             `gorm:"type:uuid;not null;index" json:"userId"`
+	EventID        uuid.UUID             `gorm:"type:uuid;not null;index" json:"eventId"`
+	EventType      WebhookEventType      `gorm:"type:varchar(50);not null" json:"eventType"`
+	Payload        string                `gorm:"type:text;not null" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	Attempts       int                   `gorm:"type:int;not null;default:0" json:"attempts"`
+	ResponseStatus *int                  `gorm:"type:int" json:"responseStatus"`
+	LastError      *string               `gorm:"type:text" json:"lastError"`
+	NextAttemptAt  *time.Time            `gorm:"index" json:"nextAttemptAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt"`
+	RedeliveryOf   *uuid.UUID            `gorm:"type:uuid" json:"redeliveryOf"`
+	CreatedAt      time.Time             `gorm:"not null" json:"createdAt"`
+	UpdatedAt      time.Time             `gorm:"not null" json:"updatedAt"`
+
+	// Associations
+	Endpoint WebhookEndpoint `gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the default table name.
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// BeforeCreate sets a new UUID before inserting.
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
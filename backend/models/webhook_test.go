@@ -0,0 +1,35 @@
+package models
+
+import "testing"
+
+func TestWebhookEndpoint_Subscribes(t *testing.T) {
+	all := &WebhookEndpoint{}
+	filtered := &WebhookEndpoint{}
+	filtered.SetEventTypes([]WebhookEventType{WebhookEventSubscriptionBilled, WebhookEventShareLinked})
+
+	tests := []struct {
+		name     string
+		endpoint *WebhookEndpoint
+		event    WebhookEventType
+		expected bool
+	}{
+		{"empty list receives everything", all, WebhookEventSubscriptionCreated, true},
+		{"listed event", filtered, WebhookEventShareLinked, true},
+		{"unlisted event", filtered, WebhookEventSubscriptionCreated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.endpoint.Subscribes(tt.event); got != tt.expected {
+				t.Errorf("Subscribes(%s) = %v, want %v", tt.event, got, tt.expected)
+			}
+		})
+	}
+
+	if filtered.Events != "subscription.billed,share.linked" {
+		t.Errorf("Events = %q", filtered.Events)
+	}
+	if got := len(filtered.EventTypes()); got != 2 {
+		t.Errorf("EventTypes() length = %d, want 2", got)
+	}
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// BudgetRepository defines the interface for budget data access.
+type BudgetRepository interface {
+	FindByID(id string) (*models.Budget, error)
+	FindByUserID(userID string) ([]*models.Budget, error)
+	Create(budget *models.Budget) error
+	Update(budget *models.Budget) error
+	Delete(id string) error
+}
+
+// budgetRepository is the GORM implementation of BudgetRepository.
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new GORM-backed BudgetRepository.
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+// FindByID retrieves a budget by its UUID with its category preloaded.
+func (r *budgetRepository) FindByID(id string) (*models.Budget, error) {
+	var budget models.Budget
+	if err := r.db.Preload("Category").Where("id = ?", id).First(&budget).Error; err != nil {
+		return nil, fmt.Errorf("find budget by id: %w", err)
+	}
+	return &budget, nil
+}
+
+// FindByUserID retrieves all budgets of a user, the overall budget first.
+func (r *budgetRepository) FindByUserID(userID string) ([]*models.Budget, error) {
+	var budgets []*models.Budget
+	if err := r.db.Preload("Category").
+		Where("user_id = ?", userID).
+		Order("category_id IS NOT NULL, created_at ASC").
+		Find(&budgets).Error; err != nil {
+		return nil, fmt.Errorf("find budgets by user id: %w", err)
+	}
+	return budgets, nil
+}
+
+// Create inserts a new budget into the database.
+func (r *budgetRepository) Create(budget *models.Budget) error {
+	if err := r.db.Create(budget).Error; err != nil {
+		return fmt.Errorf("create budget: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing budget.
+func (r *budgetRepository) Update(budget *models.Budget) error {
+	if err := r.db.Save(budget).Error; err != nil {
+		return fmt.Errorf("update budget: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a budget by its UUID.
+func (r *budgetRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.Budget{}).Error; err != nil {
+		return fmt.Errorf("delete budget: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// CancellationSavingRepository defines the interface for realized savings ledger access.
+type CancellationSavingRepository interface {
+	FindByUserID(userID string) ([]*models.CancellationSaving, error)
+	Create(saving *models.CancellationSaving) error
+	Delete(id string) error
+}
+
+// cancellationSavingRepository is the GORM implementation of CancellationSavingRepository.
+type cancellationSavingRepository struct {
+	db *gorm.DB
+}
+
+// NewCancellationSavingRepository creates a new GORM-backed CancellationSavingRepository.
+func NewCancellationSavingRepository(db *gorm.DB) CancellationSavingRepository {
+	return &cancellationSavingRepository{db: db}
+}
+
+// FindByUserID retrieves a user's ledger entries, oldest cancellation first.
+func (r *cancellationSavingRepository) FindByUserID(userID string) ([]*models.CancellationSaving, error) {
+	var savings []*models.CancellationSaving
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("cancelled_on ASC, created_at ASC").
+		Find(&savings).Error; err != nil {
+		return nil, fmt.Errorf("find cancellation savings by user id: %w", err)
+	}
+	return savings, nil
+}
+
+// Create inserts a new ledger entry.
+func (r *cancellationSavingRepository) Create(saving *models.CancellationSaving) error {
+	if err := r.db.Create(saving).Error; err != nil {
+		return fmt.Errorf("create cancellation saving: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a ledger entry by its UUID.
+func (r *cancellationSavingRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.CancellationSaving{}).Error; err != nil {
+		return fmt.Errorf("delete cancellation saving: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// DigestScheduleRepository defines the interface for digest schedule data access.
+type DigestScheduleRepository interface {
+	FindByUserID(userID string) (*models.DigestSchedule, error)
+	FindEnabled() ([]*models.DigestSchedule, error)
+	Create(schedule *models.DigestSchedule) error
+	Update(schedule *models.DigestSchedule) error
+}
+
+// digestScheduleRepository is the GORM implementation of DigestScheduleRepository.
+type digestScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestScheduleRepository creates a new GORM-backed DigestScheduleRepository.
+func NewDigestScheduleRepository(db *gorm.DB) DigestScheduleRepository {
+	return &digestScheduleRepository{db: db}
+}
+
+// FindByUserID retrieves the digest schedule of a user.
+func (r *digestScheduleRepository) FindByUserID(userID string) (*models.DigestSchedule, error) {
+	var schedule models.DigestSchedule
+	if err := r.db.Where("user_id = ?", userID).First(&schedule).Error; err != nil {
+		return nil, fmt.Errorf("find digest schedule by user id: %w", err)
+	}
+	return &schedule, nil
+}
+
+// FindEnabled retrieves all enabled digest schedules.
+func (r *digestScheduleRepository) FindEnabled() ([]*models.DigestSchedule, error) {
+	var schedules []*models.DigestSchedule
+	if err := r.db.
+		Where("enabled = true").
+		Order("user_id ASC").
+		Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("find enabled digest schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// Create inserts a new digest schedule.
+func (r *digestScheduleRepository) Create(schedule *models.DigestSchedule) error {
+	if err := r.db.Create(schedule).Error; err != nil {
+		return fmt.Errorf("create digest schedule: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing digest schedule.
+func (r *digestScheduleRepository) Update(schedule *models.DigestSchedule) error {
+	if err := r.db.Save(schedule).Error; err != nil {
+		return fmt.Errorf("update digest schedule: %w", err)
+	}
+	return nil
+}
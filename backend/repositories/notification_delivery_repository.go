@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// NotificationDeliveryRepository defines the interface for notification delivery records.
+type NotificationDeliveryRepository interface {
+	FindByDedupKey(key string) (*models.NotificationDelivery, error)
+	FindByUserID(userID string, page, perPage int) ([]*models.NotificationDelivery, int64, error)
+	Create(delivery *models.NotificationDelivery) error
+	Update(delivery *models.NotificationDelivery) error
+}
+
+// notificationDeliveryRepository is the GORM implementation of NotificationDeliveryRepository.
+type notificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationDeliveryRepository creates a new GORM-backed NotificationDeliveryRepository.
+func NewNotificationDeliveryRepository(db *gorm.DB) NotificationDeliveryRepository {
+	return &notificationDeliveryRepository{db: db}
+}
+
+// FindByDedupKey retrieves a delivery by its deduplication key.
+func (r *notificationDeliveryRepository) FindByDedupKey(key string) (*models.NotificationDelivery, error) {
+	var delivery models.NotificationDelivery
+	if err := r.db.Where("dedup_key = ?", key).First(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("find notification delivery by dedup key: %w", err)
+	}
+	return &delivery, nil
+}
+
+// FindByUserID retrieves a user's deliveries, newest first, with pagination.
+func (r *notificationDeliveryRepository) FindByUserID(userID string, page, perPage int) ([]*models.NotificationDelivery, int64, error) {
+	var deliveries []*models.NotificationDelivery
+	var total int64
+
+	query := r.db.Model(&models.NotificationDelivery{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count notification deliveries: %w", err)
+	}
+
+	if err := query.
+		Order("created_at DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("find notification deliveries by user id: %w", err)
+	}
+
+	return deliveries, total, nil
+}
+
+// Create inserts a new delivery record.
+func (r *notificationDeliveryRepository) Create(delivery *models.NotificationDelivery) error {
+	if err := r.db.Create(delivery).Error; err != nil {
+		return fmt.Errorf("create notification delivery: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing delivery record.
+func (r *notificationDeliveryRepository) Update(delivery *models.NotificationDelivery) error {
+	if err := r.db.Save(delivery).Error; err != nil {
+		return fmt.Errorf("update notification delivery: %w", err)
+	}
+	return nil
+}
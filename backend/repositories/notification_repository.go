@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationCursor marks a position in a user's inbox. Notifications are
+// listed newest first, so the cursor selects entries strictly older than it.
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NotificationFilter defines filters for listing inbox notifications.
+type NotificationFilter struct {
+	UnreadOnly bool
+	Type       string
+	After      *NotificationCursor
+	Limit      int
+}
+
+// NotificationRepository defines the interface for in-app inbox data access.
+type NotificationRepository interface {
+	FindByID(id string) (*models.Notification, error)
+	FindByUserID(userID string, filter NotificationFilter) ([]*models.Notification, error)
+	CountUnread(userID string) (int64, error)
+	CreateIfAbsent(notification *models.Notification) (bool, error)
+	Update(notification *models.Notification) error
+	MarkAllRead(userID string, readAt time.Time) (int64, error)
+}
+
+// notificationRepository is the GORM implementation of NotificationRepository.
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new GORM-backed NotificationRepository.
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// FindByID retrieves an inbox notification by its UUID.
+func (r *notificationRepository) FindByID(id string) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.Where("id = ?", id).First(&notification).Error; err != nil {
+		return nil, fmt.Errorf("find notification by id: %w", err)
+	}
+	return &notification, nil
+}
+
+// FindByUserID retrieves up to filter.Limit notifications of a user, newest
+// first, starting after filter.After.
+func (r *notificationRepository) FindByUserID(userID string, filter NotificationFilter) ([]*models.Notification, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var notifications []*models.Notification
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("find notifications by user id: %w", err)
+	}
+	return notifications, nil
+}
+
+// CountUnread counts a user's unread notifications.
+func (r *notificationRepository) CountUnread(userID string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// CreateIfAbsent inserts a notification unless one with the same user and
+// dedup key already exists. It reports whether a row was inserted.
+func (r *notificationRepository) CreateIfAbsent(notification *models.Notification) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "dedup_key"}},
+		DoNothing: true,
+	}).Create(notification)
+	if result.Error != nil {
+		return false, fmt.Errorf("create notification: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Update saves changes to an existing notification.
+func (r *notificationRepository) Update(notification *models.Notification) error {
+	if err := r.db.Save(notification).Error; err != nil {
+		return fmt.Errorf("update notification: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification of a user as read and returns
+// the number of notifications updated.
+func (r *notificationRepository) MarkAllRead(userID string, readAt time.Time) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("mark all notifications read: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// NotificationRuleRepository defines the interface for notification rule data access.
+type NotificationRuleRepository interface {
+	FindByID(id string) (*models.NotificationRule, error)
+	FindByUserID(userID string) ([]*models.NotificationRule, error)
+	FindEnabled() ([]*models.NotificationRule, error)
+	Create(rule *models.NotificationRule) error
+	Update(rule *models.NotificationRule) error
+	Delete(id string) error
+}
+
+// notificationRuleRepository is the GORM implementation of NotificationRuleRepository.
+type notificationRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRuleRepository creates a new GORM-backed NotificationRuleRepository.
+func NewNotificationRuleRepository(db *gorm.DB) NotificationRuleRepository {
+	return &notificationRuleRepository{db: db}
+}
+
+// FindByID retrieves a notification rule by its UUID.
+func (r *notificationRuleRepository) FindByID(id string) (*models.NotificationRule, error) {
+	var rule models.NotificationRule
+	if err := r.db.Where("id = ?", id).First(&rule).Error; err != nil {
+		return nil, fmt.Errorf("find notification rule by id: %w", err)
+	}
+	return &rule, nil
+}
+
+// FindByUserID retrieves all notification rules of a user, oldest first.
+func (r *notificationRuleRepository) FindByUserID(userID string) ([]*models.NotificationRule, error) {
+	var rules []*models.NotificationRule
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("find notification rules by user id: %w", err)
+	}
+	return rules, nil
+}
+
+// FindEnabled retrieves all enabled notification rules across users, grouped by user.
+func (r *notificationRuleRepository) FindEnabled() ([]*models.NotificationRule, error) {
+	var rules []*models.NotificationRule
+	if err := r.db.
+		Where("enabled = true").
+		Order("user_id ASC, created_at ASC").
+		Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("find enabled notification rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Create inserts a new notification rule.
+func (r *notificationRuleRepository) Create(rule *models.NotificationRule) error {
+	if err := r.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("create notification rule: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing notification rule.
+func (r *notificationRuleRepository) Update(rule *models.NotificationRule) error {
+	if err := r.db.Save(rule).Error; err != nil {
+		return fmt.Errorf("update notification rule: %w", err)
+	}
+	return nil
+}
+
+// Delete hard-deletes a notification rule by its UUID.
+func (r *notificationRuleRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.NotificationRule{}).Error; err != nil {
+		return fmt.Errorf("delete notification rule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlanAlternativeRepository defines the interface for alternative plan price data access.
+type PlanAlternativeRepository interface {
+	FindBySubscriptionID(subID string) ([]*models.PlanAlternative, error)
+	FindBySubscriptionIDs(subIDs []string) ([]*models.PlanAlternative, error)
+	Upsert(alt *models.PlanAlternative) error
+	Delete(subID string, cycle models.BillingCycle) (bool, error)
+}
+
+// planAlternativeRepository is the GORM implementation of PlanAlternativeRepository.
+type planAlternativeRepository struct {
+	db *gorm.DB
+}
+
+// NewPlanAlternativeRepository creates a new GORM-backed PlanAlternativeRepository.
+func NewPlanAlternativeRepository(db *gorm.DB) PlanAlternativeRepository {
+	return &planAlternativeRepository{db: db}
+}
+
+// FindBySubscriptionID retrieves the alternative plans of a subscription.
+func (r *planAlternativeRepository) FindBySubscriptionID(subID string) ([]*models.PlanAlternative, error) {
+	var alts []*models.PlanAlternative
+	if err := r.db.
+		Where("subscription_id = ?", subID).
+		Order("billing_cycle ASC").
+		Find(&alts).Error; err != nil {
+		return nil, fmt.Errorf("find plan alternatives by subscription id: %w", err)
+	}
+	return alts, nil
+}
+
+// FindBySubscriptionIDs retrieves the alternative plans of several subscriptions.
+func (r *planAlternativeRepository) FindBySubscriptionIDs(subIDs []string) ([]*models.PlanAlternative, error) {
+	var alts []*models.PlanAlternative
+	if len(subIDs) == 0 {
+		return alts, nil
+	}
+	if err := r.db.Where("subscription_id IN ?", subIDs).Find(&alts).Error; err != nil {
+		return nil, fmt.Errorf("find plan alternatives by subscription ids: %w", err)
+	}
+	return alts, nil
+}
+
+// Upsert creates the alternative plan or updates the amount of an existing
+// one for the same subscription and billing cycle.
+func (r *planAlternativeRepository) Upsert(alt *models.PlanAlternative) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "billing_cycle"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount", "updated_at"}),
+	}).Create(alt).Error; err != nil {
+		return fmt.Errorf("upsert plan alternative: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the alternative plan of a subscription for a billing cycle
+// and reports whether one existed.
+func (r *planAlternativeRepository) Delete(subID string, cycle models.BillingCycle) (bool, error) {
+	result := r.db.
+		Where("subscription_id = ? AND billing_cycle = ?", subID, cycle).
+		Delete(&models.PlanAlternative{})
+	if result.Error != nil {
+		return false, fmt.Errorf("delete plan alternative: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
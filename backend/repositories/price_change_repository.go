@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// PriceChangeRepository defines the interface for subscription price history access.
+type PriceChangeRepository interface {
+	Create(change *models.PriceChange) error
+	FindBySubscriptionID(subID string) ([]*models.PriceChange, error)
+	FindBySubscriptionIDs(subIDs []string) ([]*models.PriceChange, error)
+	FindByUserIDSince(userID string, since time.Time) ([]*models.PriceChange, error)
+}
+
+// priceChangeRepository is the GORM implementation of PriceChangeRepository.
+type priceChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceChangeRepository creates a new GORM-backed PriceChangeRepository.
+func NewPriceChangeRepository(db *gorm.DB) PriceChangeRepository {
+	return &priceChangeRepository{db: db}
+}
+
+// Create inserts a new price change record.
+func (r *priceChangeRepository) Create(change *models.PriceChange) error {
+	if err := r.db.Create(change).Error; err != nil {
+		return fmt.Errorf("create price change: %w", err)
+	}
+	return nil
+}
+
+// FindBySubscriptionID retrieves the price history of a subscription, oldest first.
+func (r *priceChangeRepository) FindBySubscriptionID(subID string) ([]*models.PriceChange, error) {
+	var changes []*models.PriceChange
+	if err := r.db.
+		Where("subscription_id = ?", subID).
+		Order("created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("find price changes by subscription id: %w", err)
+	}
+	return changes, nil
+}
+
+// FindBySubscriptionIDs retrieves the price history of several subscriptions, oldest first.
+func (r *priceChangeRepository) FindBySubscriptionIDs(subIDs []string) ([]*models.PriceChange, error) {
+	var changes []*models.PriceChange
+	if len(subIDs) == 0 {
+		return changes, nil
+	}
+	if err := r.db.
+		Where("subscription_id IN ?", subIDs).
+		Order("created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("find price changes by subscription ids: %w", err)
+	}
+	return changes, nil
+}
+
+// FindByUserIDSince retrieves a user's price changes recorded at or after since, oldest first.
+func (r *priceChangeRepository) FindByUserIDSince(userID string, since time.Time) ([]*models.PriceChange, error) {
+	var changes []*models.PriceChange
+	if err := r.db.
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("find price changes by user id: %w", err)
+	}
+	return changes, nil
+}
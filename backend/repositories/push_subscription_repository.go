@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// PushSubscriptionRepository defines the interface for browser push subscription data access.
+type PushSubscriptionRepository interface {
+	FindByID(id string) (*models.PushSubscription, error)
+	FindByUserID(userID string) ([]*models.PushSubscription, error)
+	FindByEndpoint(endpoint string) (*models.PushSubscription, error)
+	Create(sub *models.PushSubscription) error
+	Update(sub *models.PushSubscription) error
+	Delete(id string) error
+}
+
+// pushSubscriptionRepository is the GORM implementation of PushSubscriptionRepository.
+type pushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewPushSubscriptionRepository creates a new GORM-backed PushSubscriptionRepository.
+func NewPushSubscriptionRepository(db *gorm.DB) PushSubscriptionRepository {
+	return &pushSubscriptionRepository{db: db}
+}
+
+// FindByID retrieves a push subscription by its UUID.
+func (r *pushSubscriptionRepository) FindByID(id string) (*models.PushSubscription, error) {
+	var sub models.PushSubscription
+	if err := r.db.Where("id = ?", id).First(&sub).Error; err != nil {
+		return nil, fmt.Errorf("find push subscription by id: %w", err)
+	}
+	return &sub, nil
+}
+
+// FindByUserID retrieves all push subscriptions of a user, oldest first.
+func (r *pushSubscriptionRepository) FindByUserID(userID string) ([]*models.PushSubscription, error) {
+	var subs []*models.PushSubscription
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&subs).Error; err != nil {
+		return nil, fmt.Errorf("find push subscriptions by user id: %w", err)
+	}
+	return subs, nil
+}
+
+// FindByEndpoint retrieves a push subscription by its push service endpoint URL.
+func (r *pushSubscriptionRepository) FindByEndpoint(endpoint string) (*models.PushSubscription, error) {
+	var sub models.PushSubscription
+	if err := r.db.Where("endpoint = ?", endpoint).First(&sub).Error; err != nil {
+		return nil, fmt.Errorf("find push subscription by endpoint: %w", err)
+	}
+	return &sub, nil
+}
+
+// Create inserts a new push subscription.
+func (r *pushSubscriptionRepository) Create(sub *models.PushSubscription) error {
+	if err := r.db.Create(sub).Error; err != nil {
+		return fmt.Errorf("create push subscription: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing push subscription.
+func (r *pushSubscriptionRepository) Update(sub *models.PushSubscription) error {
+	if err := r.db.Save(sub).Error; err != nil {
+		return fmt.Errorf("update push subscription: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a push subscription.
+func (r *pushSubscriptionRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.PushSubscription{}).Error; err != nil {
+		return fmt.Errorf("delete push subscription: %w", err)
+	}
+	return nil
+}
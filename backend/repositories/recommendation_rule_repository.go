@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecommendationRuleRepository defines the interface for recommendation rule data access.
+type RecommendationRuleRepository interface {
+	FindByID(id string) (*models.RecommendationRule, error)
+	FindByUserID(userID string) ([]*models.RecommendationRule, error)
+	CreateAll(rules []*models.RecommendationRule) error
+	Create(rule *models.RecommendationRule) error
+	Update(rule *models.RecommendationRule) error
+	Delete(id string) error
+}
+
+// recommendationRuleRepository is the GORM implementation of RecommendationRuleRepository.
+type recommendationRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewRecommendationRuleRepository creates a new GORM-backed RecommendationRuleRepository.
+func NewRecommendationRuleRepository(db *gorm.DB) RecommendationRuleRepository {
+	return &recommendationRuleRepository{db: db}
+}
+
+// FindByID retrieves a recommendation rule by its UUID.
+func (r *recommendationRuleRepository) FindByID(id string) (*models.RecommendationRule, error) {
+	var rule models.RecommendationRule
+	if err := r.db.Where("id = ?", id).First(&rule).Error; err != nil {
+		return nil, fmt.Errorf("find recommendation rule by id: %w", err)
+	}
+	return &rule, nil
+}
+
+// FindByUserID retrieves all recommendation rules of a user ordered by sort_order.
+func (r *recommendationRuleRepository) FindByUserID(userID string) ([]*models.RecommendationRule, error) {
+	var rules []*models.RecommendationRule
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("sort_order ASC, created_at ASC").
+		Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("find recommendation rules by user id: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateAll inserts several rules in one statement, skipping built-in rules
+// the user already has.
+func (r *recommendationRuleRepository) CreateAll(rules []*models.RecommendationRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoNothing: true,
+	}).Create(&rules).Error; err != nil {
+		return fmt.Errorf("create recommendation rules: %w", err)
+	}
+	return nil
+}
+
+// Create inserts a new recommendation rule.
+func (r *recommendationRuleRepository) Create(rule *models.RecommendationRule) error {
+	if err := r.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("create recommendation rule: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing recommendation rule.
+func (r *recommendationRuleRepository) Update(rule *models.RecommendationRule) error {
+	if err := r.db.Save(rule).Error; err != nil {
+		return fmt.Errorf("update recommendation rule: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a recommendation rule by its UUID.
+func (r *recommendationRuleRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.RecommendationRule{}).Error; err != nil {
+		return fmt.Errorf("delete recommendation rule: %w", err)
+	}
+	return nil
+}
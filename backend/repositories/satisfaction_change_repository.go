@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// SatisfactionChangeRepository defines the interface for subscription satisfaction history access.
+type SatisfactionChangeRepository interface {
+	Create(change *models.SatisfactionChange) error
+	FindByUserIDSince(userID string, since time.Time) ([]*models.SatisfactionChange, error)
+}
+
+// satisfactionChangeRepository is the GORM implementation of SatisfactionChangeRepository.
+type satisfactionChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewSatisfactionChangeRepository creates a new GORM-backed SatisfactionChangeRepository.
+func NewSatisfactionChangeRepository(db *gorm.DB) SatisfactionChangeRepository {
+	return &satisfactionChangeRepository{db: db}
+}
+
+// Create inserts a new satisfaction change record.
+func (r *satisfactionChangeRepository) Create(change *models.SatisfactionChange) error {
+	if err := r.db.Create(change).Error; err != nil {
+		return fmt.Errorf("create satisfaction change: %w", err)
+	}
+	return nil
+}
+
+// FindByUserIDSince retrieves a user's satisfaction changes effective on or after since, oldest first.
+func (r *satisfactionChangeRepository) FindByUserIDSince(userID string, since time.Time) ([]*models.SatisfactionChange, error) {
+	var changes []*models.SatisfactionChange
+	if err := r.db.
+		Where("user_id = ? AND effective_date >= ?", userID, since).
+		Order("effective_date ASC, created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("find satisfaction changes by user id: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// SinkingFundRepository defines the interface for sinking fund contribution access.
+type SinkingFundRepository interface {
+	FindByID(id string) (*models.SinkingFundContribution, error)
+	FindByUserID(userID string) ([]*models.SinkingFundContribution, error)
+	Create(contribution *models.SinkingFundContribution) error
+	Delete(id string) error
+}
+
+// sinkingFundRepository is the GORM implementation of SinkingFundRepository.
+type sinkingFundRepository struct {
+	db *gorm.DB
+}
+
+// NewSinkingFundRepository creates a new GORM-backed SinkingFundRepository.
+func NewSinkingFundRepository(db *gorm.DB) SinkingFundRepository {
+	return &sinkingFundRepository{db: db}
+}
+
+// FindByID retrieves a contribution by its UUID.
+func (r *sinkingFundRepository) FindByID(id string) (*models.SinkingFundContribution, error) {
+	var contribution models.SinkingFundContribution
+	if err := r.db.Where("id = ?", id).First(&contribution).Error; err != nil {
+		return nil, fmt.Errorf("find sinking fund contribution by id: %w", err)
+	}
+	return &contribution, nil
+}
+
+// FindByUserID retrieves all contributions of a user, oldest first.
+func (r *sinkingFundRepository) FindByUserID(userID string) ([]*models.SinkingFundContribution, error) {
+	var contributions []*models.SinkingFundContribution
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("contributed_on ASC, created_at ASC").
+		Find(&contributions).Error; err != nil {
+		return nil, fmt.Errorf("find sinking fund contributions by user id: %w", err)
+	}
+	return contributions, nil
+}
+
+// Create inserts a new contribution.
+func (r *sinkingFundRepository) Create(contribution *models.SinkingFundContribution) error {
+	if err := r.db.Create(contribution).Error; err != nil {
+		return fmt.Errorf("create sinking fund contribution: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a contribution by its UUID.
+func (r *sinkingFundRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.SinkingFundContribution{}).Error; err != nil {
+		return fmt.Errorf("delete sinking fund contribution: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// StatusChangeRepository defines the interface for subscription status history access.
+type StatusChangeRepository interface {
+	Create(change *models.StatusChange) error
+	FindBySubscriptionIDs(subIDs []string) ([]*models.StatusChange, error)
+	FindByUserIDSince(userID string, since time.Time) ([]*models.StatusChange, error)
+}
+
+// statusChangeRepository is the GORM implementation of StatusChangeRepository.
+type statusChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewStatusChangeRepository creates a new GORM-backed StatusChangeRepository.
+func NewStatusChangeRepository(db *gorm.DB) StatusChangeRepository {
+	return &statusChangeRepository{db: db}
+}
+
+// Create inserts a new status change record.
+func (r *statusChangeRepository) Create(change *models.StatusChange) error {
+	if err := r.db.Create(change).Error; err != nil {
+		return fmt.Errorf("create status change: %w", err)
+	}
+	return nil
+}
+
+// FindBySubscriptionIDs retrieves the status history of several subscriptions, oldest first.
+func (r *statusChangeRepository) FindBySubscriptionIDs(subIDs []string) ([]*models.StatusChange, error) {
+	var changes []*models.StatusChange
+	if len(subIDs) == 0 {
+		return changes, nil
+	}
+	if err := r.db.
+		Where("subscription_id IN ?", subIDs).
+		Order("effective_date ASC, created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("find status changes by subscription ids: %w", err)
+	}
+	return changes, nil
+}
+
+// FindByUserIDSince retrieves a user's status changes effective on or after since, oldest first.
+func (r *statusChangeRepository) FindByUserIDSince(userID string, since time.Time) ([]*models.StatusChange, error) {
+	var changes []*models.StatusChange
+	if err := r.db.
+		Where("user_id = ? AND effective_date >= ?", userID, since).
+		Order("effective_date ASC, created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("find status changes by user id: %w", err)
+	}
+	return changes, nil
+}
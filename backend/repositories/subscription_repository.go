@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/subkeep/backend/models"
 	"gorm.io/gorm"
@@ -12,7 +13,7 @@ import (
 type SubscriptionFilter struct {
 	Status     string // "active", "paused", "cancelled", "" (all)
 	CategoryID string // filter by category UUID
-	SortBy     string // "amount", "satisfaction", "next_billing_date", "created_at"
+	SortBy     string // "amount", "satisfaction", "next_billing_date", "created_at"; "value_score" and "lifetime_spend" are sorted by the service
 	SortOrder  string // "asc", "desc"
 	Page       int
 	PerPage    int
@@ -45,6 +46,8 @@ type SubscriptionRepository interface {
 	CountByUserID(userID string) (int64, error)
 	FindDuplicateName(userID, serviceName string) (bool, error)
 	FindSimilarInCategory(userID string, categoryID string, excludeSubID string) ([]*models.Subscription, error)
+	FindDeletedByUserIDSince(userID string, since time.Time) ([]*models.Subscription, error)
+	FindActiveUserIDs() ([]string, error)
 }
 
 // subscriptionRepository is the GORM implementation of SubscriptionRepository.
@@ -192,3 +195,29 @@ func (r *subscriptionRepository) FindSimilarInCategory(userID string, categoryID
 	}
 	return subs, nil
 }
+
+// FindDeletedByUserIDSince retrieves a user's subscriptions soft-deleted at or
+// after since, so that reports can include them in past months.
+func (r *subscriptionRepository) FindDeletedByUserIDSince(userID string, since time.Time) ([]*models.Subscription, error) {
+	var subs []*models.Subscription
+	if err := r.db.Unscoped().
+		Preload("Category").
+		Where("user_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", userID, since).
+		Find(&subs).Error; err != nil {
+		return nil, fmt.Errorf("find deleted subscriptions by user id: %w", err)
+	}
+	return subs, nil
+}
+
+// FindActiveUserIDs returns the IDs of users with at least one active subscription.
+func (r *subscriptionRepository) FindActiveUserIDs() ([]string, error) {
+	var userIDs []string
+	if err := r.db.Model(&models.Subscription{}).
+		Where("status = ?", models.SubscriptionStatusActive).
+		Distinct("user_id").
+		Order("user_id ASC").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("find active user ids: %w", err)
+	}
+	return userIDs, nil
+}
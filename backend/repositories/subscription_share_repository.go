@@ -13,6 +13,7 @@ type SubscriptionShareRepository interface {
 	FindByID(id string) (*models.SubscriptionShare, error)
 	FindBySubscriptionID(subscriptionID string) (*models.SubscriptionShare, error)
 	FindByUserID(userID string) ([]*models.SubscriptionShare, error)
+	FindSharingUserIDs() ([]string, error)
 	Create(share *models.SubscriptionShare) error
 	Update(share *models.SubscriptionShare) error
 	Delete(id string) error
@@ -62,6 +63,20 @@ func (r *subscriptionShareRepository) FindByUserID(userID string) ([]*models.Sub
 	return shares, nil
 }
 
+// FindSharingUserIDs returns the IDs of users who own at least one shared subscription.
+func (r *subscriptionShareRepository) FindSharingUserIDs() ([]string, error) {
+	var userIDs []string
+	if err := r.db.Model(&models.SubscriptionShare{}).
+		Joins("JOIN subscriptions ON subscriptions.id = subscription_shares.subscription_id").
+		Where("subscriptions.deleted_at IS NULL").
+		Distinct("subscriptions.user_id").
+		Order("subscriptions.user_id ASC").
+		Pluck("subscriptions.user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("find sharing user ids: %w", err)
+	}
+	return userIDs, nil
+}
+
 // Create inserts a new subscription share into the database.
 func (r *subscriptionShareRepository) Create(share *models.SubscriptionShare) error {
 	if err := r.db.Create(share).Error; err != nil {
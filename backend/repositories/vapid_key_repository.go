@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// VAPIDKeyRepository defines the interface for VAPID key data access.
+type VAPIDKeyRepository interface {
+	FindLatest() (*models.VAPIDKey, error)
+	Create(key *models.VAPIDKey) error
+}
+
+// vapidKeyRepository is the GORM implementation of VAPIDKeyRepository.
+type vapidKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewVAPIDKeyRepository creates a new GORM-backed VAPIDKeyRepository.
+func NewVAPIDKeyRepository(db *gorm.DB) VAPIDKeyRepository {
+	return &vapidKeyRepository{db: db}
+}
+
+// FindLatest retrieves the most recently created VAPID key pair.
+func (r *vapidKeyRepository) FindLatest() (*models.VAPIDKey, error) {
+	var key models.VAPIDKey
+	if err := r.db.Order("created_at DESC").First(&key).Error; err != nil {
+		return nil, fmt.Errorf("find latest vapid key: %w", err)
+	}
+	return &key, nil
+}
+
+// Create inserts a new VAPID key pair.
+func (r *vapidKeyRepository) Create(key *models.VAPIDKey) error {
+	if err := r.db.Create(key).Error; err != nil {
+		return fmt.Errorf("create vapid key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryFilter holds query parameters for the webhook delivery log.
+type WebhookDeliveryFilter struct {
+	EndpointID string
+	Status     string
+	Page       int
+	PerPage    int
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery log.
+type WebhookDeliveryRepository interface {
+	FindByID(id string) (*models.WebhookDelivery, error)
+	FindByUserID(userID string, filter WebhookDeliveryFilter) ([]*models.WebhookDelivery, int64, error)
+	FindDue(now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	ExistsForEvent(endpointID, eventID string) (bool, error)
+	Create(delivery *models.WebhookDelivery) error
+	Update(delivery *models.WebhookDelivery) error
+}
+
+// webhookDeliveryRepository is the GORM implementation of WebhookDeliveryRepository.
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new GORM-backed WebhookDeliveryRepository.
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+// FindByID retrieves a webhook delivery by its UUID.
+func (r *webhookDeliveryRepository) FindByID(id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.Where("id = ?", id).First(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("find webhook delivery by id: %w", err)
+	}
+	return &delivery, nil
+}
+
+// FindByUserID retrieves a user's webhook deliveries, newest first, with
+// optional endpoint and status filters and pagination.
+func (r *webhookDeliveryRepository) FindByUserID(userID string, filter WebhookDeliveryFilter) ([]*models.WebhookDelivery, int64, error) {
+	var deliveries []*models.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("user_id = ?", userID)
+	if filter.EndpointID != "" {
+		query = query.Where("endpoint_id = ?", filter.EndpointID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count webhook deliveries: %w", err)
+	}
+
+	if err := query.
+		Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.PerPage).
+		Limit(filter.PerPage).
+		Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("find webhook deliveries by user id: %w", err)
+	}
+
+	return deliveries, total, nil
+}
+
+// FindDue retrieves pending deliveries whose next attempt is due, oldest first.
+func (r *webhookDeliveryRepository) FindDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	if err := r.db.
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("find due webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ExistsForEvent reports whether an event was already queued for an endpoint.
+func (r *webhookDeliveryRepository) ExistsForEvent(endpointID, eventID string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.WebhookDelivery{}).
+		Where("endpoint_id = ? AND event_id = ?", endpointID, eventID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check webhook delivery for event: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Create inserts a new delivery record.
+func (r *webhookDeliveryRepository) Create(delivery *models.WebhookDelivery) error {
+	if err := r.db.Create(delivery).Error; err != nil {
+		return fmt.Errorf("create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing delivery record.
+func (r *webhookDeliveryRepository) Update(delivery *models.WebhookDelivery) error {
+	if err := r.db.Save(delivery).Error; err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	return nil
+}
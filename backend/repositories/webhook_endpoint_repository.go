@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/subkeep/backend/models"
+	"gorm.io/gorm"
+)
+
+// WebhookEndpointRepository defines the interface for webhook endpoint data access.
+type WebhookEndpointRepository interface {
+	FindByID(id string) (*models.WebhookEndpoint, error)
+	FindByUserID(userID string) ([]*models.WebhookEndpoint, error)
+	FindEnabled() ([]*models.WebhookEndpoint, error)
+	Create(endpoint *models.WebhookEndpoint) error
+	Update(endpoint *models.WebhookEndpoint) error
+	Delete(id string) error
+}
+
+// webhookEndpointRepository is the GORM implementation of WebhookEndpointRepository.
+type webhookEndpointRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookEndpointRepository creates a new GORM-backed WebhookEndpointRepository.
+func NewWebhookEndpointRepository(db *gorm.DB) WebhookEndpointRepository {
+	return &webhookEndpointRepository{db: db}
+}
+
+// FindByID retrieves a webhook endpoint by its UUID.
+func (r *webhookEndpointRepository) FindByID(id string) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := r.db.Where("id = ?", id).First(&endpoint).Error; err != nil {
+		return nil, fmt.Errorf("find webhook endpoint by id: %w", err)
+	}
+	return &endpoint, nil
+}
+
+// FindByUserID retrieves all webhook endpoints of a user, oldest first.
+func (r *webhookEndpointRepository) FindByUserID(userID string) ([]*models.WebhookEndpoint, error) {
+	var endpoints []*models.WebhookEndpoint
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("find webhook endpoints by user id: %w", err)
+	}
+	return endpoints, nil
+}
+
+// FindEnabled retrieves all enabled webhook endpoints across users, grouped by user.
+func (r *webhookEndpointRepository) FindEnabled() ([]*models.WebhookEndpoint, error) {
+	var endpoints []*models.WebhookEndpoint
+	if err := r.db.
+		Where("enabled = true").
+		Order("user_id ASC, created_at ASC").
+		Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("find enabled webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+// Create inserts a new webhook endpoint.
+func (r *webhookEndpointRepository) Create(endpoint *models.WebhookEndpoint) error {
+	if err := r.db.Create(endpoint).Error; err != nil {
+		return fmt.Errorf("create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// Update saves changes to an existing webhook endpoint.
+func (r *webhookEndpointRepository) Update(endpoint *models.WebhookEndpoint) error {
+	if err := r.db.Save(endpoint).Error; err != nil {
+		return fmt.Errorf("update webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a webhook endpoint. Its deliveries are removed by cascade.
+func (r *webhookEndpointRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&models.WebhookEndpoint{}).Error; err != nil {
+		return fmt.Errorf("delete webhook endpoint: %w", err)
+	}
+	return nil
+}
@@ -10,16 +10,26 @@ import (
 
 // Handlers holds all handler instances used by the router.
 type Handlers struct {
-	Auth              *handlers.AuthHandler
-	Subscription      *handlers.SubscriptionHandler
-	Dashboard         *handlers.DashboardHandler
-	Simulation        *handlers.SimulationHandler
-	Calendar          *handlers.CalendarHandler
-	Category          *handlers.CategoryHandler
-	ShareGroup        *handlers.ShareGroupHandler
-	SubscriptionShare *handlers.SubscriptionShareHandler
-	Report            *handlers.ReportHandler
-	AuthService       *services.AuthService
+	Auth               *handlers.AuthHandler
+	Subscription       *handlers.SubscriptionHandler
+	Dashboard          *handlers.DashboardHandler
+	Simulation         *handlers.SimulationHandler
+	Calendar           *handlers.CalendarHandler
+	Category           *handlers.CategoryHandler
+	Budget             *handlers.BudgetHandler
+	SinkingFund        *handlers.SinkingFundHandler
+	ShareGroup         *handlers.ShareGroupHandler
+	SubscriptionShare  *handlers.SubscriptionShareHandler
+	Report             *handlers.ReportHandler
+	Forecast           *handlers.ForecastHandler
+	NotificationRule   *handlers.NotificationRuleHandler
+	Webhook            *handlers.WebhookHandler
+	Digest             *handlers.DigestHandler
+	Push               *handlers.PushHandler
+	Notification       *handlers.NotificationHandler
+	RecommendationRule *handlers.RecommendationRuleHandler
+	PlanAlternative    *handlers.PlanAlternativeHandler
+	AuthService        *services.AuthService
 }
 
 // SetupRoutes configures all API routes on the Fiber app.
@@ -35,6 +45,7 @@ func SetupRoutes(app *fiber.App, h *Handlers) {
 	authProtected := auth.Group("", middleware.AuthMiddleware(h.AuthService))
 	authProtected.Post("/logout", h.Auth.Logout)
 	authProtected.Get("/me", h.Auth.GetMe)
+	authProtected.Patch("/me", h.Auth.UpdateMe)
 
 	// OAuth routes (public) — /:provider must be last to avoid catching /me, /refresh, etc.
 	auth.Get("/:provider", h.Auth.OAuthRedirect)
@@ -52,12 +63,22 @@ func SetupRoutes(app *fiber.App, h *Handlers) {
 	subs.Put("/:id", h.Subscription.Update)
 	subs.Delete("/:id", h.Subscription.Delete)
 	subs.Patch("/:id/satisfaction", h.Subscription.UpdateSatisfaction)
+	subs.Get("/:id/plan-alternatives", h.PlanAlternative.GetAll)
+	subs.Put("/:id/plan-alternatives/:cycle", h.PlanAlternative.Set)
+	subs.Delete("/:id/plan-alternatives/:cycle", h.PlanAlternative.Delete)
 
 	// Dashboard routes.
 	dashboard := protected.Group("/dashboard")
 	dashboard.Get("/summary", h.Dashboard.GetSummary)
 	dashboard.Get("/recommendations", h.Dashboard.GetRecommendations)
 
+	// Recommendation rule routes.
+	recommendationRules := protected.Group("/recommendation-rules")
+	recommendationRules.Get("/", h.RecommendationRule.GetAll)
+	recommendationRules.Post("/", h.RecommendationRule.Create)
+	recommendationRules.Put("/:id", h.RecommendationRule.Update)
+	recommendationRules.Delete("/:id", h.RecommendationRule.Delete)
+
 	// Simulation routes.
 	simulation := protected.Group("/simulation")
 	simulation.Post("/cancel", h.Simulation.SimulateCancel)
@@ -70,6 +91,8 @@ func SetupRoutes(app *fiber.App, h *Handlers) {
 	calendar.Get("/monthly", h.Calendar.GetMonthlyCalendar)
 	calendar.Get("/daily", h.Calendar.GetDayDetail)
 	calendar.Get("/upcoming", h.Calendar.GetUpcomingPayments)
+	calendar.Get("/range", h.Calendar.GetRangeCalendar)
+	calendar.Get("/yearly", h.Calendar.GetYearlyOverview)
 
 	// Category routes.
 	categories := protected.Group("/categories")
@@ -78,6 +101,20 @@ func SetupRoutes(app *fiber.App, h *Handlers) {
 	categories.Put("/:id", h.Category.Update)
 	categories.Delete("/:id", h.Category.Delete)
 
+	// Budget routes.
+	budgets := protected.Group("/budgets")
+	budgets.Get("/", h.Budget.GetAll)
+	budgets.Get("/progress", h.Budget.GetProgress)
+	budgets.Post("/", h.Budget.Create)
+	budgets.Put("/:id", h.Budget.Update)
+	budgets.Delete("/:id", h.Budget.Delete)
+
+	// Sinking fund routes — /contributions/:id must be before /:subscriptionId.
+	sinkingFunds := protected.Group("/sinking-funds")
+	sinkingFunds.Get("/", h.SinkingFund.GetPlan)
+	sinkingFunds.Delete("/contributions/:id", h.SinkingFund.DeleteContribution)
+	sinkingFunds.Post("/:subscriptionId/contributions", h.SinkingFund.AddContribution)
+
 	// Share group routes.
 	shareGroups := protected.Group("/share-groups")
 	shareGroups.Get("/", h.ShareGroup.GetAll)
@@ -89,6 +126,58 @@ func SetupRoutes(app *fiber.App, h *Handlers) {
 	// Report routes.
 	reports := protected.Group("/reports")
 	reports.Get("/overview", h.Report.GetOverview)
+	reports.Get("/savings", h.Report.GetSavings)
+	reports.Get("/value-quadrant", h.Report.GetValueQuadrant)
+	reports.Get("/period", h.Report.GetPeriod)
+	reports.Get("/year-in-review", h.Report.GetYearInReview)
+	reports.Get("/export", h.Report.Export)
+	reports.Get("/receivables", h.Report.GetShareReceivables)
+	reports.Get("/receivables/export", h.Report.ExportShareReceivables)
+
+	// Forecast routes.
+	forecast := protected.Group("/forecast")
+	forecast.Post("/cash-flow", h.Forecast.GetCashFlowForecast)
+	forecast.Get("/projection", h.Forecast.GetSpendingProjection)
+
+	// Notification rule routes.
+	notificationRules := protected.Group("/notification-rules")
+	notificationRules.Get("/", h.NotificationRule.GetAll)
+	notificationRules.Post("/", h.NotificationRule.Create)
+	notificationRules.Put("/:id", h.NotificationRule.Update)
+	notificationRules.Delete("/:id", h.NotificationRule.Delete)
+	protected.Get("/notification-deliveries", h.NotificationRule.GetDeliveries)
+
+	// Webhook routes — /deliveries must be before /:id.
+	webhooks := protected.Group("/webhooks")
+	webhooks.Get("/", h.Webhook.GetAll)
+	webhooks.Post("/", h.Webhook.Create)
+	webhooks.Get("/deliveries", h.Webhook.GetDeliveries)
+	webhooks.Post("/deliveries/:id/redeliver", h.Webhook.Redeliver)
+	webhooks.Put("/:id", h.Webhook.Update)
+	webhooks.Delete("/:id", h.Webhook.Delete)
+	webhooks.Post("/:id/rotate-secret", h.Webhook.RotateSecret)
+
+	// Digest routes.
+	digest := protected.Group("/digest")
+	digest.Get("/preview", h.Digest.Preview)
+	digest.Get("/schedule", h.Digest.GetSchedule)
+	digest.Put("/schedule", h.Digest.UpdateSchedule)
+
+	// Web Push routes.
+	push := protected.Group("/push")
+	push.Get("/vapid-public-key", h.Push.GetPublicKey)
+	push.Get("/subscriptions", h.Push.GetSubscriptions)
+	push.Post("/subscriptions", h.Push.Subscribe)
+	push.Delete("/subscriptions/:id", h.Push.Unsubscribe)
+	push.Post("/test", h.Push.SendTest)
+
+	// In-app notification inbox routes.
+	notifications := protected.Group("/notifications")
+	notifications.Get("/", h.Notification.List)
+	notifications.Get("/unread-count", h.Notification.UnreadCount)
+	notifications.Post("/read-all", h.Notification.MarkAllRead)
+	notifications.Post("/:id/read", h.Notification.MarkRead)
+	notifications.Delete("/:id/read", h.Notification.MarkUnread)
 
 	// Subscription share routes.
 	subs.Post("/:id/share", h.SubscriptionShare.Link)
@@ -42,13 +42,16 @@ type TokenClaims struct {
 type AuthService struct {
 	userRepo  repositories.UserRepository
 	jwtConfig config.JWTConfig
+	clock     *UserClock
 }
 
 // NewAuthService creates a new AuthService.
-func NewAuthService(userRepo repositories.UserRepository, jwtConfig config.JWTConfig) *AuthService {
+// clock may be nil, in which case no cached time zone is forgotten on profile updates.
+func NewAuthService(userRepo repositories.UserRepository, jwtConfig config.JWTConfig, clock *UserClock) *AuthService {
 	return &AuthService{
 		userRepo:  userRepo,
 		jwtConfig: jwtConfig,
+		clock:     clock,
 	}
 }
 
@@ -161,6 +164,39 @@ func (s *AuthService) GetUserByID(userID string) (*models.User, error) {
 	return user, nil
 }
 
+// UpdateProfileRequest represents the request body for updating the current user's profile.
+type UpdateProfileRequest struct {
+	Timezone *string `json:"timezone" validate:"omitempty,timezone"`
+}
+
+// UpdateProfile applies profile changes for the given user.
+func (s *AuthService) UpdateProfile(userID string, req *UpdateProfileRequest) (*models.User, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("사용자를 찾을 수 없습니다")
+		}
+		slog.Error("failed to find user for profile update", "error", err, "userID", userID)
+		return nil, utils.ErrInternal("사용자 정보를 수정할 수 없습니다")
+	}
+
+	if req.Timezone != nil && *req.Timezone != "" {
+		user.Timezone = *req.Timezone
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		slog.Error("failed to update user profile", "error", err, "userID", userID)
+		return nil, utils.ErrInternal("사용자 정보를 수정할 수 없습니다")
+	}
+	s.clock.Forget(userID)
+
+	return user, nil
+}
+
 // GenerateTokenPair creates a new access token (1h) and refresh token (7d).
 func (s *AuthService) GenerateTokenPair(userID string) (*TokenPair, error) {
 	now := time.Now()
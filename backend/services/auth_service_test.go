@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -84,7 +85,7 @@ func newTestAuthService() (*AuthService, *mockUserRepo) {
 		Secret:            testJWTSecret,
 		Expiration:        15 * time.Minute,
 		RefreshExpiration: 7 * 24 * time.Hour,
-	})
+	}, nil)
 	return svc, repo
 }
 
@@ -464,6 +465,57 @@ func TestGetUserByID(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// Tests – UpdateProfile
+// ---------------------------------------------------------------------------
+
+func TestUpdateProfile(t *testing.T) {
+	svc, repo := newTestAuthService()
+
+	user := &models.User{
+		ID:             uuid.New(),
+		Provider:       models.AuthProviderGoogle,
+		ProviderUserID: "google-tz",
+	}
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	t.Run("updates timezone", func(t *testing.T) {
+		tz := "America/New_York"
+		updated, err := svc.UpdateProfile(user.ID.String(), &UpdateProfileRequest{Timezone: &tz})
+		if err != nil {
+			t.Fatalf("UpdateProfile() error = %v", err)
+		}
+		if updated.Timezone != tz {
+			t.Errorf("Timezone = %s, want %s", updated.Timezone, tz)
+		}
+	})
+
+	t.Run("forgets the cached time zone", func(t *testing.T) {
+		clock := NewUserClock(nil, repo)
+		svc := NewAuthService(repo, config.JWTConfig{Secret: testJWTSecret}, clock)
+		assertEqual(t, clock.Location(user.ID.String()).String(), "America/New_York")
+
+		tz := "Europe/Berlin"
+		_, err := svc.UpdateProfile(user.ID.String(), &UpdateProfileRequest{Timezone: &tz})
+		assertNil(t, err)
+		assertEqual(t, clock.Location(user.ID.String()).String(), tz)
+	})
+
+	t.Run("rejects unknown timezone", func(t *testing.T) {
+		tz := "Mars/Olympus_Mons"
+		_, err := svc.UpdateProfile(user.ID.String(), &UpdateProfileRequest{Timezone: &tz})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("returns not found for missing user", func(t *testing.T) {
+		tz := "Asia/Seoul"
+		_, err := svc.UpdateProfile(uuid.New().String(), &UpdateProfileRequest{Timezone: &tz})
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Tests – NewAuthService
 // ---------------------------------------------------------------------------
@@ -471,7 +523,7 @@ func TestGetUserByID(t *testing.T) {
 func TestNewAuthService(t *testing.T) {
 	repo := newMockUserRepo()
 	cfg := config.JWTConfig{Secret: testJWTSecret}
-	svc := NewAuthService(repo, cfg)
+	svc := NewAuthService(repo, cfg, nil)
 	if svc == nil {
 		t.Fatal("NewAuthService() returned nil")
 	}
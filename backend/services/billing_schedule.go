@@ -0,0 +1,177 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/subkeep/backend/models"
+)
+
+// BillingEvent represents a single expanded billing occurrence of a subscription.
+// Date is the nominal billing date; AdjustedDate is the date the charge is
+// expected to post after applying the subscription's business-day adjustment.
+type BillingEvent struct {
+	Subscription   *models.Subscription
+	Date           time.Time
+	AdjustedDate   time.Time
+	Amount         int // amount actually charged on Date
+	PersonalAmount int // user's share of Amount after splits
+}
+
+// expandBillingDates returns every billing date of a subscription within
+// [from, to] (both inclusive, compared by calendar date).
+//
+// The schedule is anchored at NextBillingDate:
+//   - weekly:  anchor ± 7 days
+//   - monthly: same day-of-month, clamped to the last day of shorter months
+//   - yearly:  same month/day, Feb 29 clamped to Feb 28 in common years
+//
+// Occurrences before the anchor are only produced for dates strictly before
+// today and on or after StartDate, since NextBillingDate is by definition the
+// next upcoming charge. Occurrences after the anchor are skipped when the
+// subscription does not auto-renew, and occurrences covered by a scheduled
+// pause are never billed.
+func expandBillingDates(sub *models.Subscription, from, to, today time.Time) []time.Time {
+	from = truncateDate(from)
+	to = truncateDate(to)
+	today = truncateDate(today)
+	anchor := truncateDate(sub.NextBillingDate)
+	start := truncateDate(sub.StartDate)
+
+	if to.Before(from) {
+		return nil
+	}
+
+	dates := make([]time.Time, 0)
+
+	// Walk backwards from the anchor for past occurrences.
+	for k := -1; ; k-- {
+		d := billingDateAt(sub.BillingCycle, anchor, k)
+		if d.Before(from) || (!start.IsZero() && d.Before(start)) {
+			break
+		}
+		if !d.Before(today) || d.After(to) || sub.PauseScheduledOn(d) {
+			continue
+		}
+		dates = append(dates, d)
+	}
+
+	// Walk forwards from the anchor (inclusive).
+	for k := 0; ; k++ {
+		if k > 0 && !sub.AutoRenew {
+			break
+		}
+		d := billingDateAt(sub.BillingCycle, anchor, k)
+		if d.After(to) {
+			break
+		}
+		if d.Before(from) || sub.PauseScheduledOn(d) {
+			continue
+		}
+		dates = append(dates, d)
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates
+}
+
+// billingDateAt returns the k-th billing date relative to the anchor date.
+func billingDateAt(cycle models.BillingCycle, anchor time.Time, k int) time.Time {
+	switch cycle {
+	case models.BillingCycleWeekly:
+		return anchor.AddDate(0, 0, 7*k)
+	case models.BillingCycleYearly:
+		return clampedDate(anchor.Year()+k, anchor.Month(), anchor.Day())
+	default:
+		// Monthly (and unknown cycles) repeat on the same day-of-month.
+		totalMonths := int(anchor.Month()) - 1 + k
+		year := anchor.Year() + floorDiv(totalMonths, 12)
+		month := time.Month(totalMonths - floorDiv(totalMonths, 12)*12 + 1)
+		return clampedDate(year, month, anchor.Day())
+	}
+}
+
+// expandBillingEvents expands the billing schedule of every subscription within
+// [from, to] and returns the events sorted by date, then service name.
+// The window applies to nominal dates; holidays may be nil.
+func expandBillingEvents(subs []*models.Subscription, shareMap map[string]*models.SubscriptionShare, from, to, today time.Time, holidays *HolidayCalendar) []BillingEvent {
+	events := make([]BillingEvent, 0)
+	for _, sub := range subs {
+		share := shareMap[sub.ID.String()]
+		for _, d := range expandBillingDates(sub, from, to, today) {
+			priced := pricedOn(sub, d)
+			events = append(events, BillingEvent{
+				Subscription:   sub,
+				Date:           d,
+				AdjustedDate:   holidays.Adjust(d, sub.BusinessDayAdjustment),
+				Amount:         priced.Amount,
+				PersonalAmount: personalChargeAmount(priced, share),
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].Subscription.ServiceName < events[j].Subscription.ServiceName
+	})
+
+	return events
+}
+
+// pricedOn returns a copy of sub whose Amount is the amount charged on d,
+// which is the promotional amount while a promotion runs.
+func pricedOn(sub *models.Subscription, d time.Time) *models.Subscription {
+	priced := *sub
+	priced.Amount = sub.AmountOn(d)
+	return &priced
+}
+
+// personalChargeAmount returns the user's share of a single charge.
+// Split settings are defined against the monthly-equivalent amount, so the
+// personal ratio of the monthly amount is applied to the charged amount.
+func personalChargeAmount(sub *models.Subscription, share *models.SubscriptionShare) int {
+	if share == nil {
+		return sub.Amount
+	}
+	monthly := sub.MonthlyAmount()
+	if monthly == 0 {
+		return 0
+	}
+	personalMonthly := share.PersonalAmount(monthly)
+	if personalMonthly == monthly {
+		return sub.Amount
+	}
+	return int(math.Round(float64(sub.Amount) * float64(personalMonthly) / float64(monthly)))
+}
+
+// clampedDate builds a UTC date, clamping the day to the last day of the month.
+func clampedDate(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// truncateDate drops the time-of-day component, keeping the calendar date.
+func truncateDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// floorDiv performs integer division rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+func scheduleDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func TestExpandBillingDates(t *testing.T) {
+	today := scheduleDate(2026, 3, 1)
+
+	tests := []struct {
+		name      string
+		cycle     models.BillingCycle
+		anchor    time.Time
+		start     time.Time
+		autoRenew bool
+		from      time.Time
+		to        time.Time
+		want      []string
+	}{
+		{
+			name:      "weekly produces every occurrence in the window",
+			cycle:     models.BillingCycleWeekly,
+			anchor:    scheduleDate(2026, 3, 12),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2026, 3, 1),
+			to:        scheduleDate(2026, 3, 31),
+			want:      []string{"2026-03-12", "2026-03-19", "2026-03-26"},
+		},
+		{
+			name:      "weekly past occurrences are produced before today",
+			cycle:     models.BillingCycleWeekly,
+			anchor:    scheduleDate(2026, 3, 5),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2026, 2, 1),
+			to:        scheduleDate(2026, 2, 28),
+			want:      []string{"2026-02-05", "2026-02-12", "2026-02-19", "2026-02-26"},
+		},
+		{
+			name:      "monthly day 31 is clamped in shorter months",
+			cycle:     models.BillingCycleMonthly,
+			anchor:    scheduleDate(2026, 3, 31),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2026, 3, 1),
+			to:        scheduleDate(2026, 6, 30),
+			want:      []string{"2026-03-31", "2026-04-30", "2026-05-31", "2026-06-30"},
+		},
+		{
+			name:      "monthly crosses the year boundary",
+			cycle:     models.BillingCycleMonthly,
+			anchor:    scheduleDate(2026, 11, 15),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2026, 12, 1),
+			to:        scheduleDate(2027, 2, 28),
+			want:      []string{"2026-12-15", "2027-01-15", "2027-02-15"},
+		},
+		{
+			name:      "yearly Feb 29 is clamped in common years",
+			cycle:     models.BillingCycleYearly,
+			anchor:    scheduleDate(2028, 2, 29),
+			start:     scheduleDate(2020, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2028, 1, 1),
+			to:        scheduleDate(2029, 12, 31),
+			want:      []string{"2028-02-29", "2029-02-28"},
+		},
+		{
+			name:      "no occurrences before start date",
+			cycle:     models.BillingCycleMonthly,
+			anchor:    scheduleDate(2026, 3, 10),
+			start:     scheduleDate(2026, 1, 10),
+			autoRenew: true,
+			from:      scheduleDate(2025, 11, 1),
+			to:        scheduleDate(2026, 2, 28),
+			want:      []string{"2026-01-10", "2026-02-10"},
+		},
+		{
+			name:      "no occurrences between today and a future anchor",
+			cycle:     models.BillingCycleMonthly,
+			anchor:    scheduleDate(2026, 4, 20),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2026, 3, 1),
+			to:        scheduleDate(2026, 4, 30),
+			want:      []string{"2026-04-20"},
+		},
+		{
+			name:      "no renewals after the anchor without auto-renew",
+			cycle:     models.BillingCycleMonthly,
+			anchor:    scheduleDate(2026, 3, 10),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: false,
+			from:      scheduleDate(2026, 3, 1),
+			to:        scheduleDate(2026, 6, 30),
+			want:      []string{"2026-03-10"},
+		},
+		{
+			name:      "inverted window yields nothing",
+			cycle:     models.BillingCycleMonthly,
+			anchor:    scheduleDate(2026, 3, 10),
+			start:     scheduleDate(2025, 1, 1),
+			autoRenew: true,
+			from:      scheduleDate(2026, 6, 1),
+			to:        scheduleDate(2026, 3, 1),
+			want:      []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &models.Subscription{
+				BillingCycle:    tt.cycle,
+				NextBillingDate: tt.anchor,
+				StartDate:       tt.start,
+				AutoRenew:       tt.autoRenew,
+			}
+			got := formatDates(expandBillingDates(sub, tt.from, tt.to, today))
+			assertEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestExpandBillingEvents_PersonalAmount(t *testing.T) {
+	today := scheduleDate(2026, 3, 1)
+	sub := &models.Subscription{
+		ID:              uuid.New(),
+		ServiceName:     "Family Plan",
+		Amount:          120000,
+		BillingCycle:    models.BillingCycleYearly,
+		NextBillingDate: scheduleDate(2026, 3, 20),
+		StartDate:       scheduleDate(2025, 3, 20),
+		AutoRenew:       true,
+	}
+	shareMap := map[string]*models.SubscriptionShare{
+		sub.ID.String(): {
+			SubscriptionID:       sub.ID,
+			SplitType:            models.SplitTypeEqual,
+			TotalMembersSnapshot: 4,
+		},
+	}
+
+	events := expandBillingEvents([]*models.Subscription{sub}, shareMap,
+		scheduleDate(2026, 3, 1), scheduleDate(2026, 3, 31), today, nil)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	assertEqual(t, events[0].Amount, 120000)
+	assertEqual(t, events[0].PersonalAmount, 30000)
+}
+
+func TestExpandBillingDates_ScheduledPause(t *testing.T) {
+	today := scheduleDate(2026, 3, 1)
+	pauseStart, pauseEnd := scheduleDate(2026, 2, 1), scheduleDate(2026, 4, 1)
+	sub := &models.Subscription{
+		BillingCycle:    models.BillingCycleMonthly,
+		NextBillingDate: scheduleDate(2026, 3, 10),
+		StartDate:       scheduleDate(2025, 1, 1),
+		AutoRenew:       true,
+		PauseStartDate:  &pauseStart,
+		PauseEndDate:    &pauseEnd,
+	}
+
+	got := formatDates(expandBillingDates(sub, scheduleDate(2026, 1, 1), scheduleDate(2026, 5, 31), today))
+	assertEqual(t, got, []string{"2026-01-10", "2026-04-10", "2026-05-10"})
+
+	sub.PauseEndDate = nil
+	got = formatDates(expandBillingDates(sub, scheduleDate(2026, 1, 1), scheduleDate(2026, 5, 31), today))
+	assertEqual(t, got, []string{"2026-01-10"})
+}
+
+func TestExpandBillingEvents_Promotion(t *testing.T) {
+	today := scheduleDate(2026, 3, 1)
+	promoEnd := scheduleDate(2026, 4, 1)
+	sub := &models.Subscription{
+		ID:              uuid.New(),
+		ServiceName:     "Music",
+		Amount:          10000,
+		BillingCycle:    models.BillingCycleMonthly,
+		NextBillingDate: scheduleDate(2026, 3, 10),
+		StartDate:       scheduleDate(2025, 1, 1),
+		AutoRenew:       true,
+		PromoAmount:     intPtr(2000),
+		PromoEndDate:    &promoEnd,
+	}
+	shareMap := map[string]*models.SubscriptionShare{
+		sub.ID.String(): {SubscriptionID: sub.ID, SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 2},
+	}
+
+	events := expandBillingEvents([]*models.Subscription{sub}, shareMap,
+		scheduleDate(2026, 3, 1), scheduleDate(2026, 4, 30), today, nil)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	assertEqual(t, events[0].Amount, 2000)
+	assertEqual(t, events[0].PersonalAmount, 1000)
+	assertEqual(t, events[1].Amount, 10000)
+	assertEqual(t, events[1].PersonalAmount, 5000)
+}
+
+func TestPersonalChargeAmount_CustomAmount(t *testing.T) {
+	// Weekly 5000 → monthly 21667; my monthly share is 10000.
+	sub := &models.Subscription{Amount: 5000, BillingCycle: models.BillingCycleWeekly}
+	share := &models.SubscriptionShare{
+		SplitType:     models.SplitTypeCustomAmount,
+		MyShareAmount: intPtr(10000),
+	}
+	assertEqual(t, personalChargeAmount(sub, share), 2308)
+	assertEqual(t, personalChargeAmount(sub, nil), 5000)
+}
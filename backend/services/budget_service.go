@@ -0,0 +1,358 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// Budget progress statuses.
+const (
+	BudgetStatusOK       = "ok"
+	BudgetStatusWarning  = "warning"
+	BudgetStatusExceeded = "exceeded"
+)
+
+// CreateBudgetRequest holds the body for creating a budget. Omitting
+// categoryId creates the overall budget.
+type CreateBudgetRequest struct {
+	CategoryID   *string `json:"categoryId" validate:"omitempty,uuid"`
+	MonthlyLimit int     `json:"monthlyLimit" validate:"required,gt=0"`
+	AlertPercent *int    `json:"alertPercent" validate:"omitempty,gte=1,lte=100"`
+}
+
+// UpdateBudgetRequest holds the body for updating a budget.
+type UpdateBudgetRequest struct {
+	MonthlyLimit *int `json:"monthlyLimit" validate:"omitempty,gt=0"`
+	AlertPercent *int `json:"alertPercent" validate:"omitempty,gte=1,lte=100"`
+}
+
+// BudgetProgress reports how much of a budget this month's subscriptions use.
+type BudgetProgress struct {
+	BudgetID     string  `json:"budgetId"`
+	CategoryID   *string `json:"categoryId"`
+	CategoryName string  `json:"categoryName"`
+	Limit        int     `json:"limit"`
+	Spent        int     `json:"spent"`
+	Remaining    int     `json:"remaining"`
+	Percentage   float64 `json:"percentage"`
+	Status       string  `json:"status"`
+}
+
+// BudgetWarning describes a budget that a simulated change would exceed.
+type BudgetWarning struct {
+	BudgetID       string  `json:"budgetId"`
+	CategoryID     *string `json:"categoryId"`
+	CategoryName   string  `json:"categoryName"`
+	Limit          int     `json:"limit"`
+	CurrentSpent   int     `json:"currentSpent"`
+	SimulatedSpent int     `json:"simulatedSpent"`
+	Overage        int     `json:"overage"`
+	Message        string  `json:"message"`
+}
+
+// BudgetService handles business logic for budgets.
+type BudgetService struct {
+	repo      repositories.BudgetRepository
+	catRepo   repositories.CategoryRepository
+	subRepo   repositories.SubscriptionRepository
+	shareRepo repositories.SubscriptionShareRepository
+	cache     *AggregateCache
+}
+
+// NewBudgetService creates a new BudgetService.
+// cache may be nil, in which case no cached aggregates are invalidated.
+func NewBudgetService(repo repositories.BudgetRepository, catRepo repositories.CategoryRepository, subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, cache *AggregateCache) *BudgetService {
+	return &BudgetService{repo: repo, catRepo: catRepo, subRepo: subRepo, shareRepo: shareRepo, cache: cache}
+}
+
+// GetBudgets returns the user's budgets.
+func (s *BudgetService) GetBudgets(userID string) ([]*models.Budget, error) {
+	budgets, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("예산 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("예산 목록을 조회할 수 없습니다")
+	}
+	return budgets, nil
+}
+
+// GetProgress returns this month's progress for each of the user's budgets.
+func (s *BudgetService) GetProgress(userID string) ([]BudgetProgress, error) {
+	budgets, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("예산 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("예산 목록을 조회할 수 없습니다")
+	}
+	if len(budgets) == 0 {
+		return []BudgetProgress{}, nil
+	}
+
+	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("예산 진행률 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("예산 진행률을 계산할 수 없습니다")
+	}
+
+	return buildBudgetProgress(budgets, activeSubs, buildShareMap(s.shareRepo, userID)), nil
+}
+
+// CreateBudget validates and creates a budget. Each user has at most one
+// overall budget and one budget per category.
+func (s *BudgetService) CreateBudget(userID string, req *CreateBudgetRequest) (*models.Budget, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	budget := &models.Budget{
+		UserID:       uid,
+		MonthlyLimit: req.MonthlyLimit,
+		AlertPercent: models.DefaultBudgetAlertPercent,
+	}
+	if req.AlertPercent != nil {
+		budget.AlertPercent = *req.AlertPercent
+	}
+
+	if req.CategoryID != nil && *req.CategoryID != "" {
+		cat, err := s.catRepo.FindByID(*req.CategoryID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, utils.ErrNotFound("카테고리를 찾을 수 없습니다")
+			}
+			slog.Error("카테고리 조회 실패", "categoryID", *req.CategoryID, "error", err)
+			return nil, utils.ErrInternal("카테고리를 조회할 수 없습니다")
+		}
+		if !cat.IsSystem && (cat.UserID == nil || *cat.UserID != uid) {
+			return nil, utils.ErrForbidden("해당 카테고리에 대한 접근 권한이 없습니다")
+		}
+		budget.CategoryID = &cat.ID
+		budget.Category = cat
+	}
+
+	existing, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("예산 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("예산 목록을 조회할 수 없습니다")
+	}
+	for _, b := range existing {
+		if sameBudgetScope(b, budget) {
+			if budget.IsOverall() {
+				return nil, utils.ErrConflict("전체 예산이 이미 설정되어 있습니다")
+			}
+			return nil, utils.ErrConflict("해당 카테고리의 예산이 이미 설정되어 있습니다")
+		}
+	}
+
+	if err := s.repo.Create(budget); err != nil {
+		slog.Error("예산 생성 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("예산을 생성할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+
+	return budget, nil
+}
+
+// UpdateBudget validates ownership and applies partial updates to a budget.
+func (s *BudgetService) UpdateBudget(userID, budgetID string, req *UpdateBudgetRequest) (*models.Budget, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	budget, err := s.findOwned(userID, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MonthlyLimit != nil {
+		budget.MonthlyLimit = *req.MonthlyLimit
+	}
+	if req.AlertPercent != nil {
+		budget.AlertPercent = *req.AlertPercent
+	}
+
+	if err := s.repo.Update(budget); err != nil {
+		slog.Error("예산 수정 실패", "budgetID", budgetID, "error", err)
+		return nil, utils.ErrInternal("예산을 수정할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+
+	return budget, nil
+}
+
+// DeleteBudget validates ownership and deletes a budget.
+func (s *BudgetService) DeleteBudget(userID, budgetID string) error {
+	if _, err := s.findOwned(userID, budgetID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(budgetID); err != nil {
+		slog.Error("예산 삭제 실패", "budgetID", budgetID, "error", err)
+		return utils.ErrInternal("예산을 삭제할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+	return nil
+}
+
+// findOwned loads a budget and verifies that it belongs to the user.
+func (s *BudgetService) findOwned(userID, budgetID string) (*models.Budget, error) {
+	budget, err := s.repo.FindByID(budgetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("예산을 찾을 수 없습니다")
+		}
+		slog.Error("예산 조회 실패", "budgetID", budgetID, "error", err)
+		return nil, utils.ErrInternal("예산을 조회할 수 없습니다")
+	}
+	if budget.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 예산에 대한 접근 권한이 없습니다")
+	}
+	return budget, nil
+}
+
+// sameBudgetScope reports whether two budgets cover the same subscriptions.
+func sameBudgetScope(a, b *models.Budget) bool {
+	if a.IsOverall() || b.IsOverall() {
+		return a.IsOverall() && b.IsOverall()
+	}
+	return *a.CategoryID == *b.CategoryID
+}
+
+// budgetSpending sums personal monthly amounts overall and per category ID.
+func budgetSpending(subs []*models.Subscription, shareMap map[string]*models.SubscriptionShare) (int, map[string]int) {
+	total := 0
+	byCategory := make(map[string]int)
+	for _, sub := range subs {
+		monthly := sub.MonthlyAmount()
+		if share, ok := shareMap[sub.ID.String()]; ok {
+			monthly = share.PersonalAmount(monthly)
+		}
+		total += monthly
+		if sub.CategoryID != nil {
+			byCategory[sub.CategoryID.String()] += monthly
+		}
+	}
+	return total, byCategory
+}
+
+// budgetSpent returns the spending that counts against a budget.
+func budgetSpent(b *models.Budget, total int, byCategory map[string]int) int {
+	if b.IsOverall() {
+		return total
+	}
+	return byCategory[b.CategoryID.String()]
+}
+
+// budgetCategory returns the budget's category ID and display name.
+func budgetCategory(b *models.Budget) (*string, string) {
+	if b.IsOverall() {
+		return nil, "전체"
+	}
+	id := b.CategoryID.String()
+	name := id
+	if b.Category != nil {
+		name = b.Category.Name
+	}
+	return &id, name
+}
+
+// buildBudgetProgress computes each budget's progress against the personal
+// monthly amounts of the given active subscriptions.
+func buildBudgetProgress(budgets []*models.Budget, activeSubs []*models.Subscription, shareMap map[string]*models.SubscriptionShare) []BudgetProgress {
+	total, byCategory := budgetSpending(activeSubs, shareMap)
+
+	progress := make([]BudgetProgress, 0, len(budgets))
+	for _, b := range budgets {
+		spent := budgetSpent(b, total, byCategory)
+		pct := 0.0
+		if b.MonthlyLimit > 0 {
+			pct = math.Round(float64(spent)/float64(b.MonthlyLimit)*1000) / 10
+		}
+
+		status := BudgetStatusOK
+		switch {
+		case spent > b.MonthlyLimit:
+			status = BudgetStatusExceeded
+		case pct >= float64(b.AlertPercent):
+			status = BudgetStatusWarning
+		}
+
+		catID, catName := budgetCategory(b)
+		progress = append(progress, BudgetProgress{
+			BudgetID:     b.ID.String(),
+			CategoryID:   catID,
+			CategoryName: catName,
+			Limit:        b.MonthlyLimit,
+			Spent:        spent,
+			Remaining:    b.MonthlyLimit - spent,
+			Percentage:   pct,
+			Status:       status,
+		})
+	}
+	return progress
+}
+
+// loadBudgetProgress fetches the user's budgets and computes their progress.
+// Budgets are optional, so a nil repository or a lookup failure yields no
+// progress instead of an error.
+func loadBudgetProgress(repo repositories.BudgetRepository, userID string, activeSubs []*models.Subscription, shareMap map[string]*models.SubscriptionShare) []BudgetProgress {
+	if repo == nil {
+		return []BudgetProgress{}
+	}
+	budgets, err := repo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("예산 목록 조회 실패", "userID", userID, "error", err)
+		return []BudgetProgress{}
+	}
+	return buildBudgetProgress(budgets, activeSubs, shareMap)
+}
+
+// budgetWarningsForAdd returns the budgets that adding a subscription costing
+// addedMonthly in categoryID (empty for uncategorized) would push over their limit.
+func budgetWarningsForAdd(budgets []*models.Budget, activeSubs []*models.Subscription, shareMap map[string]*models.SubscriptionShare, categoryID string, addedMonthly int) []BudgetWarning {
+	total, byCategory := budgetSpending(activeSubs, shareMap)
+
+	warnings := make([]BudgetWarning, 0)
+	for _, b := range budgets {
+		if !b.IsOverall() && b.CategoryID.String() != categoryID {
+			continue
+		}
+		current := budgetSpent(b, total, byCategory)
+		simulated := current + addedMonthly
+		if simulated <= b.MonthlyLimit {
+			continue
+		}
+
+		catID, catName := budgetCategory(b)
+		msg := fmt.Sprintf("%s 예산 %s원을 %s원 초과합니다", catName, formatWon(b.MonthlyLimit), formatWon(simulated-b.MonthlyLimit))
+		if current > b.MonthlyLimit {
+			msg = fmt.Sprintf("%s 예산 %s원을 이미 초과했으며, 추가 시 %s원 초과합니다", catName, formatWon(b.MonthlyLimit), formatWon(simulated-b.MonthlyLimit))
+		}
+		warnings = append(warnings, BudgetWarning{
+			BudgetID:       b.ID.String(),
+			CategoryID:     catID,
+			CategoryName:   catName,
+			Limit:          b.MonthlyLimit,
+			CurrentSpent:   current,
+			SimulatedSpent: simulated,
+			Overage:        simulated - b.MonthlyLimit,
+			Message:        msg,
+		})
+	}
+	return warnings
+}
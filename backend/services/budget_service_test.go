@@ -0,0 +1,290 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock Budget repository
+// ---------------------------------------------------------------------------
+
+type mockBudgetRepo struct {
+	budgets map[string]*models.Budget
+	order   []string
+}
+
+func newMockBudgetRepo() *mockBudgetRepo {
+	return &mockBudgetRepo{budgets: make(map[string]*models.Budget)}
+}
+
+func (m *mockBudgetRepo) FindByID(id string) (*models.Budget, error) {
+	b, ok := m.budgets[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *b
+	return &cp, nil
+}
+
+func (m *mockBudgetRepo) FindByUserID(userID string) ([]*models.Budget, error) {
+	var result []*models.Budget
+	for _, id := range m.order {
+		b, ok := m.budgets[id]
+		if ok && b.UserID.String() == userID {
+			cp := *b
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockBudgetRepo) Create(b *models.Budget) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	cp := *b
+	m.budgets[b.ID.String()] = &cp
+	m.order = append(m.order, b.ID.String())
+	return nil
+}
+
+func (m *mockBudgetRepo) Update(b *models.Budget) error {
+	cp := *b
+	m.budgets[b.ID.String()] = &cp
+	return nil
+}
+
+func (m *mockBudgetRepo) Delete(id string) error {
+	delete(m.budgets, id)
+	return nil
+}
+
+// seed stores a budget for userID, scoped to category when it is non-nil.
+func (m *mockBudgetRepo) seed(userID uuid.UUID, category *models.Category, limit int) *models.Budget {
+	b := &models.Budget{UserID: userID, MonthlyLimit: limit, AlertPercent: models.DefaultBudgetAlertPercent}
+	if category != nil {
+		b.CategoryID = &category.ID
+		b.Category = category
+	}
+	_ = m.Create(b)
+	return b
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+type budgetFixture struct {
+	svc     *BudgetService
+	budgets *mockBudgetRepo
+	cats    *mockCategoryRepo
+	subs    *mockSubscriptionRepo
+	shares  *mockShareRepoForDashboard
+	userID  uuid.UUID
+	ent     *models.Category
+}
+
+func newBudgetFixture() *budgetFixture {
+	f := &budgetFixture{
+		budgets: newMockBudgetRepo(),
+		cats:    newMockCategoryRepo(),
+		subs:    newMockRepo(),
+		shares:  newMockShareRepo(),
+		userID:  uuid.New(),
+	}
+	f.ent = &models.Category{ID: uuid.New(), Name: "엔터테인먼트", IsSystem: true}
+	f.cats.categories[f.ent.ID.String()] = f.ent
+	f.svc = NewBudgetService(f.budgets, f.cats, f.subs, f.shares, nil)
+	return f
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestBudgetService_CreateBudget(t *testing.T) {
+	t.Run("creates overall and category budgets", func(t *testing.T) {
+		f := newBudgetFixture()
+
+		overall, err := f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{MonthlyLimit: 80000})
+		assertNil(t, err)
+		assertEqual(t, overall.IsOverall(), true)
+		assertEqual(t, overall.AlertPercent, models.DefaultBudgetAlertPercent)
+
+		catID := f.ent.ID.String()
+		byCat, err := f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{
+			CategoryID: &catID, MonthlyLimit: 30000, AlertPercent: intPtr(90),
+		})
+		assertNil(t, err)
+		assertEqual(t, *byCat.CategoryID, f.ent.ID)
+		assertEqual(t, byCat.AlertPercent, 90)
+	})
+
+	t.Run("rejects a second budget for the same scope", func(t *testing.T) {
+		f := newBudgetFixture()
+		f.budgets.seed(f.userID, nil, 80000)
+		f.budgets.seed(f.userID, f.ent, 30000)
+
+		_, err := f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{MonthlyLimit: 90000})
+		assertAppErrorCode(t, err, http.StatusConflict)
+
+		catID := f.ent.ID.String()
+		_, err = f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{CategoryID: &catID, MonthlyLimit: 10000})
+		assertAppErrorCode(t, err, http.StatusConflict)
+	})
+
+	t.Run("checks the category", func(t *testing.T) {
+		f := newBudgetFixture()
+		other := uuid.New()
+		private := &models.Category{ID: uuid.New(), Name: "Private", UserID: &other}
+		f.cats.categories[private.ID.String()] = private
+
+		catID := private.ID.String()
+		_, err := f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{CategoryID: &catID, MonthlyLimit: 10000})
+		assertAppErrorCode(t, err, http.StatusForbidden)
+
+		missing := uuid.NewString()
+		_, err = f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{CategoryID: &missing, MonthlyLimit: 10000})
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("validates the request", func(t *testing.T) {
+		f := newBudgetFixture()
+		_, err := f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{MonthlyLimit: 0})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+		_, err = f.svc.CreateBudget(f.userID.String(), &CreateBudgetRequest{MonthlyLimit: 1000, AlertPercent: intPtr(150)})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestBudgetService_UpdateAndDelete(t *testing.T) {
+	f := newBudgetFixture()
+	budget := f.budgets.seed(f.userID, nil, 80000)
+
+	_, err := f.svc.UpdateBudget(uuid.NewString(), budget.ID.String(), &UpdateBudgetRequest{MonthlyLimit: intPtr(1)})
+	assertAppErrorCode(t, err, http.StatusForbidden)
+
+	updated, err := f.svc.UpdateBudget(f.userID.String(), budget.ID.String(), &UpdateBudgetRequest{MonthlyLimit: intPtr(100000)})
+	assertNil(t, err)
+	assertEqual(t, updated.MonthlyLimit, 100000)
+	assertEqual(t, updated.AlertPercent, models.DefaultBudgetAlertPercent)
+
+	assertAppErrorCode(t, f.svc.DeleteBudget(uuid.NewString(), budget.ID.String()), http.StatusForbidden)
+	assertNil(t, f.svc.DeleteBudget(f.userID.String(), budget.ID.String()))
+	assertAppErrorCode(t, f.svc.DeleteBudget(f.userID.String(), budget.ID.String()), http.StatusNotFound)
+}
+
+func TestBudgetService_GetProgress(t *testing.T) {
+	f := newBudgetFixture()
+	overall := f.budgets.seed(f.userID, nil, 40000)
+	ent := f.budgets.seed(f.userID, f.ent, 20000)
+
+	netflix := f.subs.seedSubscriptionWithDetails(f.userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, f.ent)
+	f.subs.seedSubscriptionWithDetails(f.userID, "Notion", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	f.subs.seedSubscriptionWithDetails(f.userID, "Paused", 50000, models.BillingCycleMonthly, models.SubscriptionStatusPaused, nil, nil)
+
+	// Netflix is split four ways, so only 4,250원 counts against the budgets.
+	f.shares.shares[netflix.ID.String()] = &models.SubscriptionShare{
+		SubscriptionID: netflix.ID, SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 4,
+	}
+
+	progress, err := f.svc.GetProgress(f.userID.String())
+	assertNil(t, err)
+	assertEqual(t, len(progress), 2)
+
+	assertEqual(t, progress[0].BudgetID, overall.ID.String())
+	assertEqual(t, progress[0].CategoryName, "전체")
+	assertEqual(t, progress[0].Spent, 14250)
+	assertEqual(t, progress[0].Remaining, 25750)
+	assertEqual(t, progress[0].Status, BudgetStatusOK)
+
+	assertEqual(t, progress[1].BudgetID, ent.ID.String())
+	assertEqual(t, progress[1].CategoryName, "엔터테인먼트")
+	assertEqual(t, progress[1].Spent, 4250)
+
+	t.Run("reports warning and exceeded states", func(t *testing.T) {
+		delete(f.shares.shares, netflix.ID.String())
+		progress, err := f.svc.GetProgress(f.userID.String())
+		assertNil(t, err)
+		assertEqual(t, progress[0].Status, BudgetStatusOK)
+		assertEqual(t, progress[1].Percentage, 85.0)
+		assertEqual(t, progress[1].Status, BudgetStatusWarning)
+
+		f.subs.seedSubscriptionWithDetails(f.userID, "Disney+", 9900, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, f.ent)
+		progress, err = f.svc.GetProgress(f.userID.String())
+		assertNil(t, err)
+		assertEqual(t, progress[1].Status, BudgetStatusExceeded)
+		assertEqual(t, progress[1].Remaining, -6900)
+	})
+}
+
+func TestBudgetProgress_InSummaries(t *testing.T) {
+	f := newBudgetFixture()
+	f.budgets.seed(f.userID, nil, 20000)
+	f.subs.seedSubscriptionWithDetails(f.userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+
+	summary, err := NewDashboardServiceWithDeps(f.subs, f.shares, DashboardDeps{Budgets: f.budgets}).GetSummary(f.userID.String())
+	assertNil(t, err)
+	assertEqual(t, len(summary.Budgets), 1)
+	assertEqual(t, summary.Budgets[0].Spent, 17000)
+	assertEqual(t, summary.Budgets[0].Status, BudgetStatusWarning)
+
+	overview, err := NewReportServiceWithDeps(f.subs, f.shares, ReportDeps{Budgets: f.budgets}).GetOverview(f.userID.String())
+	assertNil(t, err)
+	assertEqual(t, len(overview.Budgets), 1)
+	assertEqual(t, overview.Budgets[0].Spent, 17000)
+
+	summary, err = NewDashboardService(f.subs, f.shares).GetSummary(f.userID.String())
+	assertNil(t, err)
+	assertEqual(t, len(summary.Budgets), 0)
+}
+
+func TestSimulateAdd_BudgetWarnings(t *testing.T) {
+	f := newBudgetFixture()
+	f.budgets.seed(f.userID, nil, 30000)
+	f.budgets.seed(f.userID, f.ent, 20000)
+	f.subs.seedSubscriptionWithDetails(f.userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, f.ent)
+	svc := NewSimulationServiceWithDeps(f.subs, f.shares, SimulationDeps{Budgets: f.budgets})
+
+	t.Run("no warning within budget", func(t *testing.T) {
+		result, err := svc.SimulateAdd(f.userID.String(), &AddSimulationRequest{
+			ServiceName: "Notion", Amount: 10000, BillingCycle: "monthly",
+		})
+		assertNil(t, err)
+		assertEqual(t, len(result.BudgetWarnings), 0)
+	})
+
+	t.Run("warns for the category budget only", func(t *testing.T) {
+		catID := f.ent.ID.String()
+		result, err := svc.SimulateAdd(f.userID.String(), &AddSimulationRequest{
+			ServiceName: "Disney+", Amount: 9900, BillingCycle: "monthly", CategoryID: &catID,
+		})
+		assertNil(t, err)
+		if len(result.BudgetWarnings) != 1 {
+			t.Fatalf("expected 1 warning, got %d", len(result.BudgetWarnings))
+		}
+		w := result.BudgetWarnings[0]
+		assertEqual(t, w.CategoryName, "엔터테인먼트")
+		assertEqual(t, w.CurrentSpent, 17000)
+		assertEqual(t, w.SimulatedSpent, 26900)
+		assertEqual(t, w.Overage, 6900)
+		assertEqual(t, w.Message, "엔터테인먼트 예산 20,000원을 6,900원 초과합니다")
+	})
+
+	t.Run("warns for the overall budget", func(t *testing.T) {
+		result, err := svc.SimulateAdd(f.userID.String(), &AddSimulationRequest{
+			ServiceName: "Yearly", Amount: 180000, BillingCycle: "yearly",
+		})
+		assertNil(t, err)
+		assertEqual(t, len(result.BudgetWarnings), 1)
+		assertEqual(t, result.BudgetWarnings[0].CategoryName, "전체")
+		assertEqual(t, result.BudgetWarnings[0].Overage, 2000)
+	})
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is a key-value store with per-entry expiry backing the aggregate cache.
+type Cache interface {
+	// Get returns the value stored under key, if present and not expired.
+	Get(key string) ([]byte, bool, error)
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every entry whose key starts with prefix.
+	DeletePrefix(prefix string) error
+	// Name identifies the backend in cache statistics.
+	Name() string
+}
+
+// maxMemoryCacheEntries is the size at which the in-memory cache sweeps out
+// expired entries before adding new ones.
+const maxMemoryCacheEntries = 10000
+
+// memoryCacheEntry is a value held by MemoryCache.
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache for single-instance deployments and tests.
+type MemoryCache struct {
+	mu      sync.Mutex
+	clock   Clock
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache creates an empty MemoryCache. clock defaults to SystemClock
+// when nil.
+func NewMemoryCache(clock Clock) *MemoryCache {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &MemoryCache{clock: clock, entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get returns the value stored under key, if present and not expired.
+func (c *MemoryCache) Get(key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !c.clock.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set stores value under key for ttl.
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if len(c.entries) >= maxMemoryCacheEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// DeletePrefix removes every entry whose key starts with prefix.
+func (c *MemoryCache) DeletePrefix(prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
+
+// Name identifies the backend in cache statistics.
+func (c *MemoryCache) Name() string {
+	return "memory"
+}
+
+// aggregateKeyPrefix namespaces aggregate cache keys.
+const aggregateKeyPrefix = "subkeep:agg:"
+
+// CacheStats reports aggregate cache effectiveness since startup.
+type CacheStats struct {
+	Backend string  `json:"backend"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Errors  int64   `json:"errors"`
+	HitRate float64 `json:"hitRate"`
+}
+
+// AggregateCache caches computed per-user aggregates such as the dashboard
+// summary as JSON and counts hits and misses. Backend errors are logged and
+// treated as misses so that the cache never fails a request.
+//
+// A nil *AggregateCache caches nothing.
+type AggregateCache struct {
+	backend Cache
+	ttl     time.Duration
+	hits    atomic.Int64
+	misses  atomic.Int64
+	errors  atomic.Int64
+}
+
+// NewAggregateCache creates an AggregateCache storing entries in backend for ttl.
+func NewAggregateCache(backend Cache, ttl time.Duration) *AggregateCache {
+	return &AggregateCache{backend: backend, ttl: ttl}
+}
+
+// InvalidateUser drops every cached aggregate of the user. Call it after any
+// change to the user's subscriptions, shares, categories or budgets.
+func (c *AggregateCache) InvalidateUser(userID string) {
+	if c == nil {
+		return
+	}
+	if err := c.backend.DeletePrefix(aggregateUserPrefix(userID)); err != nil {
+		c.errors.Add(1)
+		slog.Error("집계 캐시 무효화 실패", "userID", userID, "error", err)
+	}
+}
+
+// Stats returns the hit and miss counts since startup.
+func (c *AggregateCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{Backend: "disabled"}
+	}
+	stats := CacheStats{
+		Backend: c.backend.Name(),
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Errors:  c.errors.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// get decodes the entry stored under key into dst and reports whether it was found.
+func (c *AggregateCache) get(key string, dst any) bool {
+	data, ok, err := c.backend.Get(key)
+	if err != nil {
+		c.errors.Add(1)
+		slog.Warn("집계 캐시 조회 실패", "key", key, "error", err)
+	}
+	if ok && err == nil {
+		if err := json.Unmarshal(data, dst); err == nil {
+			c.hits.Add(1)
+			return true
+		}
+		slog.Warn("집계 캐시 항목 디코딩 실패", "key", key)
+	}
+	c.misses.Add(1)
+	return false
+}
+
+// set encodes value as JSON and stores it under key.
+func (c *AggregateCache) set(key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		slog.Warn("집계 캐시 항목 인코딩 실패", "key", key, "error", err)
+		return
+	}
+	if err := c.backend.Set(key, data, c.ttl); err != nil {
+		c.errors.Add(1)
+		slog.Warn("집계 캐시 저장 실패", "key", key, "error", err)
+	}
+}
+
+// cachedAggregate returns the aggregate stored under key, or builds, stores
+// and returns it on a miss. Errors from build are returned and not cached.
+func cachedAggregate[T any](c *AggregateCache, key string, build func() (*T, error)) (*T, error) {
+	if c == nil {
+		return build()
+	}
+	var cached T
+	if c.get(key, &cached) {
+		return &cached, nil
+	}
+	value, err := build()
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, value)
+	return value, nil
+}
+
+// aggregateUserPrefix returns the key prefix shared by all of a user's aggregates.
+func aggregateUserPrefix(userID string) string {
+	return aggregateKeyPrefix + userID + ":"
+}
+
+// aggregateKey builds a cache key for one of a user's aggregates.
+func aggregateKey(userID string, parts ...string) string {
+	return aggregateUserPrefix(userID) + strings.Join(parts, ":")
+}
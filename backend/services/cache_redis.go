@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCacheTimeout bounds every Redis round trip so a slow cache cannot
+// stall requests.
+const redisCacheTimeout = 500 * time.Millisecond
+
+// redisScanCount is the SCAN batch size used when deleting by prefix.
+const redisScanCount = 100
+
+// RedisCache is a Cache backed by Redis, shared by all API instances.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a RedisCache using an already connected client.
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get returns the value stored under key, if present.
+func (c *RedisCache) Get(key string) ([]byte, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisCacheTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis get: %w", err)
+	}
+	return data, true, nil
+}
+
+// Set stores value under key for ttl.
+func (c *RedisCache) Set(key string, value []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisCacheTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis set: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix removes every key starting with prefix. Prefixes are built
+// from UUIDs and fixed names, so they contain no glob metacharacters.
+func (c *RedisCache) DeletePrefix(prefix string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*redisCacheTimeout)
+	defer cancel()
+
+	iter := c.client.Scan(ctx, 0, prefix+"*", redisScanCount).Iterator()
+	keys := make([]string, 0)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("redis scan: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("redis del: %w", err)
+	}
+	return nil
+}
+
+// Name identifies the backend in cache statistics.
+func (c *RedisCache) Name() string {
+	return "redis"
+}
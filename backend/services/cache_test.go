@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+// movableClock is a Clock whose time can be advanced by tests.
+type movableClock struct {
+	now time.Time
+}
+
+func (c *movableClock) Now() time.Time {
+	return c.now
+}
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("returns stored values until they expire", func(t *testing.T) {
+		clock := &movableClock{now: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}
+		cache := NewMemoryCache(clock)
+
+		assertNil(t, cache.Set("key", []byte("value"), time.Minute))
+
+		value, ok, err := cache.Get("key")
+		assertNil(t, err)
+		assertEqual(t, ok, true)
+		assertEqual(t, string(value), "value")
+
+		clock.now = clock.now.Add(time.Minute)
+		_, ok, err = cache.Get("key")
+		assertNil(t, err)
+		assertEqual(t, ok, false)
+	})
+
+	t.Run("deletes only keys with the given prefix", func(t *testing.T) {
+		cache := NewMemoryCache(nil)
+		assertNil(t, cache.Set("user-a:summary", []byte("1"), time.Hour))
+		assertNil(t, cache.Set("user-a:overview", []byte("2"), time.Hour))
+		assertNil(t, cache.Set("user-b:summary", []byte("3"), time.Hour))
+
+		assertNil(t, cache.DeletePrefix("user-a:"))
+
+		_, ok, _ := cache.Get("user-a:summary")
+		assertEqual(t, ok, false)
+		_, ok, _ = cache.Get("user-a:overview")
+		assertEqual(t, ok, false)
+		_, ok, _ = cache.Get("user-b:summary")
+		assertEqual(t, ok, true)
+	})
+}
+
+func TestAggregateCache(t *testing.T) {
+	t.Run("counts hits and misses", func(t *testing.T) {
+		cache := NewAggregateCache(NewMemoryCache(nil), time.Hour)
+		builds := 0
+		build := func() (*DashboardSummary, error) {
+			builds++
+			return &DashboardSummary{MonthlyTotal: 17000}, nil
+		}
+
+		for i := 0; i < 3; i++ {
+			summary, err := cachedAggregate(cache, aggregateKey("user", "summary"), build)
+			assertNil(t, err)
+			assertEqual(t, summary.MonthlyTotal, 17000)
+		}
+
+		assertEqual(t, builds, 1)
+		stats := cache.Stats()
+		assertEqual(t, stats.Backend, "memory")
+		assertEqual(t, stats.Hits, int64(2))
+		assertEqual(t, stats.Misses, int64(1))
+		assertEqual(t, stats.HitRate, 2.0/3.0)
+	})
+
+	t.Run("does not cache build errors", func(t *testing.T) {
+		cache := NewAggregateCache(NewMemoryCache(nil), time.Hour)
+		builds := 0
+		build := func() (*DashboardSummary, error) {
+			builds++
+			return nil, errors.New("build failed")
+		}
+
+		_, err := cachedAggregate(cache, aggregateKey("user", "summary"), build)
+		assertNotNil(t, err)
+		_, err = cachedAggregate(cache, aggregateKey("user", "summary"), build)
+		assertNotNil(t, err)
+		assertEqual(t, builds, 2)
+	})
+
+	t.Run("nil cache always builds and reports disabled", func(t *testing.T) {
+		var cache *AggregateCache
+		builds := 0
+		build := func() (*DashboardSummary, error) {
+			builds++
+			return &DashboardSummary{}, nil
+		}
+
+		_, _ = cachedAggregate(cache, aggregateKey("user", "summary"), build)
+		_, _ = cachedAggregate(cache, aggregateKey("user", "summary"), build)
+		cache.InvalidateUser("user")
+
+		assertEqual(t, builds, 2)
+		assertEqual(t, cache.Stats().Backend, "disabled")
+	})
+}
+
+func TestAggregateCacheInvalidation(t *testing.T) {
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	t.Run("subscription changes invalidate the cached summary", func(t *testing.T) {
+		repo := newMockRepo()
+		cache := NewAggregateCache(NewMemoryCache(nil), time.Hour)
+		dashboard := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Cache: cache})
+		subs := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{Cache: cache})
+
+		repo.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+
+		summary, err := dashboard.GetSummary(userID.String())
+		assertNil(t, err)
+		assertEqual(t, summary.MonthlyTotal, 17000)
+
+		_, err = subs.CreateSubscription(userID.String(), &CreateSubscriptionRequest{
+			ServiceName:     "Spotify",
+			Amount:          10900,
+			BillingCycle:    "monthly",
+			NextBillingDate: time.Now().AddDate(0, 0, 10).Format("2006-01-02"),
+		})
+		assertNil(t, err)
+
+		summary, err = dashboard.GetSummary(userID.String())
+		assertNil(t, err)
+		assertEqual(t, summary.MonthlyTotal, 27900)
+
+		summary, err = dashboard.GetSummary(userID.String())
+		assertNil(t, err)
+		assertEqual(t, summary.MonthlyTotal, 27900)
+
+		stats := cache.Stats()
+		assertEqual(t, stats.Hits, int64(1))
+		assertEqual(t, stats.Misses, int64(2))
+	})
+
+	t.Run("the cached summary is keyed by the dashboard clock's date", func(t *testing.T) {
+		repo := newMockRepo()
+		cache := NewAggregateCache(NewMemoryCache(nil), time.Hour)
+		now := &movableClock{now: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}
+		dashboard := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{
+			Cache: cache,
+			Clock: NewUserClock(now, nil),
+		})
+
+		repo.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+
+		_, _ = dashboard.GetSummary(userID.String())
+		_, _ = dashboard.GetSummary(userID.String())
+		now.now = now.now.AddDate(0, 0, 1)
+		_, _ = dashboard.GetSummary(userID.String())
+
+		stats := cache.Stats()
+		assertEqual(t, stats.Hits, int64(1))
+		assertEqual(t, stats.Misses, int64(2))
+	})
+
+	t.Run("only the changed user's aggregates are invalidated", func(t *testing.T) {
+		repo := newMockRepo()
+		cache := NewAggregateCache(NewMemoryCache(nil), time.Hour)
+		dashboard := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Cache: cache})
+		categories := NewCategoryServiceWithDeps(newMockCategoryRepo(), CategoryDeps{Cache: cache})
+
+		repo.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+		repo.seedSubscriptionWithDetails(otherID, "Spotify", 10900, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+
+		_, _ = dashboard.GetSummary(userID.String())
+		_, _ = dashboard.GetSummary(otherID.String())
+
+		_, err := categories.CreateCategory(userID.String(), &CreateCategoryRequest{Name: "업무"})
+		assertNil(t, err)
+
+		_, _ = dashboard.GetSummary(userID.String())
+		_, _ = dashboard.GetSummary(otherID.String())
+
+		stats := cache.Stats()
+		assertEqual(t, stats.Hits, int64(1))
+		assertEqual(t, stats.Misses, int64(3))
+	})
+}
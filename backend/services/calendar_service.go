@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"log/slog"
+	"math"
 	"sort"
 	"time"
 
@@ -30,31 +31,73 @@ type CalendarDay struct {
 }
 
 // CalendarSubscription represents a subscription entry within a calendar day.
+// Entries are placed on their nominal billing date; AdjustedDate is the
+// expected posting date after weekend/holiday adjustment.
 type CalendarSubscription struct {
-	SubscriptionID string `json:"subscriptionId"`
-	ServiceName    string `json:"serviceName"`
-	Amount         int    `json:"amount"`
-	MonthlyAmount  int    `json:"monthlyAmount"`
-	PersonalAmount int    `json:"personalAmount"`
-	BillingCycle   string `json:"billingCycle"`
-	CategoryName   string `json:"categoryName"`
-	CategoryColor  string `json:"categoryColor"`
-	AutoRenew      bool   `json:"autoRenew"`
+	SubscriptionID        string `json:"subscriptionId"`
+	ServiceName           string `json:"serviceName"`
+	Amount                int    `json:"amount"`
+	MonthlyAmount         int    `json:"monthlyAmount"`
+	PersonalAmount        int    `json:"personalAmount"`
+	BillingCycle          string `json:"billingCycle"`
+	CategoryName          string `json:"categoryName"`
+	CategoryColor         string `json:"categoryColor"`
+	AutoRenew             bool   `json:"autoRenew"`
+	NominalDate           string `json:"nominalDate"`
+	AdjustedDate          string `json:"adjustedDate"`
+	BusinessDayAdjustment string `json:"businessDayAdjustment"`
 }
 
 // CalendarService handles calendar-related business logic.
 type CalendarService struct {
 	subRepo   repositories.SubscriptionRepository
 	shareRepo repositories.SubscriptionShareRepository
+	holidays  *HolidayCalendar
+	clock     *UserClock
+	cache     *AggregateCache
+}
+
+// CalendarDeps holds the optional dependencies of a CalendarService. Any of
+// them may be nil.
+type CalendarDeps struct {
+	Holidays *HolidayCalendar // nil: only weekends are treated as non-business days
+	Clock    *UserClock       // nil: the system clock and default time zone are used
+	Cache    *AggregateCache  // nil: calendars are computed on every request
 }
 
-// NewCalendarService creates a new CalendarService.
+// NewCalendarService creates a new CalendarService without optional dependencies.
 func NewCalendarService(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository) *CalendarService {
-	return &CalendarService{subRepo: subRepo, shareRepo: shareRepo}
+	return NewCalendarServiceWithDeps(subRepo, shareRepo, CalendarDeps{})
 }
 
-// GetMonthlyCalendar returns the monthly calendar with billing schedule for a user.
+// NewCalendarServiceWithDeps creates a new CalendarService with the given optional dependencies.
+func NewCalendarServiceWithDeps(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, deps CalendarDeps) *CalendarService {
+	return &CalendarService{
+		subRepo:   subRepo,
+		shareRepo: shareRepo,
+		holidays:  deps.Holidays,
+		clock:     deps.Clock,
+		cache:     deps.Cache,
+	}
+}
+
+// Today returns the user's current local date.
+func (s *CalendarService) Today(userID string) time.Time {
+	return s.clock.Today(userID)
+}
+
+// GetMonthlyCalendar returns the monthly calendar with billing schedule for a
+// user. Calendars are cached per day, since past billings are marked against
+// the user's current date.
 func (s *CalendarService) GetMonthlyCalendar(userID string, year, month int) (*MonthlyCalendar, error) {
+	key := aggregateKey(userID, "calendar", fmt.Sprintf("%04d-%02d", year, month), s.clock.Today(userID).Format("2006-01-02"))
+	return cachedAggregate(s.cache, key, func() (*MonthlyCalendar, error) {
+		return s.buildMonthlyCalendar(userID, year, month)
+	})
+}
+
+// buildMonthlyCalendar computes the monthly calendar for a user.
+func (s *CalendarService) buildMonthlyCalendar(userID string, year, month int) (*MonthlyCalendar, error) {
 	// Fetch active subscriptions.
 	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
 		Status:  "active",
@@ -72,92 +115,30 @@ func (s *CalendarService) GetMonthlyCalendar(userID string, year, month int) (*M
 	// Target month boundaries.
 	targetStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	targetEnd := targetStart.AddDate(0, 1, -1) // last day of month
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := s.clock.Today(userID)
 
-	// Group subscriptions by billing day in the target month.
-	dayMap := make(map[int][]CalendarSubscription) // day-of-month -> subscriptions
+	events := expandBillingEvents(activeSubs, shareMap, targetStart, targetEnd, today, s.holidays)
 
 	totalAmount := 0
-	totalCount := 0
 	remainingAmount := 0
 	remainingCount := 0
 
-	for _, sub := range activeSubs {
-		billingDay, inMonth := s.billingDayInMonth(sub, year, month)
-		if !inMonth {
-			continue
-		}
-
-		// Clamp billing day to last day of month.
-		lastDay := targetEnd.Day()
-		if billingDay > lastDay {
-			billingDay = lastDay
-		}
-
-		monthlyAmt := sub.MonthlyAmount()
-		personalAmt := monthlyAmt
-		if share, ok := shareMap[sub.ID.String()]; ok {
-			personalAmt = share.PersonalAmount(monthlyAmt)
-		}
-
-		catName := "미분류"
-		catColor := "#9E9E9E"
-		if sub.CategoryID != nil && sub.Category != nil {
-			catName = sub.Category.Name
-			if sub.Category.Color != nil {
-				catColor = *sub.Category.Color
-			}
-		}
-
-		entry := CalendarSubscription{
-			SubscriptionID: sub.ID.String(),
-			ServiceName:    sub.ServiceName,
-			Amount:         sub.Amount,
-			MonthlyAmount:  monthlyAmt,
-			PersonalAmount: personalAmt,
-			BillingCycle:   string(sub.BillingCycle),
-			CategoryName:   catName,
-			CategoryColor:  catColor,
-			AutoRenew:      sub.AutoRenew,
-		}
-
-		dayMap[billingDay] = append(dayMap[billingDay], entry)
-
-		totalAmount += personalAmt
-		totalCount++
-
-		billingDate := time.Date(year, time.Month(month), billingDay, 0, 0, 0, 0, time.UTC)
-		if !billingDate.Before(today) {
-			remainingAmount += personalAmt
+	for _, ev := range events {
+		totalAmount += ev.PersonalAmount
+		if !ev.Date.Before(today) {
+			remainingAmount += ev.PersonalAmount
 			remainingCount++
 		}
 	}
 
-	// Build sorted CalendarDay slice.
-	days := make([]CalendarDay, 0, len(dayMap))
-	for day, subs := range dayMap {
-		dayTotal := 0
-		for _, s := range subs {
-			dayTotal += s.PersonalAmount
-		}
-		days = append(days, CalendarDay{
-			Date:          fmt.Sprintf("%04d-%02d-%02d", year, month, day),
-			TotalAmount:   dayTotal,
-			Subscriptions: subs,
-		})
-	}
-	sort.Slice(days, func(i, j int) bool {
-		return days[i].Date < days[j].Date
-	})
-
 	return &MonthlyCalendar{
 		Year:            year,
 		Month:           month,
 		TotalAmount:     totalAmount,
-		TotalCount:      totalCount,
+		TotalCount:      len(events),
 		RemainingAmount: remainingAmount,
 		RemainingCount:  remainingCount,
-		Days:            days,
+		Days:            groupCalendarDays(events),
 	}, nil
 }
 
@@ -183,68 +164,32 @@ func (s *CalendarService) GetDayDetail(userID string, year, month, day int) (*Da
 
 	shareMap := buildShareMap(s.shareRepo, userID)
 
-	// Last day of the target month.
-	targetEnd := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC)
-	lastDay := targetEnd.Day()
-
 	result := &DayDetail{
 		Date:          fmt.Sprintf("%04d-%02d-%02d", year, month, day),
 		TotalAmount:   0,
 		Subscriptions: make([]CalendarSubscription, 0),
 	}
 
-	for _, sub := range activeSubs {
-		billingDay, inMonth := s.billingDayInMonth(sub, year, month)
-		if !inMonth {
-			continue
-		}
-
-		// Clamp to last day of month.
-		if billingDay > lastDay {
-			billingDay = lastDay
-		}
-
-		if billingDay != day {
-			continue
-		}
-
-		monthlyAmt := sub.MonthlyAmount()
-		personalAmt := monthlyAmt
-		if share, ok := shareMap[sub.ID.String()]; ok {
-			personalAmt = share.PersonalAmount(monthlyAmt)
-		}
-
-		catName := "미분류"
-		catColor := "#9E9E9E"
-		if sub.CategoryID != nil && sub.Category != nil {
-			catName = sub.Category.Name
-			if sub.Category.Color != nil {
-				catColor = *sub.Category.Color
-			}
-		}
-
-		entry := CalendarSubscription{
-			SubscriptionID: sub.ID.String(),
-			ServiceName:    sub.ServiceName,
-			Amount:         sub.Amount,
-			MonthlyAmount:  monthlyAmt,
-			PersonalAmount: personalAmt,
-			BillingCycle:   string(sub.BillingCycle),
-			CategoryName:   catName,
-			CategoryColor:  catColor,
-			AutoRenew:      sub.AutoRenew,
-		}
+	// Days beyond the end of the month (e.g. Feb 30) have no billing events.
+	target := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if int(target.Month()) != month {
+		return result, nil
+	}
+	today := s.clock.Today(userID)
 
-		result.Subscriptions = append(result.Subscriptions, entry)
-		result.TotalAmount += personalAmt
+	for _, ev := range expandBillingEvents(activeSubs, shareMap, target, target, today, s.holidays) {
+		result.Subscriptions = append(result.Subscriptions, toCalendarSubscription(ev))
+		result.TotalAmount += ev.PersonalAmount
 	}
 
 	return result, nil
 }
 
 // UpcomingPayment represents a single upcoming payment entry.
+// Date is the nominal billing date; AdjustedDate is the expected posting date.
 type UpcomingPayment struct {
 	Date           string `json:"date"`
+	AdjustedDate   string `json:"adjustedDate"`
 	DaysUntil      int    `json:"daysUntil"`
 	SubscriptionID string `json:"subscriptionId"`
 	ServiceName    string `json:"serviceName"`
@@ -255,6 +200,8 @@ type UpcomingPayment struct {
 }
 
 // GetUpcomingPayments returns payments due within the next N days.
+// Every occurrence within the window is returned, so a weekly subscription
+// appears once per billing week.
 func (s *CalendarService) GetUpcomingPayments(userID string, days int) ([]UpcomingPayment, error) {
 	if days <= 0 {
 		days = 30
@@ -275,84 +222,280 @@ func (s *CalendarService) GetUpcomingPayments(userID string, days int) ([]Upcomi
 
 	shareMap := buildShareMap(s.shareRepo, userID)
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := s.clock.Today(userID)
 	deadline := today.AddDate(0, 0, days)
 
-	payments := make([]UpcomingPayment, 0)
-
-	for _, sub := range activeSubs {
-		nbd := sub.NextBillingDate
-		// Only include if NextBillingDate is within range [today, deadline].
-		if nbd.Before(today) || nbd.After(deadline) {
-			continue
-		}
-
-		daysUntil := int(nbd.Sub(today).Hours() / 24)
-
-		monthlyAmt := sub.MonthlyAmount()
-		personalAmt := monthlyAmt
-		if share, ok := shareMap[sub.ID.String()]; ok {
-			personalAmt = share.PersonalAmount(monthlyAmt)
-		}
+	events := expandBillingEvents(activeSubs, shareMap, today, deadline, today, s.holidays)
+	payments := make([]UpcomingPayment, 0, len(events))
 
-		catName := "미분류"
-		catColor := "#9E9E9E"
-		if sub.CategoryID != nil && sub.Category != nil {
-			catName = sub.Category.Name
-			if sub.Category.Color != nil {
-				catColor = *sub.Category.Color
-			}
-		}
+	for _, ev := range events {
+		catName, catColor := categoryDisplay(ev.Subscription)
 
 		payments = append(payments, UpcomingPayment{
-			Date:           nbd.Format("2006-01-02"),
-			DaysUntil:      daysUntil,
-			SubscriptionID: sub.ID.String(),
-			ServiceName:    sub.ServiceName,
-			Amount:         sub.Amount,
-			PersonalAmount: personalAmt,
+			Date:           ev.Date.Format("2006-01-02"),
+			AdjustedDate:   ev.AdjustedDate.Format("2006-01-02"),
+			DaysUntil:      int(ev.Date.Sub(today).Hours() / 24),
+			SubscriptionID: ev.Subscription.ID.String(),
+			ServiceName:    ev.Subscription.ServiceName,
+			Amount:         ev.Amount,
+			PersonalAmount: ev.PersonalAmount,
 			CategoryName:   catName,
 			CategoryColor:  catColor,
 		})
 	}
 
-	// Sort by date ascending.
-	sort.Slice(payments, func(i, j int) bool {
-		return payments[i].Date < payments[j].Date
+	return payments, nil
+}
+
+// maxRangeMonths is the longest span accepted by GetRangeCalendar.
+const maxRangeMonths = 24
+
+// RangeCalendar holds billing events for an arbitrary date range, aggregated
+// per day, ISO week (Monday start) and month.
+type RangeCalendar struct {
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	TotalAmount int              `json:"totalAmount"`
+	TotalCount  int              `json:"totalCount"`
+	Days        []CalendarDay    `json:"days"`
+	Weeks       []CalendarPeriod `json:"weeks"`
+	Months      []CalendarPeriod `json:"months"`
+}
+
+// CalendarPeriod holds the billing totals for a week or month bucket.
+// Start and End are the bucket boundaries clipped to the requested range.
+type CalendarPeriod struct {
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	TotalAmount int    `json:"totalAmount"`
+	Count       int    `json:"count"`
+}
+
+// GetRangeCalendar returns every billing event between from and to (inclusive)
+// with per-day, per-week and per-month totals. The range may span at most
+// 24 months.
+func (s *CalendarService) GetRangeCalendar(userID string, from, to time.Time) (*RangeCalendar, error) {
+	from = truncateDate(from)
+	to = truncateDate(to)
+	if to.Before(from) {
+		return nil, utils.ErrBadRequest("to는 from 이후 날짜여야 합니다")
+	}
+	if !to.Before(from.AddDate(0, maxRangeMonths, 0)) {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("조회 기간은 최대 %d개월입니다", maxRangeMonths))
+	}
+
+	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
 	})
+	if err != nil {
+		slog.Error("기간 캘린더 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("캘린더 데이터를 조회할 수 없습니다")
+	}
 
-	return payments, nil
+	shareMap := buildShareMap(s.shareRepo, userID)
+	today := s.clock.Today(userID)
+	events := expandBillingEvents(activeSubs, shareMap, from, to, today, s.holidays)
+
+	totalAmount := 0
+	for _, ev := range events {
+		totalAmount += ev.PersonalAmount
+	}
+
+	return &RangeCalendar{
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		TotalAmount: totalAmount,
+		TotalCount:  len(events),
+		Days:        groupCalendarDays(events),
+		Weeks:       bucketCalendarPeriods(events, from, to, weekStart, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }),
+		Months:      bucketCalendarPeriods(events, from, to, monthStart, func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }),
+	}, nil
+}
+
+// YearlyOverview summarises a calendar year month by month and highlights the
+// months carrying large annual renewals.
+type YearlyOverview struct {
+	Year                int           `json:"year"`
+	TotalAmount         int           `json:"totalAmount"`
+	AverageMonthlyTotal int           `json:"averageMonthlyTotal"`
+	Months              []YearlyMonth `json:"months"`
 }
 
-// billingDayInMonth determines whether a subscription has a billing event in
-// the given year/month and returns the day-of-month for that event.
-func (s *CalendarService) billingDayInMonth(sub *models.Subscription, year, month int) (int, bool) {
-	nbd := sub.NextBillingDate
+// YearlyMonth holds the totals of a single month within a YearlyOverview.
+// RecurringAmount covers weekly and monthly plans; RenewalAmount covers
+// yearly renewals. IsSpike is set when the month total exceeds the yearly
+// monthly average by 50% or more because of renewals.
+type YearlyMonth struct {
+	Month           int                    `json:"month"`
+	TotalAmount     int                    `json:"totalAmount"`
+	RecurringAmount int                    `json:"recurringAmount"`
+	RenewalAmount   int                    `json:"renewalAmount"`
+	Count           int                    `json:"count"`
+	IsSpike         bool                   `json:"isSpike"`
+	Renewals        []CalendarSubscription `json:"renewals"`
+}
 
-	switch sub.BillingCycle {
-	case models.BillingCycleMonthly:
-		// Monthly subscriptions bill on the same day every month.
-		return nbd.Day(), true
+// GetYearlyOverview returns per-month billing totals for a calendar year.
+func (s *CalendarService) GetYearlyOverview(userID string, year int) (*YearlyOverview, error) {
+	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("연간 캘린더 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("캘린더 데이터를 조회할 수 없습니다")
+	}
+
+	shareMap := buildShareMap(s.shareRepo, userID)
+	today := s.clock.Today(userID)
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	months := make([]YearlyMonth, 12)
+	for i := range months {
+		months[i] = YearlyMonth{Month: i + 1, Renewals: make([]CalendarSubscription, 0)}
+	}
 
-	case models.BillingCycleYearly:
-		// Yearly subscriptions bill only if the month matches.
-		if int(nbd.Month()) == month {
-			return nbd.Day(), true
+	totalAmount := 0
+	for _, ev := range expandBillingEvents(activeSubs, shareMap, from, to, today, s.holidays) {
+		m := &months[int(ev.Date.Month())-1]
+		m.TotalAmount += ev.PersonalAmount
+		m.Count++
+		if ev.Subscription.BillingCycle == models.BillingCycleYearly {
+			m.RenewalAmount += ev.PersonalAmount
+			m.Renewals = append(m.Renewals, toCalendarSubscription(ev))
+		} else {
+			m.RecurringAmount += ev.PersonalAmount
 		}
-		return 0, false
+		totalAmount += ev.PersonalAmount
+	}
 
-	case models.BillingCycleWeekly:
-		// Weekly subscriptions: check if NextBillingDate itself falls in this month.
-		if nbd.Year() == year && int(nbd.Month()) == month {
-			return nbd.Day(), true
+	average := int(math.Round(float64(totalAmount) / 12.0))
+	for i := range months {
+		m := &months[i]
+		m.IsSpike = m.RenewalAmount > 0 && average > 0 && float64(m.TotalAmount) >= float64(average)*1.5
+	}
+
+	return &YearlyOverview{
+		Year:                year,
+		TotalAmount:         totalAmount,
+		AverageMonthlyTotal: average,
+		Months:              months,
+	}, nil
+}
+
+// bucketCalendarPeriods aggregates events into consecutive buckets covering
+// [from, to]. startOf returns the bucket start containing a date and next
+// advances a bucket start to the following bucket.
+func bucketCalendarPeriods(events []BillingEvent, from, to time.Time, startOf func(time.Time) time.Time, next func(time.Time) time.Time) []CalendarPeriod {
+	periods := make([]CalendarPeriod, 0)
+	index := make(map[time.Time]int)
+
+	for bucket := startOf(from); !bucket.After(to); bucket = next(bucket) {
+		start := bucket
+		if start.Before(from) {
+			start = from
+		}
+		end := next(bucket).AddDate(0, 0, -1)
+		if end.After(to) {
+			end = to
+		}
+		index[bucket] = len(periods)
+		periods = append(periods, CalendarPeriod{
+			Start: start.Format("2006-01-02"),
+			End:   end.Format("2006-01-02"),
+		})
+	}
+
+	for _, ev := range events {
+		if i, ok := index[startOf(ev.Date)]; ok {
+			periods[i].TotalAmount += ev.PersonalAmount
+			periods[i].Count++
 		}
-		return 0, false
+	}
+
+	return periods
+}
+
+// weekStart returns the Monday of the ISO week containing t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return truncateDate(t).AddDate(0, 0, -offset)
+}
+
+// monthStart returns the first day of the month containing t.
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// groupCalendarDays groups billing events by date into a sorted CalendarDay slice.
+func groupCalendarDays(events []BillingEvent) []CalendarDay {
+	dayMap := make(map[string]*CalendarDay) // YYYY-MM-DD -> day
+	for _, ev := range events {
+		date := ev.Date.Format("2006-01-02")
+		day, ok := dayMap[date]
+		if !ok {
+			day = &CalendarDay{Date: date, Subscriptions: make([]CalendarSubscription, 0)}
+			dayMap[date] = day
+		}
+		day.Subscriptions = append(day.Subscriptions, toCalendarSubscription(ev))
+		day.TotalAmount += ev.PersonalAmount
+	}
+
+	days := make([]CalendarDay, 0, len(dayMap))
+	for _, day := range dayMap {
+		days = append(days, *day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+
+	return days
+}
+
+// toCalendarSubscription converts a billing event into a calendar entry.
+func toCalendarSubscription(ev BillingEvent) CalendarSubscription {
+	sub := ev.Subscription
+	catName, catColor := categoryDisplay(sub)
+
+	return CalendarSubscription{
+		SubscriptionID:        sub.ID.String(),
+		ServiceName:           sub.ServiceName,
+		Amount:                ev.Amount,
+		MonthlyAmount:         sub.MonthlyAmount(),
+		PersonalAmount:        ev.PersonalAmount,
+		BillingCycle:          string(sub.BillingCycle),
+		CategoryName:          catName,
+		CategoryColor:         catColor,
+		AutoRenew:             sub.AutoRenew,
+		NominalDate:           ev.Date.Format("2006-01-02"),
+		AdjustedDate:          ev.AdjustedDate.Format("2006-01-02"),
+		BusinessDayAdjustment: string(businessDayAdjustmentOrDefault(sub.BusinessDayAdjustment)),
+	}
+}
+
+// businessDayAdjustmentOrDefault returns "none" for subscriptions created
+// before the adjustment setting existed.
+func businessDayAdjustmentOrDefault(mode models.BusinessDayAdjustment) models.BusinessDayAdjustment {
+	if mode == "" {
+		return models.BusinessDayAdjustmentNone
+	}
+	return mode
+}
 
-	default:
-		// Fallback: check if NextBillingDate is in the target month.
-		if nbd.Year() == year && int(nbd.Month()) == month {
-			return nbd.Day(), true
+// categoryDisplay returns the category name and color for a subscription,
+// falling back to the "미분류" defaults.
+func categoryDisplay(sub *models.Subscription) (string, string) {
+	catName := "미분류"
+	catColor := "#9E9E9E"
+	if sub.CategoryID != nil && sub.Category != nil {
+		catName = sub.Category.Name
+		if sub.Category.Color != nil {
+			catColor = *sub.Category.Color
 		}
-		return 0, false
 	}
+	return catName, catColor
 }
@@ -51,6 +51,13 @@ func (m *mockSubRepoForCalendar) FindDuplicateName(userID, name string) (bool, e
 func (m *mockSubRepoForCalendar) FindSimilarInCategory(userID string, categoryID string, excludeSubID string) ([]*models.Subscription, error) {
 	return nil, nil
 }
+func (m *mockSubRepoForCalendar) FindDeletedByUserIDSince(userID string, since time.Time) ([]*models.Subscription, error) {
+	return nil, nil
+}
+
+func (m *mockSubRepoForCalendar) FindActiveUserIDs() ([]string, error) {
+	return nil, nil
+}
 
 type mockShareRepoForCalendar struct {
 	shares map[string]*models.SubscriptionShare
@@ -77,6 +84,18 @@ func (m *mockShareRepoForCalendar) Create(share *models.SubscriptionShare) error
 func (m *mockShareRepoForCalendar) Update(share *models.SubscriptionShare) error  { return nil }
 func (m *mockShareRepoForCalendar) Delete(id string) error                        { return nil }
 func (m *mockShareRepoForCalendar) DeleteBySubscriptionID(subscriptionID string) error { return nil }
+func (m *mockShareRepoForCalendar) FindSharingUserIDs() ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+	for _, s := range m.shares {
+		uid := s.Subscription.UserID.String()
+		if s.Subscription.UserID != uuid.Nil && !seen[uid] {
+			seen[uid] = true
+			result = append(result, uid)
+		}
+	}
+	return result, nil
+}
 
 // ---------------------------------------------------------------------------
 // Helpers
@@ -296,9 +315,12 @@ func TestGetMonthlyCalendar_YearlyBillingCycle_MatchingMonth(t *testing.T) {
 	if cal.TotalCount != 1 {
 		t.Errorf("expected 1 subscription in matching month, got %d", cal.TotalCount)
 	}
-	// Yearly 132000 / 12 = 11000.
-	if cal.TotalAmount != 11000 {
-		t.Errorf("expected monthly amount 11000, got %d", cal.TotalAmount)
+	// The full yearly charge lands in the billing month.
+	if cal.TotalAmount != 132000 {
+		t.Errorf("expected charged amount 132000, got %d", cal.TotalAmount)
+	}
+	if cal.Days[0].Subscriptions[0].MonthlyAmount != 11000 {
+		t.Errorf("expected monthly amount 11000, got %d", cal.Days[0].Subscriptions[0].MonthlyAmount)
 	}
 }
 
@@ -326,22 +348,36 @@ func TestGetMonthlyCalendar_WeeklyBillingCycle(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	// Weekly sub with NextBillingDate in March 2026.
-	seedCalendarSub(repo, userID, "Gym", 5000, models.BillingCycleWeekly,
+	// Weekly sub anchored on Thursday 2026-03-12.
+	sub := seedCalendarSub(repo, userID, "Gym", 5000, models.BillingCycleWeekly,
 		time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC), nil)
+	sub.StartDate = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
 
+	// March: 5, 12, 19, 26.
 	cal, err := svc.GetMonthlyCalendar(userID.String(), 2026, 3)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if cal.TotalCount != 1 {
-		t.Errorf("expected 1 subscription, got %d", cal.TotalCount)
+	if cal.TotalCount != 4 {
+		t.Errorf("expected 4 occurrences in March, got %d", cal.TotalCount)
+	}
+	if cal.TotalAmount != 20000 {
+		t.Errorf("expected totalAmount 20000, got %d", cal.TotalAmount)
+	}
+	if len(cal.Days) != 4 || cal.Days[0].Date != "2026-03-05" || cal.Days[3].Date != "2026-03-26" {
+		t.Errorf("unexpected March billing days: %+v", cal.Days)
 	}
 
-	// Should NOT appear in April.
+	// April: 2, 9, 16, 23, 30.
 	cal4, _ := svc.GetMonthlyCalendar(userID.String(), 2026, 4)
-	if cal4.TotalCount != 0 {
-		t.Errorf("expected 0 subscriptions in April, got %d", cal4.TotalCount)
+	if cal4.TotalCount != 5 {
+		t.Errorf("expected 5 occurrences in April, got %d", cal4.TotalCount)
+	}
+
+	// February is before StartDate.
+	cal2, _ := svc.GetMonthlyCalendar(userID.String(), 2026, 2)
+	if cal2.TotalCount != 0 {
+		t.Errorf("expected 0 occurrences before start date, got %d", cal2.TotalCount)
 	}
 }
 
@@ -629,7 +665,7 @@ func TestGetUpcomingPayments_WithinRange(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := NewUserClock(nil, nil).Today("")
 
 	// Within 30 days.
 	seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
@@ -656,7 +692,7 @@ func TestGetUpcomingPayments_SortedByDate(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := NewUserClock(nil, nil).Today("")
 
 	seedCalendarSub(repo, userID, "Later", 5000, models.BillingCycleMonthly,
 		today.AddDate(0, 0, 20), nil)
@@ -684,7 +720,7 @@ func TestGetUpcomingPayments_DaysUntilCalculation(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := NewUserClock(nil, nil).Today("")
 	seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
 		today.AddDate(0, 0, 7), nil)
 
@@ -706,7 +742,7 @@ func TestGetUpcomingPayments_DefaultDays(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := NewUserClock(nil, nil).Today("")
 	// 35 days out — should NOT be included with default 30 days.
 	seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
 		today.AddDate(0, 0, 35), nil)
@@ -726,7 +762,7 @@ func TestGetUpcomingPayments_MaxDaysClamped(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := NewUserClock(nil, nil).Today("")
 	// 85 days out — within 90 max.
 	seedCalendarSub(repo, userID, "Annual", 100000, models.BillingCycleYearly,
 		today.AddDate(0, 0, 85), nil)
@@ -746,7 +782,7 @@ func TestGetUpcomingPayments_WithShareAmount(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := NewUserClock(nil, nil).Today("")
 	sub := seedCalendarSub(repo, userID, "Netflix", 20000, models.BillingCycleMonthly,
 		today.AddDate(0, 0, 10), nil)
 
@@ -777,16 +813,270 @@ func TestGetUpcomingPayments_PastBillingDateExcluded(t *testing.T) {
 	svc := NewCalendarService(repo, shareRepo)
 	userID := uuid.New()
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
-	// Past date — should be excluded.
-	seedCalendarSub(repo, userID, "Old", 5000, models.BillingCycleMonthly,
-		today.AddDate(0, 0, -5), nil)
+	today := NewUserClock(nil, nil).Today("")
+	// Past date — the stale occurrence is excluded, the next cycle is not.
+	past := today.AddDate(0, 0, -5)
+	seedCalendarSub(repo, userID, "Old", 5000, models.BillingCycleMonthly, past, nil)
 
 	payments, err := svc.GetUpcomingPayments(userID.String(), 30)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(payments) != 0 {
-		t.Errorf("expected 0 payments for past billing, got %d", len(payments))
+	if len(payments) != 1 {
+		t.Fatalf("expected 1 payment for the next cycle, got %d", len(payments))
 	}
+	if want := past.AddDate(0, 1, 0).Format("2006-01-02"); payments[0].Date != want {
+		t.Errorf("expected next cycle on %s, got %s", want, payments[0].Date)
+	}
+}
+
+func TestGetUpcomingPayments_WeeklyRecurrence(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	svc := NewCalendarService(repo, shareRepo)
+	userID := uuid.New()
+
+	today := NewUserClock(nil, nil).Today("")
+	seedCalendarSub(repo, userID, "Gym", 5000, models.BillingCycleWeekly,
+		today.AddDate(0, 0, 2), nil)
+
+	// 2, 9, 16, 23, 30 days out.
+	payments, err := svc.GetUpcomingPayments(userID.String(), 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payments) != 5 {
+		t.Fatalf("expected 5 weekly payments, got %d", len(payments))
+	}
+	for i, p := range payments {
+		if p.DaysUntil != 2+7*i {
+			t.Errorf("payment %d: expected daysUntil %d, got %d", i, 2+7*i, p.DaysUntil)
+		}
+	}
+}
+
+// ===========================================================================
+// GetRangeCalendar
+// ===========================================================================
+
+func TestGetRangeCalendar_WeekAndMonthBuckets(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	svc := NewCalendarService(repo, shareRepo)
+	userID := uuid.New()
+
+	seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
+		time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), nil)
+	seedCalendarSub(repo, userID, "Gym", 5000, models.BillingCycleWeekly,
+		time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), nil)
+
+	// 2026-03-01 (Sun) ~ 2026-04-30 (Thu).
+	cal, err := svc.GetRangeCalendar(userID.String(),
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Netflix x2, Gym on every Monday from 3/2 to 4/27 (9).
+	if cal.TotalCount != 11 {
+		t.Errorf("expected 11 events, got %d", cal.TotalCount)
+	}
+	if cal.TotalAmount != 17000*2+5000*9 {
+		t.Errorf("expected totalAmount %d, got %d", 17000*2+5000*9, cal.TotalAmount)
+	}
+
+	if len(cal.Months) != 2 {
+		t.Fatalf("expected 2 month buckets, got %d", len(cal.Months))
+	}
+	if cal.Months[0].Start != "2026-03-01" || cal.Months[0].End != "2026-03-31" {
+		t.Errorf("unexpected March bucket: %+v", cal.Months[0])
+	}
+	if cal.Months[0].TotalAmount != 17000+5000*5 {
+		t.Errorf("expected March total %d, got %d", 17000+5000*5, cal.Months[0].TotalAmount)
+	}
+
+	// First week is clipped to the range start (Sunday 3/1 only).
+	if cal.Weeks[0].Start != "2026-03-01" || cal.Weeks[0].End != "2026-03-01" {
+		t.Errorf("unexpected first week bucket: %+v", cal.Weeks[0])
+	}
+	if cal.Weeks[1].Start != "2026-03-02" || cal.Weeks[1].Count != 1 {
+		t.Errorf("unexpected second week bucket: %+v", cal.Weeks[1])
+	}
+	if last := cal.Weeks[len(cal.Weeks)-1]; last.End != "2026-04-30" {
+		t.Errorf("expected last week clipped to 2026-04-30, got %s", last.End)
+	}
+}
+
+func TestGetRangeCalendar_InvalidRange(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	svc := NewCalendarService(repo, shareRepo)
+	userID := uuid.New()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := svc.GetRangeCalendar(userID.String(), from, from.AddDate(0, 0, -1)); err == nil {
+		t.Error("expected error when to is before from")
+	}
+	if _, err := svc.GetRangeCalendar(userID.String(), from, from.AddDate(0, 24, 0)); err == nil {
+		t.Error("expected error for range longer than 24 months")
+	}
+	if _, err := svc.GetRangeCalendar(userID.String(), from, from.AddDate(0, 24, -1)); err != nil {
+		t.Errorf("expected 24-month range to be accepted, got %v", err)
+	}
+}
+
+// ===========================================================================
+// GetYearlyOverview
+// ===========================================================================
+
+func TestGetYearlyOverview_AnnualRenewalSpike(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	svc := NewCalendarService(repo, shareRepo)
+	userID := uuid.New()
+
+	seedCalendarSub(repo, userID, "Netflix", 10000, models.BillingCycleMonthly,
+		time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), nil)
+	seedCalendarSub(repo, userID, "iCloud", 120000, models.BillingCycleYearly,
+		time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC), nil)
+
+	overview, err := svc.GetYearlyOverview(userID.String(), 2026)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if overview.TotalAmount != 10000*12+120000 {
+		t.Errorf("expected total %d, got %d", 10000*12+120000, overview.TotalAmount)
+	}
+	if len(overview.Months) != 12 {
+		t.Fatalf("expected 12 months, got %d", len(overview.Months))
+	}
+
+	june := overview.Months[5]
+	if june.RenewalAmount != 120000 || june.RecurringAmount != 10000 {
+		t.Errorf("unexpected June totals: %+v", june)
+	}
+	if !june.IsSpike {
+		t.Error("expected June to be flagged as a spike month")
+	}
+	if len(june.Renewals) != 1 || june.Renewals[0].ServiceName != "iCloud" {
+		t.Errorf("expected iCloud renewal in June, got %+v", june.Renewals)
+	}
+	if overview.Months[0].IsSpike {
+		t.Error("expected January not to be flagged as a spike month")
+	}
+}
+
+func TestGetMonthlyCalendar_BusinessDayAdjustment(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	holidays, err := LoadHolidayCalendar("")
+	if err != nil {
+		t.Fatalf("load holidays: %v", err)
+	}
+	svc := NewCalendarServiceWithDeps(repo, shareRepo, CalendarDeps{Holidays: holidays})
+	userID := uuid.New()
+
+	// 2026-02-17 is 설날 → next business day is Thursday 2026-02-19.
+	sub := seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
+		time.Date(2026, 2, 17, 0, 0, 0, 0, time.UTC), nil)
+	sub.BusinessDayAdjustment = models.BusinessDayAdjustmentNext
+
+	cal, err := svc.GetMonthlyCalendar(userID.String(), 2026, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cal.Days) != 1 {
+		t.Fatalf("expected 1 day, got %d", len(cal.Days))
+	}
+	entry := cal.Days[0].Subscriptions[0]
+	if cal.Days[0].Date != "2026-02-17" || entry.NominalDate != "2026-02-17" {
+		t.Errorf("expected nominal date 2026-02-17, got day %s / entry %s", cal.Days[0].Date, entry.NominalDate)
+	}
+	if entry.AdjustedDate != "2026-02-19" {
+		t.Errorf("expected adjusted date 2026-02-19, got %s", entry.AdjustedDate)
+	}
+	if entry.BusinessDayAdjustment != "next_business_day" {
+		t.Errorf("expected next_business_day, got %s", entry.BusinessDayAdjustment)
+	}
+
+	// Without an adjustment the dates are identical.
+	sub.BusinessDayAdjustment = ""
+	cal, _ = svc.GetMonthlyCalendar(userID.String(), 2026, 2)
+	entry = cal.Days[0].Subscriptions[0]
+	if entry.AdjustedDate != entry.NominalDate || entry.BusinessDayAdjustment != "none" {
+		t.Errorf("expected unadjusted entry, got %+v", entry)
+	}
+}
+
+func TestGetMonthlyCalendar_PromotionAndScheduledPause(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)), nil)
+	svc := NewCalendarServiceWithDeps(repo, shareRepo, CalendarDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
+		time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), nil)
+	promoEnd := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	pauseStart, pauseEnd := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	sub.PromoAmount, sub.PromoEndDate = intPtr(5000), &promoEnd
+	sub.PauseStartDate, sub.PauseEndDate = &pauseStart, &pauseEnd
+
+	totals := make([]int, 0, 4)
+	for month := 3; month <= 6; month++ {
+		cal, err := svc.GetMonthlyCalendar(userID.String(), 2026, month)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		totals = append(totals, cal.TotalAmount)
+	}
+	assertEqual(t, totals, []int{5000, 17000, 0, 17000})
+
+	payments, err := svc.GetUpcomingPayments(userID.String(), 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payments) != 1 || payments[0].Amount != 5000 {
+		t.Errorf("expected the promotional amount for the next payment, got %+v", payments)
+	}
+}
+
+func TestGetUpcomingPayments_UserTimezone(t *testing.T) {
+	repo := newMockSubRepoForCalendar()
+	shareRepo := newMockShareRepoForCalendar()
+	users := newMockUserRepo()
+	seoul := &models.User{ID: uuid.New(), Timezone: "Asia/Seoul"}
+	utc := &models.User{ID: uuid.New(), Timezone: "UTC"}
+	users.users[seoul.ID.String()] = seoul
+	users.users[utc.ID.String()] = utc
+
+	// 2026-03-31 16:00 UTC is 2026-04-01 01:00 in Seoul.
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)), users)
+	svc := NewCalendarServiceWithDeps(repo, shareRepo, CalendarDeps{Clock: clock})
+
+	for _, u := range []*models.User{seoul, utc} {
+		seedCalendarSub(repo, u.ID, "Netflix", 17000, models.BillingCycleMonthly,
+			time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), nil)
+	}
+
+	seoulPayments, err := svc.GetUpcomingPayments(seoul.ID.String(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seoulPayments) != 1 || seoulPayments[0].DaysUntil != 0 {
+		t.Errorf("expected the Seoul user to be charged today, got %+v", seoulPayments)
+	}
+
+	utcPayments, err := svc.GetUpcomingPayments(utc.ID.String(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(utcPayments) != 1 || utcPayments[0].DaysUntil != 1 {
+		t.Errorf("expected the UTC user to be charged tomorrow, got %+v", utcPayments)
+	}
+
+	assertEqual(t, svc.Today(seoul.ID.String()).Format("2006-01-02"), "2026-04-01")
+	assertEqual(t, svc.Today(utc.ID.String()).Format("2006-01-02"), "2026-03-31")
 }
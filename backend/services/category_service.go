@@ -31,12 +31,27 @@ type UpdateCategoryRequest struct {
 
 // CategoryService handles business logic for categories.
 type CategoryService struct {
-	repo repositories.CategoryRepository
+	repo  repositories.CategoryRepository
+	cache *AggregateCache
 }
 
-// NewCategoryService creates a new CategoryService.
+// CategoryDeps holds the optional dependencies of a CategoryService. Any of
+// them may be nil.
+type CategoryDeps struct {
+	Cache *AggregateCache // nil: no cached aggregates are invalidated
+}
+
+// NewCategoryService creates a new CategoryService without optional dependencies.
 func NewCategoryService(repo repositories.CategoryRepository) *CategoryService {
-	return &CategoryService{repo: repo}
+	return NewCategoryServiceWithDeps(repo, CategoryDeps{})
+}
+
+// NewCategoryServiceWithDeps creates a new CategoryService with the given optional dependencies.
+func NewCategoryServiceWithDeps(repo repositories.CategoryRepository, deps CategoryDeps) *CategoryService {
+	return &CategoryService{
+		repo:  repo,
+		cache: deps.Cache,
+	}
 }
 
 // GetCategories returns system categories and user's custom categories.
@@ -81,6 +96,7 @@ func (s *CategoryService) CreateCategory(userID string, req *CreateCategoryReque
 		slog.Error("카테고리 생성 실패", "userID", userID, "error", err)
 		return nil, utils.ErrInternal("카테고리를 생성할 수 없습니다")
 	}
+	s.cache.InvalidateUser(userID)
 
 	return cat, nil
 }
@@ -137,6 +153,7 @@ func (s *CategoryService) UpdateCategory(userID, categoryID string, req *UpdateC
 		slog.Error("카테고리 수정 실패", "categoryID", categoryID, "error", err)
 		return nil, utils.ErrInternal("카테고리를 수정할 수 없습니다")
 	}
+	s.cache.InvalidateUser(userID)
 
 	return cat, nil
 }
@@ -191,6 +208,7 @@ func (s *CategoryService) DeleteCategory(userID, categoryID string) error {
 		slog.Error("카테고리 삭제 실패", "categoryID", categoryID, "error", err)
 		return utils.ErrInternal("카테고리를 삭제할 수 없습니다")
 	}
+	s.cache.InvalidateUser(userID)
 
 	return nil
 }
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// Clock abstracts the current time so that date calculations can be tested.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock returns a Clock that reports the real current time.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// UserClock resolves "now" and "today" in each user's own time zone.
+//
+// Date-only fields (billing dates, start dates) are stored as calendar dates at
+// UTC midnight, so Today returns the user's local calendar date in that same
+// representation to keep comparisons and arithmetic consistent.
+//
+// Time zones are cached per user for locationTTL, so that services asking for
+// the date several times per request load the user once. Forget drops a cached
+// time zone when the user changes it.
+//
+// A nil *UserClock uses the system clock and models.DefaultTimezone.
+type UserClock struct {
+	clock    Clock
+	userRepo repositories.UserRepository
+
+	mu        sync.Mutex
+	locations map[string]cachedLocation
+}
+
+// locationTTL bounds how long a cached time zone is used, since a change made
+// through another instance is not forgotten here.
+const locationTTL = 10 * time.Minute
+
+// cachedLocation is a user's time zone and when it was loaded.
+type cachedLocation struct {
+	loc      *time.Location
+	loadedAt time.Time
+}
+
+// NewUserClock creates a new UserClock. clock defaults to SystemClock when nil;
+// userRepo may be nil, in which case every user is in models.DefaultTimezone.
+func NewUserClock(clock Clock, userRepo repositories.UserRepository) *UserClock {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &UserClock{clock: clock, userRepo: userRepo, locations: make(map[string]cachedLocation)}
+}
+
+// Location returns the user's time zone, falling back to the default when the
+// user cannot be loaded. The fallback is not cached.
+func (c *UserClock) Location(userID string) *time.Location {
+	if c == nil || c.userRepo == nil {
+		return models.DefaultLocation()
+	}
+
+	now := c.now()
+	c.mu.Lock()
+	cached, ok := c.locations[userID]
+	c.mu.Unlock()
+	if ok && now.Sub(cached.loadedAt) < locationTTL {
+		return cached.loc
+	}
+
+	user, err := c.userRepo.FindByID(userID)
+	if err != nil {
+		slog.Warn("사용자 시간대 조회 실패, 기본 시간대 사용", "userID", userID, "error", err)
+		return models.DefaultLocation()
+	}
+	loc := user.Location()
+
+	c.mu.Lock()
+	c.locations[userID] = cachedLocation{loc: loc, loadedAt: now}
+	c.mu.Unlock()
+	return loc
+}
+
+// Forget drops the cached time zone of the user.
+func (c *UserClock) Forget(userID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.locations, userID)
+	c.mu.Unlock()
+}
+
+// Now returns the current instant in the user's time zone.
+func (c *UserClock) Now(userID string) time.Time {
+	return c.now().In(c.Location(userID))
+}
+
+// Today returns the user's local calendar date as a UTC-midnight date value.
+func (c *UserClock) Today(userID string) time.Time {
+	return localDate(c.Now(userID))
+}
+
+// now returns the current instant from the underlying clock.
+func (c *UserClock) now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// localDate converts an instant to its wall-clock calendar date at UTC midnight.
+func localDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+// fixedClock is a Clock that always reports the same instant.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestUserClock_Today(t *testing.T) {
+	users := newMockUserRepo()
+	seoul := &models.User{ID: uuid.New(), Timezone: "Asia/Seoul"}
+	newYork := &models.User{ID: uuid.New(), Timezone: "America/New_York"}
+	unset := &models.User{ID: uuid.New()}
+	invalid := &models.User{ID: uuid.New(), Timezone: "Mars/Olympus_Mons"}
+	for _, u := range []*models.User{seoul, newYork, unset, invalid} {
+		users.users[u.ID.String()] = u
+	}
+
+	// 2026-03-31 16:00 UTC is already April 1st in Seoul.
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)), users)
+
+	tests := []struct {
+		name   string
+		userID string
+		want   string
+	}{
+		{"Seoul is nine hours ahead", seoul.ID.String(), "2026-04-01"},
+		{"New York is behind UTC", newYork.ID.String(), "2026-03-31"},
+		{"empty timezone uses the default", unset.ID.String(), "2026-04-01"},
+		{"unknown timezone uses the default", invalid.ID.String(), "2026-04-01"},
+		{"missing user uses the default", uuid.New().String(), "2026-04-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clock.Today(tt.userID)
+			assertEqual(t, got.Format("2006-01-02"), tt.want)
+			assertEqual(t, got.Location(), time.UTC)
+		})
+	}
+}
+
+func TestUserClock_Now(t *testing.T) {
+	users := newMockUserRepo()
+	user := &models.User{ID: uuid.New(), Timezone: "Asia/Seoul"}
+	users.users[user.ID.String()] = user
+
+	instant := time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)
+	clock := NewUserClock(fixedClock(instant), users)
+
+	got := clock.Now(user.ID.String())
+	if !got.Equal(instant) {
+		t.Errorf("expected the same instant, got %v", got)
+	}
+	assertEqual(t, got.Hour(), 1)
+}
+
+func TestUserClock_CachesLocation(t *testing.T) {
+	users := newMockUserRepo()
+	user := &models.User{ID: uuid.New(), Timezone: "Asia/Seoul"}
+	users.users[user.ID.String()] = user
+
+	now := &movableClock{now: time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)}
+	clock := NewUserClock(now, users)
+	assertEqual(t, clock.Location(user.ID.String()).String(), "Asia/Seoul")
+
+	// Changed behind the clock's back: the cached zone is still used.
+	user.Timezone = "America/New_York"
+	assertEqual(t, clock.Location(user.ID.String()).String(), "Asia/Seoul")
+
+	t.Run("reloads after forgetting", func(t *testing.T) {
+		clock.Forget(user.ID.String())
+		assertEqual(t, clock.Location(user.ID.String()).String(), "America/New_York")
+	})
+
+	t.Run("reloads after the TTL", func(t *testing.T) {
+		user.Timezone = "Europe/Berlin"
+		now.now = now.now.Add(locationTTL)
+		assertEqual(t, clock.Location(user.ID.String()).String(), "Europe/Berlin")
+	})
+}
+
+func TestUserClock_Nil(t *testing.T) {
+	var clock *UserClock
+	assertEqual(t, clock.Location("any").String(), models.DefaultTimezone)
+	if clock.Today("any").IsZero() {
+		t.Error("expected a nil clock to fall back to the system clock")
+	}
+}
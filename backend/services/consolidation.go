@@ -0,0 +1,134 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/subkeep/backend/models"
+)
+
+// OverlappingService is one member of a group of services doing the same job.
+type OverlappingService struct {
+	SubscriptionID    string `json:"subscriptionId"`
+	ServiceName       string `json:"serviceName"`
+	MonthlyAmount     int    `json:"monthlyAmount"`
+	SatisfactionScore *int   `json:"satisfactionScore"`
+}
+
+// ConsolidationOption estimates the saving of keeping a single service of a
+// group and cancelling the rest.
+type ConsolidationOption struct {
+	KeepSubscriptionID string `json:"keepSubscriptionId"`
+	KeepServiceName    string `json:"keepServiceName"`
+	MonthlySaving      int    `json:"monthlySaving"`
+	AnnualSaving       int    `json:"annualSaving"`
+}
+
+// ConsolidationDetail describes overlapping services within a functional group.
+// KeepMostLiked is nil when none of the services has a satisfaction score.
+type ConsolidationDetail struct {
+	Group         models.FunctionalGroup `json:"group"`
+	GroupLabel    string                 `json:"groupLabel"`
+	Services      []OverlappingService   `json:"services"`
+	MonthlyTotal  int                    `json:"monthlyTotal"`
+	KeepMostLiked *ConsolidationOption   `json:"keepMostLiked"`
+	KeepCheapest  ConsolidationOption    `json:"keepCheapest"`
+}
+
+// suggested returns the option the recommendation is based on: keeping the
+// most-liked service when satisfaction is known, otherwise the cheapest.
+func (d *ConsolidationDetail) suggested() ConsolidationOption {
+	if d.KeepMostLiked != nil {
+		return *d.KeepMostLiked
+	}
+	return d.KeepCheapest
+}
+
+// findOverlaps groups the candidates by functional group and returns a detail
+// for every group with two or more services, ordered by group name.
+func findOverlaps(candidates []recommendationCandidate, catalog *ServiceCatalog) []*ConsolidationDetail {
+	groups := make(map[models.FunctionalGroup][]recommendationCandidate)
+	for _, c := range candidates {
+		if group, ok := catalog.FunctionalGroupOf(c.sub); ok {
+			groups[group] = append(groups[group], c)
+		}
+	}
+
+	details := make([]*ConsolidationDetail, 0)
+	for group, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		details = append(details, newConsolidationDetail(group, members))
+	}
+	sort.Slice(details, func(i, j int) bool { return details[i].Group < details[j].Group })
+	return details
+}
+
+// newConsolidationDetail builds the detail and saving estimates for one group.
+func newConsolidationDetail(group models.FunctionalGroup, members []recommendationCandidate) *ConsolidationDetail {
+	// Cheapest first; ties go to the better-liked service, then by name.
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].monthly != members[j].monthly {
+			return members[i].monthly < members[j].monthly
+		}
+		si, sj := satisfactionOrZero(members[i].sub), satisfactionOrZero(members[j].sub)
+		if si != sj {
+			return si > sj
+		}
+		return members[i].sub.ServiceName < members[j].sub.ServiceName
+	})
+
+	detail := &ConsolidationDetail{
+		Group:      group,
+		GroupLabel: group.Label(),
+		Services:   make([]OverlappingService, len(members)),
+	}
+	for i, m := range members {
+		detail.Services[i] = OverlappingService{
+			SubscriptionID:    m.sub.ID.String(),
+			ServiceName:       m.sub.ServiceName,
+			MonthlyAmount:     m.monthly,
+			SatisfactionScore: m.sub.SatisfactionScore,
+		}
+		detail.MonthlyTotal += m.monthly
+	}
+
+	detail.KeepCheapest = consolidationOption(members[0], detail.MonthlyTotal)
+
+	// The most-liked service is the highest-rated one; members are already
+	// ordered by price, so the first of equally rated services is the cheapest.
+	var liked *recommendationCandidate
+	for i, m := range members {
+		if m.sub.SatisfactionScore == nil {
+			continue
+		}
+		if liked == nil || *m.sub.SatisfactionScore > *liked.sub.SatisfactionScore {
+			liked = &members[i]
+		}
+	}
+	if liked != nil {
+		option := consolidationOption(*liked, detail.MonthlyTotal)
+		detail.KeepMostLiked = &option
+	}
+
+	return detail
+}
+
+// consolidationOption computes the saving of keeping only the given service.
+func consolidationOption(keep recommendationCandidate, monthlyTotal int) ConsolidationOption {
+	saving := monthlyTotal - keep.monthly
+	return ConsolidationOption{
+		KeepSubscriptionID: keep.sub.ID.String(),
+		KeepServiceName:    keep.sub.ServiceName,
+		MonthlySaving:      saving,
+		AnnualSaving:       saving * 12,
+	}
+}
+
+// satisfactionOrZero returns the satisfaction score, or 0 when unrated.
+func satisfactionOrZero(sub *models.Subscription) int {
+	if sub.SatisfactionScore == nil {
+		return 0
+	}
+	return *sub.SatisfactionScore
+}
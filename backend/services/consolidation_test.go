@@ -0,0 +1,90 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+func TestGetRecommendations_Consolidation(t *testing.T) {
+	catalog, err := NewServiceCatalog([]CatalogEntry{
+		{Name: "Spotify", Group: models.FunctionalGroupMusic},
+		{Name: "Melon", Group: models.FunctionalGroupMusic},
+		{Name: "Genie", Group: models.FunctionalGroupMusic},
+		{Name: "Netflix", Group: models.FunctionalGroupVideo},
+	})
+	assertNil(t, err)
+
+	t.Run("keeps the most-liked service and estimates both options", func(t *testing.T) {
+		repo := newMockRepo()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Catalog: catalog})
+		userID := uuid.New()
+
+		spotify := repo.seedSubscriptionWithDetails(userID, "Spotify", 10900, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+		melon := repo.seedSubscriptionWithDetails(userID, "Melon", 7900, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(4), nil)
+		repo.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+
+		rec := recs[0]
+		assertEqual(t, RecommendationTypeConsolidate, rec.Type)
+		assertEqual(t, melon.ID.String(), rec.SubscriptionID)
+		assertEqual(t, "음악 스트리밍 서비스 중복 (Spotify 유지 추천)", rec.Reason)
+		assertEqual(t, 7900*12, rec.AnnualSaving)
+
+		detail := rec.Consolidation
+		assertNotNil(t, detail)
+		assertEqual(t, 2, len(detail.Services))
+		assertEqual(t, 18800, detail.MonthlyTotal)
+		assertEqual(t, spotify.ID.String(), detail.KeepMostLiked.KeepSubscriptionID)
+		assertEqual(t, 7900, detail.KeepMostLiked.MonthlySaving)
+		assertEqual(t, melon.ID.String(), detail.KeepCheapest.KeepSubscriptionID)
+		assertEqual(t, 10900*12, detail.KeepCheapest.AnnualSaving)
+	})
+
+	t.Run("falls back to the cheapest service without ratings", func(t *testing.T) {
+		repo := newMockRepo()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Catalog: catalog})
+		userID := uuid.New()
+
+		repo.seedSubscriptionWithDetails(userID, "Spotify", 10900, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+		repo.seedSubscriptionWithDetails(userID, "Melon", 7900, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+		repo.seedSubscriptionWithDetails(userID, "Genie", 8400, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 2, len(recs))
+		for _, rec := range recs {
+			if rec.ServiceName == "Melon" {
+				t.Error("expected the cheapest service to be kept")
+			}
+			if rec.Consolidation.KeepMostLiked != nil {
+				t.Error("expected no most-liked option without ratings")
+			}
+		}
+	})
+
+	t.Run("uses manual groups and attaches details to rule matches", func(t *testing.T) {
+		repo := newMockRepo()
+		svc := NewDashboardService(repo, newMockShareRepo())
+		userID := uuid.New()
+		storage := models.FunctionalGroupStorage
+
+		kept := repo.seedSubscriptionWithDetails(userID, "NAS Cloud", 3000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(4), nil)
+		kept.FunctionalGroup = &storage
+		disliked := repo.seedSubscriptionWithDetails(userID, "Old Drive", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+		disliked.FunctionalGroup = &storage
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		assertEqual(t, RecommendationTypeCancel, recs[0].Type)
+		assertEqual(t, "만족도 낮음", recs[0].Reason)
+		assertNotNil(t, recs[0].Consolidation)
+		assertEqual(t, models.FunctionalGroupStorage, recs[0].Consolidation.Group)
+	})
+}
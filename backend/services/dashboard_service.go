@@ -1,10 +1,13 @@
 package services
 
 import (
+	"fmt"
 	"log/slog"
 	"math"
 	"sort"
 
+	"github.com/google/uuid"
+
 	"github.com/subkeep/backend/models"
 	"github.com/subkeep/backend/repositories"
 	"github.com/subkeep/backend/utils"
@@ -31,6 +34,7 @@ type DashboardSummary struct {
 	ActiveCount       int                 `json:"activeCount"`
 	PausedCount       int                 `json:"pausedCount"`
 	CategoryBreakdown []CategoryBreakdown `json:"categoryBreakdown"`
+	Budgets           []BudgetProgress    `json:"budgets"`
 }
 
 // CategoryBreakdown represents spending breakdown per category.
@@ -43,29 +47,89 @@ type CategoryBreakdown struct {
 	Count         int     `json:"count"`
 }
 
+// Recommendation types.
+const (
+	RecommendationTypeCancel      = "cancel"
+	RecommendationTypeConsolidate = "consolidate"
+	RecommendationTypeSwitchPlan  = "switch_plan"
+)
+
 // CancelRecommendation represents a subscription recommended for cancellation.
+// Consolidate recommendations cancel a service that overlaps with another one
+// the user keeps; Consolidation describes the whole overlapping group.
+// Switch-plan recommendations keep the service on a cheaper billing cycle, in
+// which case AnnualSaving is the saving from switching.
 type CancelRecommendation struct {
+	Type              string `json:"type"`
 	SubscriptionID    string `json:"subscriptionId"`
 	ServiceName       string `json:"serviceName"`
 	MonthlyAmount     int    `json:"monthlyAmount"`
 	AnnualSaving      int    `json:"annualSaving"`
 	SatisfactionScore *int   `json:"satisfactionScore"`
 	Reason            string `json:"reason"`
+	// Score is the sum of the weights of the fired rules.
+	Score         float64              `json:"score"`
+	FiredRules    []FiredRule          `json:"firedRules"`
+	Consolidation *ConsolidationDetail `json:"consolidation,omitempty"`
+	PlanSwitch    *PlanSwitchDetail    `json:"planSwitch,omitempty"`
 }
 
 // DashboardService handles dashboard-related business logic.
 type DashboardService struct {
 	subRepo   repositories.SubscriptionRepository
 	shareRepo repositories.SubscriptionShareRepository
+	inbox     *InboxService
+	budgets   repositories.BudgetRepository
+	rules     *RecommendationRuleService
+	catalog   *ServiceCatalog
+	altRepo   repositories.PlanAlternativeRepository
+	cache     *AggregateCache
+	clock     *UserClock
+}
+
+// DashboardDeps holds the optional dependencies of a DashboardService. Any of
+// them may be nil.
+type DashboardDeps struct {
+	Inbox   *InboxService                          // nil: RecordRecommendations does nothing
+	Budgets repositories.BudgetRepository          // nil: the summary reports no budget progress
+	Rules   *RecommendationRuleService             // nil: recommendations use the built-in rules
+	Catalog *ServiceCatalog                        // nil: only manually set functional groups and no catalog plan prices are used
+	AltRepo repositories.PlanAlternativeRepository // nil: only catalog plan prices are used to suggest billing cycle switches
+	Cache   *AggregateCache                        // nil: the summary is computed on every request
+	Clock   *UserClock                             // nil: the system clock and default time zone are used
 }
 
-// NewDashboardService creates a new DashboardService.
+// NewDashboardService creates a new DashboardService without optional dependencies.
 func NewDashboardService(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository) *DashboardService {
-	return &DashboardService{subRepo: subRepo, shareRepo: shareRepo}
+	return NewDashboardServiceWithDeps(subRepo, shareRepo, DashboardDeps{})
+}
+
+// NewDashboardServiceWithDeps creates a new DashboardService with the given optional dependencies.
+func NewDashboardServiceWithDeps(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, deps DashboardDeps) *DashboardService {
+	return &DashboardService{
+		subRepo:   subRepo,
+		shareRepo: shareRepo,
+		inbox:     deps.Inbox,
+		budgets:   deps.Budgets,
+		rules:     deps.Rules,
+		catalog:   deps.Catalog,
+		altRepo:   deps.AltRepo,
+		cache:     deps.Cache,
+		clock:     deps.Clock,
+	}
 }
 
-// GetSummary returns the overall spending summary for a user.
+// GetSummary returns the overall spending summary for a user. Summaries are
+// cached per day, since budget progress and plan switch dates depend on it.
 func (s *DashboardService) GetSummary(userID string) (*DashboardSummary, error) {
+	key := aggregateKey(userID, "summary", s.clock.Today(userID).Format("2006-01-02"))
+	return cachedAggregate(s.cache, key, func() (*DashboardSummary, error) {
+		return s.buildSummary(userID)
+	})
+}
+
+// buildSummary computes the spending summary for a user.
+func (s *DashboardService) buildSummary(userID string) (*DashboardSummary, error) {
 	// Fetch active subscriptions.
 	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
 		Status:  "active",
@@ -167,11 +231,20 @@ func (s *DashboardService) GetSummary(userID string) (*DashboardSummary, error)
 		ActiveCount:       len(activeSubs),
 		PausedCount:       pausedCount,
 		CategoryBreakdown: breakdown,
+		Budgets:           loadBudgetProgress(s.budgets, userID, activeSubs, shareMap),
 	}, nil
 }
 
-// GetRecommendations returns cancel recommendations based on satisfaction and cost.
+// GetRecommendations returns cancel recommendations by evaluating the user's
+// recommendation rules against their active subscriptions, suggests
+// consolidating services that overlap within a functional group, and suggests
+// switching well-liked services to a cheaper longer billing cycle.
 func (s *DashboardService) GetRecommendations(userID string) ([]*CancelRecommendation, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
 	// Fetch all active subscriptions.
 	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
 		Status:  "active",
@@ -190,58 +263,75 @@ func (s *DashboardService) GetRecommendations(userID string) ([]*CancelRecommend
 	// Fetch subscription shares for the user.
 	shareMap := buildShareMap(s.shareRepo, userID)
 
-	// Calculate monthly amounts and find top 20% cost threshold.
-	type subWithCost struct {
-		sub     *models.Subscription
-		monthly int
-	}
-	items := make([]subWithCost, len(activeSubs))
+	candidates := make([]recommendationCandidate, len(activeSubs))
 	for i, sub := range activeSubs {
 		monthly := sub.MonthlyAmount()
 		if share, ok := shareMap[sub.ID.String()]; ok {
 			monthly = share.PersonalAmount(monthly)
 		}
-		items[i] = subWithCost{sub: sub, monthly: monthly}
+		candidates[i] = recommendationCandidate{sub: sub, monthly: monthly}
 	}
 
-	// Sort by cost descending to find top 20% threshold.
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].monthly > items[j].monthly
-	})
-
-	top20Index := int(math.Ceil(float64(len(items)) * 0.2))
-	costThreshold := 0
-	if top20Index > 0 && top20Index <= len(items) {
-		costThreshold = items[top20Index-1].monthly
-	}
+	fired := s.rules.evaluate(uid, candidates)
 
-	// Build recommendations.
+	// Build recommendations; the heaviest fired rule gives the primary reason.
 	recommendations := make([]*CancelRecommendation, 0)
-	for _, item := range items {
-		reason := ""
-
-		// Criteria 1: satisfaction_score 1-2 (any amount).
-		if item.sub.SatisfactionScore != nil && *item.sub.SatisfactionScore <= 2 {
-			reason = "만족도 낮음"
+	bySubID := make(map[string]*CancelRecommendation)
+	for _, item := range candidates {
+		rules := fired[item.sub.ID.String()]
+		if len(rules) == 0 {
+			continue
+		}
+		score := 0.0
+		for _, rule := range rules {
+			score += rule.Weight
 		}
 
-		// Criteria 2: top 20% cost AND satisfaction_score <= 3.
-		if reason == "" && item.monthly >= costThreshold && item.sub.SatisfactionScore != nil && *item.sub.SatisfactionScore <= 3 {
-			reason = "높은 비용 대비 낮은 만족도"
+		rec := newCancelRecommendation(RecommendationTypeCancel, item, rules[0].Reason)
+		rec.Score = score
+		rec.FiredRules = rules
+		recommendations = append(recommendations, rec)
+		bySubID[rec.SubscriptionID] = rec
+	}
+
+	// Suggest cancelling every overlapping service except the one to keep.
+	for _, detail := range findOverlaps(candidates, s.catalog) {
+		keep := detail.suggested()
+		reason := fmt.Sprintf("%s 서비스 중복 (%s 유지 추천)", detail.GroupLabel, keep.KeepServiceName)
+		for _, item := range candidates {
+			if !overlapsWith(detail, item.sub.ID.String()) || item.sub.ID.String() == keep.KeepSubscriptionID {
+				continue
+			}
+			if rec, ok := bySubID[item.sub.ID.String()]; ok {
+				rec.Consolidation = detail
+				continue
+			}
+			rec := newCancelRecommendation(RecommendationTypeConsolidate, item, reason)
+			rec.FiredRules = []FiredRule{}
+			rec.Consolidation = detail
+			recommendations = append(recommendations, rec)
+			bySubID[rec.SubscriptionID] = rec
 		}
+	}
 
-		if reason == "" {
+	// Suggest cheaper billing cycles for services the user keeps.
+	altsBySub := s.loadPlanAlternatives(candidates)
+	today := s.clock.Today(userID)
+	for _, item := range candidates {
+		if _, ok := bySubID[item.sub.ID.String()]; ok {
 			continue
 		}
-
-		recommendations = append(recommendations, &CancelRecommendation{
-			SubscriptionID:    item.sub.ID.String(),
-			ServiceName:       item.sub.ServiceName,
-			MonthlyAmount:     item.monthly,
-			AnnualSaving:      item.monthly * 12,
-			SatisfactionScore: item.sub.SatisfactionScore,
-			Reason:            reason,
-		})
+		prices := alternativePlanPrices(item.sub, altsBySub[item.sub.ID.String()], s.catalog)
+		detail := suggestPlanSwitch(item, prices, today)
+		if detail == nil {
+			continue
+		}
+		reason := fmt.Sprintf("%s 결제로 전환하면 연 %s원 절약", billingCycleLabel(detail.ToCycle), formatWon(detail.AnnualSaving))
+		rec := newCancelRecommendation(RecommendationTypeSwitchPlan, item, reason)
+		rec.AnnualSaving = detail.AnnualSaving
+		rec.FiredRules = []FiredRule{}
+		rec.PlanSwitch = detail
+		recommendations = append(recommendations, rec)
 	}
 
 	// Sort by satisfaction ASC then monthlyAmount DESC.
@@ -262,3 +352,103 @@ func (s *DashboardService) GetRecommendations(userID string) ([]*CancelRecommend
 
 	return recommendations, nil
 }
+
+// newCancelRecommendation creates a recommendation to cancel the candidate.
+func newCancelRecommendation(recType string, item recommendationCandidate, reason string) *CancelRecommendation {
+	return &CancelRecommendation{
+		Type:              recType,
+		SubscriptionID:    item.sub.ID.String(),
+		ServiceName:       item.sub.ServiceName,
+		MonthlyAmount:     item.monthly,
+		AnnualSaving:      item.monthly * 12,
+		SatisfactionScore: item.sub.SatisfactionScore,
+		Reason:            reason,
+	}
+}
+
+// RecordRecommendations evaluates the user's recommendations and writes each
+// one to the inbox. Entries are keyed by the rule that produced them and the
+// subscription, so a recommendation is recorded once even when its amounts
+// or wording change between evaluations.
+func (s *DashboardService) RecordRecommendations(userID string) error {
+	if s.inbox == nil {
+		return nil
+	}
+	recommendations, err := s.GetRecommendations(userID)
+	if err != nil {
+		return err
+	}
+	uid := uuid.MustParse(userID)
+	for _, rec := range recommendations {
+		s.recordRecommendation(uid, rec)
+	}
+	return nil
+}
+
+// recordRecommendation writes the recommendation to the user's inbox once.
+func (s *DashboardService) recordRecommendation(userID uuid.UUID, rec *CancelRecommendation) {
+	subID, err := uuid.Parse(rec.SubscriptionID)
+	if err != nil {
+		return
+	}
+	title := fmt.Sprintf("%s 해지 추천", rec.ServiceName)
+	body := fmt.Sprintf("%s: %s. 해지하면 연 %s원을 절약할 수 있습니다.",
+		rec.ServiceName, rec.Reason, formatWon(rec.AnnualSaving))
+	if rec.PlanSwitch != nil {
+		title = fmt.Sprintf("%s 결제 주기 변경 추천", rec.ServiceName)
+		body = fmt.Sprintf("%s: %s. 전환 시 %s에 %s원이 결제됩니다.",
+			rec.ServiceName, rec.Reason, rec.PlanSwitch.SwitchDate, formatWon(rec.PlanSwitch.UpfrontCost))
+	}
+	s.inbox.Record(userID, InboxEntry{
+		Type:           models.NotificationTypeRecommendation,
+		Title:          title,
+		Body:           body,
+		SubscriptionID: &subID,
+		DedupKey:       fmt.Sprintf("recommendation:%s:%s", recommendationRuleKey(rec), subID),
+	})
+}
+
+// recommendationRuleKey identifies what produced a recommendation: the key or
+// ID of its leading rule for cancel recommendations, or the recommendation
+// type otherwise.
+func recommendationRuleKey(rec *CancelRecommendation) string {
+	if rec.Type != RecommendationTypeCancel || len(rec.FiredRules) == 0 {
+		return rec.Type
+	}
+	if key := rec.FiredRules[0].Key; key != nil {
+		return *key
+	}
+	return rec.FiredRules[0].RuleID
+}
+
+// overlapsWith reports whether the subscription is part of the overlap group.
+func overlapsWith(detail *ConsolidationDetail, subID string) bool {
+	for _, svc := range detail.Services {
+		if svc.SubscriptionID == subID {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPlanAlternatives returns the user-entered alternative plans keyed by
+// subscription ID. Failures are logged and treated as no alternatives.
+func (s *DashboardService) loadPlanAlternatives(candidates []recommendationCandidate) map[string][]*models.PlanAlternative {
+	bySub := make(map[string][]*models.PlanAlternative)
+	if s.altRepo == nil {
+		return bySub
+	}
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.sub.ID.String()
+	}
+	alts, err := s.altRepo.FindBySubscriptionIDs(ids)
+	if err != nil {
+		slog.Error("대체 요금제 조회 실패", "error", err)
+		return bySub
+	}
+	for _, alt := range alts {
+		bySub[alt.SubscriptionID.String()] = append(bySub[alt.SubscriptionID.String()], alt)
+	}
+	return bySub
+}
@@ -45,6 +45,7 @@ func (m *mockShareRepoForDashboard) Delete(id string) error
 func (m *mockShareRepoForDashboard) DeleteBySubscriptionID(subscriptionID string) error {
 	return nil
 }
+func (m *mockShareRepoForDashboard) FindSharingUserIDs() ([]string, error) { return nil, nil }
 
 // ---------------------------------------------------------------------------
 // Helper: seed subscription with category and satisfaction score
@@ -304,6 +305,31 @@ func TestGetRecommendations(t *testing.T) {
 		}
 	})
 
+	t.Run("writes recommendations to inbox once per rule and subscription", func(t *testing.T) {
+		repo := newMockRepo()
+		inboxRepo := newMockNotificationRepo()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Inbox: NewInboxService(inboxRepo, nil)})
+
+		bad := repo.seedSubscriptionWithDetails(userID, "BadService", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+		repo.seedSubscriptionWithDetails(userID, "GoodService", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+
+		// Reading recommendations has no side effects.
+		_, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, len(inboxRepo.byType(userID, models.NotificationTypeRecommendation)), 0)
+
+		assertNil(t, svc.RecordRecommendations(userID.String()))
+		bad.Amount = 7000
+		assertNil(t, svc.RecordRecommendations(userID.String()))
+
+		entries := inboxRepo.byType(userID, models.NotificationTypeRecommendation)
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 inbox entry, got %d", len(entries))
+		}
+		assertEqual(t, entries[0].Body, "BadService: 만족도 낮음. 해지하면 연 60,000원을 절약할 수 있습니다.")
+		assertEqual(t, entries[0].DedupKey, "recommendation:"+models.RecommendationRuleLowSatisfaction+":"+bad.ID.String())
+	})
+
 	t.Run("recommends high-cost low-satisfaction subscriptions (top 20% cost + satisfaction <= 3)", func(t *testing.T) {
 		repo := newMockRepo()
 		shareRepo := newMockShareRepo()
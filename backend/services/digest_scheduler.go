@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// DigestScheduler periodically delivers spending digests according to each
+// user's DigestSchedule.
+//
+// A schedule is due once its day and hour have been reached in the user's
+// time zone. The delivered date is stored as LastPeriodKey, so each period is
+// sent at most once; a failed send is retried on the next run.
+type DigestScheduler struct {
+	scheduleRepo repositories.DigestScheduleRepository
+	userRepo     repositories.UserRepository
+	digests      *DigestService
+	notifiers    map[models.NotificationChannel]Notifier
+	clock        *UserClock
+	cfg          config.DigestConfig
+}
+
+// NewDigestScheduler creates a new DigestScheduler.
+// Channels without an entry in notifiers are skipped with a warning.
+func NewDigestScheduler(
+	scheduleRepo repositories.DigestScheduleRepository,
+	userRepo repositories.UserRepository,
+	digests *DigestService,
+	notifiers map[models.NotificationChannel]Notifier,
+	clock *UserClock,
+	cfg config.DigestConfig,
+) *DigestScheduler {
+	return &DigestScheduler{
+		scheduleRepo: scheduleRepo,
+		userRepo:     userRepo,
+		digests:      digests,
+		notifiers:    notifiers,
+		clock:        clock,
+		cfg:          cfg,
+	}
+}
+
+// Start runs the scheduler every cfg.Interval until ctx is cancelled.
+// The first run happens immediately.
+func (s *DigestScheduler) Start(ctx context.Context) {
+	interval := s.cfg.Interval
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			slog.Error("다이제스트 스케줄러 실행 실패", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce delivers every due digest and returns the number sent.
+func (s *DigestScheduler) RunOnce(ctx context.Context) (int, error) {
+	schedules, err := s.scheduleRepo.FindEnabled()
+	if err != nil {
+		return 0, fmt.Errorf("load digest schedules: %w", err)
+	}
+
+	sent := 0
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		userID := schedule.UserID.String()
+		periodKey, due := digestDue(schedule, s.clock.Now(userID))
+		if !due {
+			continue
+		}
+		if err := s.deliver(ctx, schedule, periodKey); err != nil {
+			slog.Warn("다이제스트 발송 실패", "userID", userID, "frequency", schedule.Frequency, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// deliver builds, renders and sends one digest, then records it on the schedule.
+func (s *DigestScheduler) deliver(ctx context.Context, schedule *models.DigestSchedule, periodKey string) error {
+	userID := schedule.UserID.String()
+
+	notifier, ok := s.notifiers[schedule.Channel]
+	if !ok {
+		return fmt.Errorf("notification channel %q is not configured", schedule.Channel)
+	}
+	target, err := resolveChannelTarget(s.userRepo, userID, schedule.Channel, schedule.Target)
+	if err != nil {
+		return err
+	}
+
+	digest, err := s.digests.BuildDigest(userID, schedule.Frequency, schedule.LastSnapshot)
+	if err != nil {
+		return fmt.Errorf("build digest: %w", err)
+	}
+	subject, text, html, err := s.digests.Render(digest)
+	if err != nil {
+		return err
+	}
+	snapshot, err := encodeDigestSnapshot(digest)
+	if err != nil {
+		return err
+	}
+
+	n := &Notification{
+		UserID:   userID,
+		Event:    models.NotificationEventDigest,
+		Target:   target,
+		Subject:  subject,
+		Body:     text,
+		HTMLBody: html,
+	}
+	if err := notifier.Send(ctx, n); err != nil {
+		return err
+	}
+
+	sentAt := s.clock.now()
+	schedule.LastPeriodKey = periodKey
+	schedule.LastSentAt = &sentAt
+	schedule.LastSnapshot = snapshot
+	if err := s.scheduleRepo.Update(schedule); err != nil {
+		// The digest went out; the worst case is a duplicate next run.
+		slog.Error("다이제스트 일정 갱신 실패", "userID", userID, "error", err)
+	}
+
+	slog.Info("다이제스트 발송", "userID", userID, "frequency", schedule.Frequency, "period", periodKey)
+	return nil
+}
+
+// digestDue reports whether a schedule should be delivered at the given local
+// time, returning the period key to record when it is.
+func digestDue(schedule *models.DigestSchedule, now time.Time) (string, bool) {
+	switch schedule.Frequency {
+	case models.DigestFrequencyMonthly:
+		if now.Day() != schedule.DayOfMonth {
+			return "", false
+		}
+	default:
+		if int(now.Weekday()) != schedule.DayOfWeek {
+			return "", false
+		}
+	}
+	if now.Hour() < schedule.Hour {
+		return "", false
+	}
+
+	key := now.Format("2006-01-02")
+	if key == schedule.LastPeriodKey {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+func newTestDigestScheduler(f *digestFixture, notifiers map[models.NotificationChannel]Notifier) *DigestScheduler {
+	return NewDigestScheduler(f.schedules, f.users, f.service, notifiers, NewUserClock(f.clock, f.users), config.DigestConfig{})
+}
+
+func TestDigestScheduler_SendsOncePerPeriod(t *testing.T) {
+	f := newDigestFixture(t)
+	f.seed("Netflix", 17000, models.BillingCycleMonthly, time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC))
+	schedule := defaultDigestSchedule(f.userID) // Monday 09:00 email
+	assertNil(t, f.schedules.Create(schedule))
+
+	logs := NewLogNotifier()
+	scheduler := newTestDigestScheduler(f, map[models.NotificationChannel]Notifier{
+		models.NotificationChannelEmail: logs,
+	})
+
+	sent, err := scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, 1, sent)
+
+	msgs := logs.Sent()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(msgs))
+	}
+	assertEqual(t, "digest@example.com", msgs[0].Target)
+	assertEqual(t, models.NotificationEventDigest, msgs[0].Event)
+	assertEqual(t, "[SubKeep] 주간 구독 지출 리포트", msgs[0].Subject)
+	if msgs[0].Body == "" || msgs[0].HTMLBody == "" {
+		t.Fatal("expected both text and HTML bodies")
+	}
+
+	stored := f.schedules.schedules[f.userID.String()]
+	assertEqual(t, "2026-03-16", stored.LastPeriodKey)
+	assertNotNil(t, stored.LastSentAt)
+	if stored.LastSnapshot == "" {
+		t.Fatal("expected the snapshot to be stored")
+	}
+
+	// Later the same day nothing is sent again.
+	f.clock.advance(3 * time.Hour)
+	sent, err = scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, 0, sent)
+	assertEqual(t, 1, len(logs.Sent()))
+}
+
+func TestDigestScheduler_RetriesAfterFailure(t *testing.T) {
+	f := newDigestFixture(t)
+	assertNil(t, f.schedules.Create(defaultDigestSchedule(f.userID)))
+
+	notifier := &failingNotifier{failures: 1}
+	scheduler := newTestDigestScheduler(f, map[models.NotificationChannel]Notifier{
+		models.NotificationChannelEmail: notifier,
+	})
+
+	sent, err := scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, 0, sent)
+	assertEqual(t, "", f.schedules.schedules[f.userID.String()].LastPeriodKey)
+
+	sent, err = scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, 1, sent)
+	assertEqual(t, 2, notifier.calls)
+}
+
+func TestDigestScheduler_UnconfiguredChannel(t *testing.T) {
+	f := newDigestFixture(t)
+	assertNil(t, f.schedules.Create(defaultDigestSchedule(f.userID)))
+
+	scheduler := newTestDigestScheduler(f, map[models.NotificationChannel]Notifier{})
+	sent, err := scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, 0, sent)
+}
+
+func TestDigestDue(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	monday9 := time.Date(2026, 3, 16, 9, 0, 0, 0, seoul)
+
+	weekly := &models.DigestSchedule{Frequency: models.DigestFrequencyWeekly, DayOfWeek: 1, Hour: 9}
+	monthly := &models.DigestSchedule{Frequency: models.DigestFrequencyMonthly, DayOfMonth: 16, Hour: 9}
+	sentToday := &models.DigestSchedule{Frequency: models.DigestFrequencyWeekly, DayOfWeek: 1, Hour: 9, LastPeriodKey: "2026-03-16"}
+
+	tests := []struct {
+		name     string
+		schedule *models.DigestSchedule
+		now      time.Time
+		wantKey  string
+		wantDue  bool
+	}{
+		{"weekly at the scheduled hour", weekly, monday9, "2026-03-16", true},
+		{"weekly later the same day", weekly, monday9.Add(5 * time.Hour), "2026-03-16", true},
+		{"weekly before the hour", weekly, monday9.Add(-time.Minute), "", false},
+		{"weekly on another weekday", weekly, monday9.AddDate(0, 0, 1), "", false},
+		{"monthly on the scheduled day", monthly, monday9, "2026-03-16", true},
+		{"monthly on another day", monthly, monday9.AddDate(0, 0, 7), "", false},
+		{"already sent this period", sentToday, monday9, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, due := digestDue(tt.schedule, tt.now)
+			assertEqual(t, tt.wantDue, due)
+			assertEqual(t, tt.wantKey, key)
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"sort"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// digestUpcomingDays is the look-ahead of the "coming week" section:
+// today plus the following six days.
+const digestUpcomingDays = 6
+
+//go:embed templates/digest.html.tmpl
+var digestHTMLSource string
+
+//go:embed templates/digest.txt.tmpl
+var digestTextSource string
+
+var digestTemplateFuncs = map[string]any{
+	"won":       formatWon,
+	"signedWon": signedWon,
+}
+
+var (
+	digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(digestTemplateFuncs).Parse(digestHTMLSource))
+	digestTextTemplate = texttemplate.Must(texttemplate.New("digest.txt").Funcs(digestTemplateFuncs).Parse(digestTextSource))
+)
+
+// DigestChangeType identifies what changed about a subscription between digests.
+type DigestChangeType string
+
+const (
+	DigestChangeAdded         DigestChangeType = "added"
+	DigestChangeRemoved       DigestChangeType = "removed"
+	DigestChangePriceChanged  DigestChangeType = "price_changed"
+	DigestChangeStatusChanged DigestChangeType = "status_changed"
+)
+
+// Digest is a periodic spending summary for a single user.
+// The period covers the days before the digest date; UpcomingPayments covers
+// the coming week starting today.
+type Digest struct {
+	UserID           string                  `json:"userId"`
+	Frequency        models.DigestFrequency  `json:"frequency"`
+	PeriodStart      string                  `json:"periodStart"`
+	PeriodEnd        string                  `json:"periodEnd"`
+	GeneratedAt      time.Time               `json:"generatedAt"`
+	Summary          DigestSummary           `json:"summary"`
+	Charges          []DigestCharge          `json:"charges"`
+	UpcomingPayments []UpcomingPayment       `json:"upcomingPayments"`
+	Changes          []DigestChange          `json:"changes"`
+	FirstDigest      bool                    `json:"firstDigest"`
+	Recommendations  []*CancelRecommendation `json:"recommendations"`
+
+	// snapshot is the subscription state this digest was built from. It is
+	// stored on the schedule after delivery as the next digest's baseline.
+	snapshot map[string]digestSnapshotEntry
+}
+
+// DigestSummary holds the headline figures of a digest.
+// MonthOverMonth is this month's trend amount minus last month's.
+type DigestSummary struct {
+	PeriodTotal    int `json:"periodTotal"`
+	PeriodCount    int `json:"periodCount"`
+	UpcomingTotal  int `json:"upcomingTotal"`
+	MonthlyTotal   int `json:"monthlyTotal"`
+	ActiveCount    int `json:"activeCount"`
+	PausedCount    int `json:"pausedCount"`
+	MonthOverMonth int `json:"monthOverMonth"`
+}
+
+// DigestCharge is a single billing event within the digest period.
+type DigestCharge struct {
+	Date           string `json:"date"`
+	AdjustedDate   string `json:"adjustedDate"`
+	SubscriptionID string `json:"subscriptionId"`
+	ServiceName    string `json:"serviceName"`
+	Amount         int    `json:"amount"`
+	PersonalAmount int    `json:"personalAmount"`
+}
+
+// DigestChange describes a subscription change since the previous digest.
+type DigestChange struct {
+	Type           DigestChangeType `json:"type"`
+	SubscriptionID string           `json:"subscriptionId"`
+	ServiceName    string           `json:"serviceName"`
+	Description    string           `json:"description"`
+}
+
+// digestSnapshotEntry is the per-subscription state compared between digests.
+type digestSnapshotEntry struct {
+	Name   string                    `json:"name"`
+	Amount int                       `json:"amount"`
+	Cycle  models.BillingCycle       `json:"cycle"`
+	Status models.SubscriptionStatus `json:"status"`
+}
+
+// UpdateDigestScheduleRequest holds the body for creating or updating a
+// digest schedule. Omitted fields keep their current (or default) value.
+type UpdateDigestScheduleRequest struct {
+	Frequency  *string `json:"frequency" validate:"omitempty,oneof=weekly monthly"`
+	DayOfWeek  *int    `json:"dayOfWeek" validate:"omitempty,gte=0,lte=6"`
+	DayOfMonth *int    `json:"dayOfMonth" validate:"omitempty,gte=1,lte=28"`
+	Hour       *int    `json:"hour" validate:"omitempty,gte=0,lte=23"`
+	Channel    *string `json:"channel" validate:"omitempty,oneof=email webhook log push"`
+	Target     *string `json:"target" validate:"omitempty,max=500"`
+	Enabled    *bool   `json:"enabled"`
+}
+
+// DigestService builds, renders and schedules spending digests. The digest
+// content is assembled from the dashboard, calendar and report services.
+type DigestService struct {
+	scheduleRepo repositories.DigestScheduleRepository
+	subRepo      repositories.SubscriptionRepository
+	dashboard    *DashboardService
+	calendar     *CalendarService
+	report       *ReportService
+	clock        *UserClock
+}
+
+// NewDigestService creates a new DigestService.
+// clock may be nil, in which case the system clock and default time zone are used.
+func NewDigestService(
+	scheduleRepo repositories.DigestScheduleRepository,
+	subRepo repositories.SubscriptionRepository,
+	dashboard *DashboardService,
+	calendar *CalendarService,
+	report *ReportService,
+	clock *UserClock,
+) *DigestService {
+	return &DigestService{
+		scheduleRepo: scheduleRepo,
+		subRepo:      subRepo,
+		dashboard:    dashboard,
+		calendar:     calendar,
+		report:       report,
+		clock:        clock,
+	}
+}
+
+// Preview builds the digest the user would receive today. Changes are
+// computed against the last delivered digest, if any.
+func (s *DigestService) Preview(userID string, frequency models.DigestFrequency) (*Digest, error) {
+	if frequency != models.DigestFrequencyWeekly && frequency != models.DigestFrequencyMonthly {
+		return nil, utils.ErrBadRequest("frequency는 weekly 또는 monthly여야 합니다")
+	}
+
+	baseline := ""
+	schedule, err := s.scheduleRepo.FindByUserID(userID)
+	switch {
+	case err == nil:
+		baseline = schedule.LastSnapshot
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		slog.Error("다이제스트 일정 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("다이제스트 일정을 조회할 수 없습니다")
+	}
+
+	return s.BuildDigest(userID, frequency, baseline)
+}
+
+// BuildDigest assembles a digest for the period ending yesterday. baseline is
+// the snapshot stored with the previous digest; when empty the digest is
+// marked as the first one and carries no change list.
+func (s *DigestService) BuildDigest(userID string, frequency models.DigestFrequency, baseline string) (*Digest, error) {
+	today := s.clock.Today(userID)
+	periodEnd := today.AddDate(0, 0, -1)
+	periodStart := today.AddDate(0, 0, -7)
+	if frequency == models.DigestFrequencyMonthly {
+		periodStart = today.AddDate(0, -1, 0)
+	}
+
+	rangeCal, err := s.calendar.GetRangeCalendar(userID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	upcoming, err := s.calendar.GetUpcomingPayments(userID, digestUpcomingDays)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.dashboard.GetSummary(userID)
+	if err != nil {
+		return nil, err
+	}
+	recommendations, err := s.dashboard.GetRecommendations(userID)
+	if err != nil {
+		return nil, err
+	}
+	overview, err := s.report.GetOverview(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	allSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("다이제스트 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("다이제스트 데이터를 조회할 수 없습니다")
+	}
+	snapshot := make(map[string]digestSnapshotEntry, len(allSubs))
+	for _, sub := range allSubs {
+		snapshot[sub.ID.String()] = digestSnapshotEntry{
+			Name:   sub.ServiceName,
+			Amount: sub.Amount,
+			Cycle:  sub.BillingCycle,
+			Status: sub.Status,
+		}
+	}
+
+	charges := make([]DigestCharge, 0, rangeCal.TotalCount)
+	for _, day := range rangeCal.Days {
+		for _, cs := range day.Subscriptions {
+			charges = append(charges, DigestCharge{
+				Date:           cs.NominalDate,
+				AdjustedDate:   cs.AdjustedDate,
+				SubscriptionID: cs.SubscriptionID,
+				ServiceName:    cs.ServiceName,
+				Amount:         cs.Amount,
+				PersonalAmount: cs.PersonalAmount,
+			})
+		}
+	}
+
+	upcomingTotal := 0
+	for _, p := range upcoming {
+		upcomingTotal += p.PersonalAmount
+	}
+
+	monthOverMonth := 0
+	if n := len(overview.MonthlyTrend); n >= 2 {
+		monthOverMonth = overview.MonthlyTrend[n-1].Amount - overview.MonthlyTrend[n-2].Amount
+	}
+
+	digest := &Digest{
+		UserID:      userID,
+		Frequency:   frequency,
+		PeriodStart: periodStart.Format("2006-01-02"),
+		PeriodEnd:   periodEnd.Format("2006-01-02"),
+		GeneratedAt: s.clock.Now(userID),
+		Summary: DigestSummary{
+			PeriodTotal:    rangeCal.TotalAmount,
+			PeriodCount:    rangeCal.TotalCount,
+			UpcomingTotal:  upcomingTotal,
+			MonthlyTotal:   summary.MonthlyTotal,
+			ActiveCount:    summary.ActiveCount,
+			PausedCount:    summary.PausedCount,
+			MonthOverMonth: monthOverMonth,
+		},
+		Charges:          charges,
+		UpcomingPayments: upcoming,
+		Changes:          []DigestChange{},
+		Recommendations:  recommendations,
+		snapshot:         snapshot,
+	}
+
+	if baseline == "" {
+		digest.FirstDigest = true
+		return digest, nil
+	}
+
+	var previous map[string]digestSnapshotEntry
+	if err := json.Unmarshal([]byte(baseline), &previous); err != nil {
+		// A corrupt baseline only costs us the change list.
+		slog.Warn("다이제스트 스냅샷 파싱 실패", "userID", userID, "error", err)
+		digest.FirstDigest = true
+		return digest, nil
+	}
+	digest.Changes = diffDigestSnapshots(previous, snapshot)
+	return digest, nil
+}
+
+// Render returns the subject, plain-text body and HTML body of a digest.
+func (s *DigestService) Render(d *Digest) (string, string, string, error) {
+	title := "[SubKeep] 주간 구독 지출 리포트"
+	if d.Frequency == models.DigestFrequencyMonthly {
+		title = "[SubKeep] 월간 구독 지출 리포트"
+	}
+	data := struct {
+		Title  string
+		Digest *Digest
+	}{Title: title, Digest: d}
+
+	var text bytes.Buffer
+	if err := digestTextTemplate.Execute(&text, data); err != nil {
+		return "", "", "", fmt.Errorf("render digest text: %w", err)
+	}
+	var html bytes.Buffer
+	if err := digestHTMLTemplate.Execute(&html, data); err != nil {
+		return "", "", "", fmt.Errorf("render digest html: %w", err)
+	}
+	return title, text.String(), html.String(), nil
+}
+
+// GetSchedule returns the user's digest schedule. Users without a stored
+// schedule get the defaults with delivery disabled.
+func (s *DigestService) GetSchedule(userID string) (*models.DigestSchedule, error) {
+	schedule, err := s.scheduleRepo.FindByUserID(userID)
+	if err == nil {
+		return schedule, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("다이제스트 일정 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("다이제스트 일정을 조회할 수 없습니다")
+	}
+
+	uid, parseErr := uuid.Parse(userID)
+	if parseErr != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+	schedule = defaultDigestSchedule(uid)
+	schedule.Enabled = false
+	return schedule, nil
+}
+
+// UpdateSchedule creates or updates the user's digest schedule.
+func (s *DigestService) UpdateSchedule(userID string, req *UpdateDigestScheduleRequest) (*models.DigestSchedule, error) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.scheduleRepo.FindByUserID(userID)
+	isNew := false
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		uid, parseErr := uuid.Parse(userID)
+		if parseErr != nil {
+			return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+		}
+		schedule = defaultDigestSchedule(uid)
+		isNew = true
+	default:
+		slog.Error("다이제스트 일정 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("다이제스트 일정을 조회할 수 없습니다")
+	}
+
+	if req.Frequency != nil {
+		schedule.Frequency = models.DigestFrequency(*req.Frequency)
+	}
+	if req.DayOfWeek != nil {
+		schedule.DayOfWeek = *req.DayOfWeek
+	}
+	if req.DayOfMonth != nil {
+		schedule.DayOfMonth = *req.DayOfMonth
+	}
+	if req.Hour != nil {
+		schedule.Hour = *req.Hour
+	}
+	if req.Channel != nil {
+		schedule.Channel = models.NotificationChannel(*req.Channel)
+	}
+	if req.Target != nil {
+		if *req.Target == "" {
+			schedule.Target = nil
+		} else {
+			schedule.Target = req.Target
+		}
+	}
+	if req.Enabled != nil {
+		schedule.Enabled = *req.Enabled
+	}
+
+	if appErr := validateChannelTarget(schedule.Channel, schedule.Target); appErr != nil {
+		return nil, appErr
+	}
+
+	if isNew {
+		err = s.scheduleRepo.Create(schedule)
+	} else {
+		err = s.scheduleRepo.Update(schedule)
+	}
+	if err != nil {
+		slog.Error("다이제스트 일정 저장 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("다이제스트 일정을 저장할 수 없습니다")
+	}
+
+	slog.Info("다이제스트 일정 저장", "userID", userID, "frequency", schedule.Frequency, "enabled", schedule.Enabled)
+	return schedule, nil
+}
+
+// defaultDigestSchedule returns a weekly Monday 09:00 email schedule.
+func defaultDigestSchedule(userID uuid.UUID) *models.DigestSchedule {
+	return &models.DigestSchedule{
+		UserID:     userID,
+		Frequency:  models.DigestFrequencyWeekly,
+		DayOfWeek:  int(time.Monday),
+		DayOfMonth: 1,
+		Hour:       9,
+		Channel:    models.NotificationChannelEmail,
+		Enabled:    true,
+	}
+}
+
+// diffDigestSnapshots lists the changes from previous to current, ordered by
+// service name.
+func diffDigestSnapshots(previous, current map[string]digestSnapshotEntry) []DigestChange {
+	changes := make([]DigestChange, 0)
+
+	for id, cur := range current {
+		prev, ok := previous[id]
+		if !ok {
+			changes = append(changes, DigestChange{
+				Type:           DigestChangeAdded,
+				SubscriptionID: id,
+				ServiceName:    cur.Name,
+				Description: fmt.Sprintf("%s 구독이 추가되었습니다 (%s원, %s)",
+					cur.Name, formatWon(cur.Amount), billingCycleLabel(cur.Cycle)),
+			})
+			continue
+		}
+		if prev.Amount != cur.Amount || prev.Cycle != cur.Cycle {
+			changes = append(changes, DigestChange{
+				Type:           DigestChangePriceChanged,
+				SubscriptionID: id,
+				ServiceName:    cur.Name,
+				Description: fmt.Sprintf("%s 가격이 %s원(%s)에서 %s원(%s)으로 변경되었습니다",
+					cur.Name,
+					formatWon(prev.Amount), billingCycleLabel(prev.Cycle),
+					formatWon(cur.Amount), billingCycleLabel(cur.Cycle)),
+			})
+		}
+		if prev.Status != cur.Status {
+			changes = append(changes, DigestChange{
+				Type:           DigestChangeStatusChanged,
+				SubscriptionID: id,
+				ServiceName:    cur.Name,
+				Description: fmt.Sprintf("%s 상태가 %s에서 %s(으)로 변경되었습니다",
+					cur.Name, subscriptionStatusLabel(prev.Status), subscriptionStatusLabel(cur.Status)),
+			})
+		}
+	}
+
+	for id, prev := range previous {
+		if _, ok := current[id]; ok {
+			continue
+		}
+		changes = append(changes, DigestChange{
+			Type:           DigestChangeRemoved,
+			SubscriptionID: id,
+			ServiceName:    prev.Name,
+			Description:    fmt.Sprintf("%s 구독이 삭제되었습니다", prev.Name),
+		})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].ServiceName != changes[j].ServiceName {
+			return changes[i].ServiceName < changes[j].ServiceName
+		}
+		return changes[i].Type < changes[j].Type
+	})
+	return changes
+}
+
+// encodeDigestSnapshot serialises a digest's snapshot for storage.
+func encodeDigestSnapshot(d *Digest) (string, error) {
+	raw, err := json.Marshal(d.snapshot)
+	if err != nil {
+		return "", fmt.Errorf("encode digest snapshot: %w", err)
+	}
+	return string(raw), nil
+}
+
+// subscriptionStatusLabel returns the Korean label of a subscription status.
+func subscriptionStatusLabel(status models.SubscriptionStatus) string {
+	switch status {
+	case models.SubscriptionStatusPaused:
+		return "일시정지"
+	case models.SubscriptionStatusCancelled:
+		return "해지"
+	default:
+		return "활성"
+	}
+}
+
+// signedWon formats an amount with an explicit sign, e.g. 3000 → "+3,000".
+func signedWon(amount int) string {
+	if amount > 0 {
+		return "+" + formatWon(amount)
+	}
+	return formatWon(amount)
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock DigestScheduleRepository
+// ---------------------------------------------------------------------------
+
+type mockDigestScheduleRepo struct {
+	schedules map[string]*models.DigestSchedule // keyed by user ID
+}
+
+func newMockDigestScheduleRepo() *mockDigestScheduleRepo {
+	return &mockDigestScheduleRepo{schedules: make(map[string]*models.DigestSchedule)}
+}
+
+func (m *mockDigestScheduleRepo) FindByUserID(userID string) (*models.DigestSchedule, error) {
+	schedule, ok := m.schedules[userID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *schedule
+	return &copied, nil
+}
+
+func (m *mockDigestScheduleRepo) FindEnabled() ([]*models.DigestSchedule, error) {
+	var result []*models.DigestSchedule
+	for _, schedule := range m.schedules {
+		if schedule.Enabled {
+			copied := *schedule
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockDigestScheduleRepo) Create(schedule *models.DigestSchedule) error {
+	if schedule.ID == uuid.Nil {
+		schedule.ID = uuid.New()
+	}
+	copied := *schedule
+	m.schedules[schedule.UserID.String()] = &copied
+	return nil
+}
+
+func (m *mockDigestScheduleRepo) Update(schedule *models.DigestSchedule) error {
+	copied := *schedule
+	m.schedules[schedule.UserID.String()] = &copied
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Fixture
+// ---------------------------------------------------------------------------
+
+type digestFixture struct {
+	service   *DigestService
+	schedules *mockDigestScheduleRepo
+	subs      *mockSubRepoForCalendar
+	users     *mockUserRepo
+	clock     *stepClock
+	userID    uuid.UUID
+}
+
+// newDigestFixture builds a DigestService whose clock starts on
+// Monday 2026-03-16 10:00 in Seoul.
+func newDigestFixture(t *testing.T) *digestFixture {
+	t.Helper()
+	holidays, err := LoadHolidayCalendar("")
+	if err != nil {
+		t.Fatalf("load holidays: %v", err)
+	}
+
+	f := &digestFixture{
+		schedules: newMockDigestScheduleRepo(),
+		subs:      newMockSubRepoForCalendar(),
+		users:     newMockUserRepo(),
+		clock:     &stepClock{t: time.Date(2026, 3, 16, 1, 0, 0, 0, time.UTC)},
+		userID:    uuid.New(),
+	}
+	email := "digest@example.com"
+	f.users.users[f.userID.String()] = &models.User{ID: f.userID, Email: &email}
+
+	clock := NewUserClock(f.clock, f.users)
+	shares := newMockShareRepoForCalendar()
+	f.service = NewDigestService(
+		f.schedules, f.subs,
+		NewDashboardService(f.subs, shares),
+		NewCalendarServiceWithDeps(f.subs, shares, CalendarDeps{Holidays: holidays, Clock: clock}),
+		NewReportServiceWithDeps(f.subs, shares, ReportDeps{Clock: clock}),
+		clock,
+	)
+	return f
+}
+
+func (f *digestFixture) seed(name string, amount int, cycle models.BillingCycle, nextBilling time.Time) *models.Subscription {
+	sub := seedCalendarSub(f.subs, f.userID, name, amount, cycle, nextBilling, nil)
+	sub.StartDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return sub
+}
+
+// ===========================================================================
+// BuildDigest
+// ===========================================================================
+
+func TestBuildDigest_Weekly(t *testing.T) {
+	f := newDigestFixture(t)
+	// Billed on 2026-03-12, inside the 03-09..03-15 period.
+	f.seed("Netflix", 17000, models.BillingCycleMonthly, time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC))
+	// Due on 2026-03-18, inside the coming week.
+	f.seed("YouTube Premium", 14900, models.BillingCycleMonthly, time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC))
+	// Due on 2026-03-30, outside both windows.
+	f.seed("Melon", 10900, models.BillingCycleMonthly, time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC))
+
+	digest, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyWeekly, "")
+	assertNil(t, err)
+
+	assertEqual(t, "2026-03-09", digest.PeriodStart)
+	assertEqual(t, "2026-03-15", digest.PeriodEnd)
+	assertEqual(t, true, digest.FirstDigest)
+	assertEqual(t, 0, len(digest.Changes))
+
+	if len(digest.Charges) != 1 {
+		t.Fatalf("expected 1 charge, got %d", len(digest.Charges))
+	}
+	assertEqual(t, "Netflix", digest.Charges[0].ServiceName)
+	assertEqual(t, "2026-03-12", digest.Charges[0].Date)
+	assertEqual(t, 17000, digest.Summary.PeriodTotal)
+	assertEqual(t, 1, digest.Summary.PeriodCount)
+
+	if len(digest.UpcomingPayments) != 1 {
+		t.Fatalf("expected 1 upcoming payment, got %d", len(digest.UpcomingPayments))
+	}
+	assertEqual(t, "YouTube Premium", digest.UpcomingPayments[0].ServiceName)
+	assertEqual(t, 14900, digest.Summary.UpcomingTotal)
+
+	assertEqual(t, 17000+14900+10900, digest.Summary.MonthlyTotal)
+	assertEqual(t, 3, digest.Summary.ActiveCount)
+}
+
+func TestBuildDigest_MonthlyPeriod(t *testing.T) {
+	f := newDigestFixture(t)
+	f.seed("Netflix", 17000, models.BillingCycleMonthly, time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC))
+	f.seed("Spotify", 3000, models.BillingCycleWeekly, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	digest, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyMonthly, "")
+	assertNil(t, err)
+
+	assertEqual(t, "2026-02-16", digest.PeriodStart)
+	assertEqual(t, "2026-03-15", digest.PeriodEnd)
+	// Netflix on 03-12 plus weekly Spotify on 02-20, 02-27, 03-06 and 03-13.
+	assertEqual(t, 5, digest.Summary.PeriodCount)
+	assertEqual(t, 17000+4*3000, digest.Summary.PeriodTotal)
+}
+
+func TestBuildDigest_ChangesSincePreviousDigest(t *testing.T) {
+	f := newDigestFixture(t)
+	next := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	netflix := f.seed("Netflix", 13500, models.BillingCycleMonthly, next)
+	spotify := f.seed("Spotify", 10900, models.BillingCycleMonthly, next)
+	watcha := f.seed("Watcha", 7900, models.BillingCycleMonthly, next)
+	f.seed("Tving", 9500, models.BillingCycleMonthly, next)
+
+	first, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyWeekly, "")
+	assertNil(t, err)
+	baseline, err := encodeDigestSnapshot(first)
+	assertNil(t, err)
+
+	netflix.Amount = 17000
+	spotify.Status = models.SubscriptionStatusPaused
+	delete(f.subs.subs, watcha.ID.String())
+	f.seed("Disney+", 9900, models.BillingCycleMonthly, next)
+
+	second, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyWeekly, baseline)
+	assertNil(t, err)
+	assertEqual(t, false, second.FirstDigest)
+
+	got := make([]DigestChangeType, len(second.Changes))
+	names := make([]string, len(second.Changes))
+	for i, c := range second.Changes {
+		got[i] = c.Type
+		names[i] = c.ServiceName
+	}
+	assertEqual(t, []string{"Disney+", "Netflix", "Spotify", "Watcha"}, names)
+	assertEqual(t, []DigestChangeType{
+		DigestChangeAdded, DigestChangePriceChanged, DigestChangeStatusChanged, DigestChangeRemoved,
+	}, got)
+	assertEqual(t, "Netflix 가격이 13,500원(월간)에서 17,000원(월간)으로 변경되었습니다", second.Changes[1].Description)
+	assertEqual(t, "Spotify 상태가 활성에서 일시정지(으)로 변경되었습니다", second.Changes[2].Description)
+}
+
+func TestBuildDigest_CorruptBaselineIsTreatedAsFirst(t *testing.T) {
+	f := newDigestFixture(t)
+	f.seed("Netflix", 17000, models.BillingCycleMonthly, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	digest, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyWeekly, "{not json")
+	assertNil(t, err)
+	assertEqual(t, true, digest.FirstDigest)
+	assertEqual(t, 0, len(digest.Changes))
+}
+
+// ===========================================================================
+// Preview / Render
+// ===========================================================================
+
+func TestDigestPreview_UsesStoredBaseline(t *testing.T) {
+	f := newDigestFixture(t)
+	sub := f.seed("Netflix", 13500, models.BillingCycleMonthly, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	first, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyWeekly, "")
+	assertNil(t, err)
+	baseline, err := encodeDigestSnapshot(first)
+	assertNil(t, err)
+	schedule := defaultDigestSchedule(f.userID)
+	schedule.LastSnapshot = baseline
+	assertNil(t, f.schedules.Create(schedule))
+
+	sub.Amount = 17000
+	digest, err := f.service.Preview(f.userID.String(), models.DigestFrequencyWeekly)
+	assertNil(t, err)
+	if len(digest.Changes) != 1 || digest.Changes[0].Type != DigestChangePriceChanged {
+		t.Fatalf("expected a single price change, got %+v", digest.Changes)
+	}
+}
+
+func TestDigestPreview_InvalidFrequency(t *testing.T) {
+	f := newDigestFixture(t)
+	_, err := f.service.Preview(f.userID.String(), models.DigestFrequency("daily"))
+	assertAppErrorCode(t, err, http.StatusBadRequest)
+}
+
+func TestDigestRender(t *testing.T) {
+	f := newDigestFixture(t)
+	f.seed("<b>Netflix</b>", 17000, models.BillingCycleMonthly, time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC))
+	f.seed("YouTube Premium", 14900, models.BillingCycleMonthly, time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC))
+
+	digest, err := f.service.BuildDigest(f.userID.String(), models.DigestFrequencyWeekly, "")
+	assertNil(t, err)
+
+	subject, text, html, err := f.service.Render(digest)
+	assertNil(t, err)
+	assertEqual(t, "[SubKeep] 주간 구독 지출 리포트", subject)
+
+	for _, want := range []string{
+		"기간: 2026-03-09 ~ 2026-03-15",
+		"- 2026-03-12 <b>Netflix</b> 17,000원",
+		"- 2026-03-18 YouTube Premium 14,900원",
+		"첫 다이제스트입니다",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text body missing %q:\n%s", want, text)
+		}
+	}
+
+	if strings.Contains(html, "<b>Netflix</b>") {
+		t.Error("expected service names to be HTML-escaped")
+	}
+	if !strings.Contains(html, "&lt;b&gt;Netflix&lt;/b&gt;") || !strings.Contains(html, "14,900원") {
+		t.Errorf("unexpected html body:\n%s", html)
+	}
+
+	digest.Frequency = models.DigestFrequencyMonthly
+	subject, _, _, err = f.service.Render(digest)
+	assertNil(t, err)
+	assertEqual(t, "[SubKeep] 월간 구독 지출 리포트", subject)
+}
+
+// ===========================================================================
+// Schedule
+// ===========================================================================
+
+func TestGetDigestSchedule_DefaultsWhenMissing(t *testing.T) {
+	f := newDigestFixture(t)
+
+	schedule, err := f.service.GetSchedule(f.userID.String())
+	assertNil(t, err)
+	assertEqual(t, false, schedule.Enabled)
+	assertEqual(t, models.DigestFrequencyWeekly, schedule.Frequency)
+	assertEqual(t, 1, schedule.DayOfWeek)
+	assertEqual(t, 9, schedule.Hour)
+	assertEqual(t, 0, len(f.schedules.schedules))
+}
+
+func TestUpdateDigestSchedule(t *testing.T) {
+	t.Run("creates then updates", func(t *testing.T) {
+		f := newDigestFixture(t)
+
+		created, err := f.service.UpdateSchedule(f.userID.String(), &UpdateDigestScheduleRequest{
+			Frequency:  strPtr("monthly"),
+			DayOfMonth: intPtr(5),
+		})
+		assertNil(t, err)
+		assertEqual(t, models.DigestFrequencyMonthly, created.Frequency)
+		assertEqual(t, 5, created.DayOfMonth)
+		assertEqual(t, true, created.Enabled)
+		assertEqual(t, 1, len(f.schedules.schedules))
+
+		updated, err := f.service.UpdateSchedule(f.userID.String(), &UpdateDigestScheduleRequest{
+			Hour:    intPtr(20),
+			Enabled: boolPtr(false),
+		})
+		assertNil(t, err)
+		assertEqual(t, created.ID, updated.ID)
+		assertEqual(t, models.DigestFrequencyMonthly, updated.Frequency)
+		assertEqual(t, 20, updated.Hour)
+		assertEqual(t, false, updated.Enabled)
+	})
+
+	t.Run("rejects out of range values", func(t *testing.T) {
+		f := newDigestFixture(t)
+		_, err := f.service.UpdateSchedule(f.userID.String(), &UpdateDigestScheduleRequest{DayOfMonth: intPtr(31)})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("webhook channel requires a url", func(t *testing.T) {
+		f := newDigestFixture(t)
+		_, err := f.service.UpdateSchedule(f.userID.String(), &UpdateDigestScheduleRequest{Channel: strPtr("webhook")})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+		assertEqual(t, 0, len(f.schedules.schedules))
+	})
+}
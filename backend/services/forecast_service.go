@@ -0,0 +1,226 @@
+package services
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// defaultForecastMonths is the projection length used when the request omits it.
+const defaultForecastMonths = 3
+
+// CashFlowForecastRequest holds the body for a cash-flow forecast.
+// Balance is the account balance at the start of today.
+type CashFlowForecastRequest struct {
+	Balance   int               `json:"balance"`
+	Months    int               `json:"months" validate:"omitempty,min=1,max=12"`
+	Threshold int               `json:"threshold" validate:"gte=0"`
+	Incomes   []RecurringIncome `json:"incomes" validate:"omitempty,max=10,dive"`
+}
+
+// RecurringIncome describes a monthly income such as a salary.
+// Paydays falling on a weekend or holiday default to the previous business day.
+type RecurringIncome struct {
+	Name                  string `json:"name" validate:"omitempty,max=50"`
+	Amount                int    `json:"amount" validate:"required,gt=0"`
+	DayOfMonth            int    `json:"dayOfMonth" validate:"required,min=1,max=31"`
+	BusinessDayAdjustment string `json:"businessDayAdjustment" validate:"omitempty,oneof=none next_business_day previous_business_day"`
+}
+
+// CashFlowForecast is the projected daily account balance over a period.
+type CashFlowForecast struct {
+	StartDate         string        `json:"startDate"`
+	EndDate           string        `json:"endDate"`
+	OpeningBalance    int           `json:"openingBalance"`
+	ClosingBalance    int           `json:"closingBalance"`
+	LowestBalance     int           `json:"lowestBalance"`
+	LowestBalanceDate string        `json:"lowestBalanceDate"`
+	Threshold         int           `json:"threshold"`
+	TotalIncome       int           `json:"totalIncome"`
+	TotalExpense      int           `json:"totalExpense"`
+	LowBalanceDays    int           `json:"lowBalanceDays"`
+	Days              []CashFlowDay `json:"days"`
+}
+
+// CashFlowDay is the projected end-of-day balance for a single date.
+type CashFlowDay struct {
+	Date           string                `json:"date"`
+	Income         int                   `json:"income"`
+	Expense        int                   `json:"expense"`
+	Balance        int                   `json:"balance"`
+	BelowThreshold bool                  `json:"belowThreshold"`
+	Transactions   []CashFlowTransaction `json:"transactions"`
+}
+
+// CashFlowTransaction is a single projected income or subscription charge.
+type CashFlowTransaction struct {
+	Type           string `json:"type"` // "income" or "expense"
+	Name           string `json:"name"`
+	Amount         int    `json:"amount"`
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+	NominalDate    string `json:"nominalDate"`
+}
+
+// ForecastService handles cash-flow projection business logic.
+type ForecastService struct {
+	subRepo   repositories.SubscriptionRepository
+	shareRepo repositories.SubscriptionShareRepository
+	holidays  *HolidayCalendar
+	clock     *UserClock
+}
+
+// NewForecastService creates a new ForecastService.
+// holidays and clock may be nil, see NewCalendarService.
+func NewForecastService(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, holidays *HolidayCalendar, clock *UserClock) *ForecastService {
+	return &ForecastService{subRepo: subRepo, shareRepo: shareRepo, holidays: holidays, clock: clock}
+}
+
+// GetCashFlowForecast projects the daily balance from today over the requested
+// number of months. Subscription charges reduce the balance by the full charged
+// amount on their adjusted posting date, since the card is charged in full even
+// when the cost is later split with others.
+func (s *ForecastService) GetCashFlowForecast(userID string, req *CashFlowForecastRequest) (*CashFlowForecast, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	months := req.Months
+	if months == 0 {
+		months = defaultForecastMonths
+	}
+
+	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("현금 흐름 예측 활성 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("현금 흐름 예측을 계산할 수 없습니다")
+	}
+
+	shareMap := buildShareMap(s.shareRepo, userID)
+
+	today := s.clock.Today(userID)
+	end := today.AddDate(0, months, -1)
+
+	// Collect transactions keyed by their posting date.
+	byDate := make(map[string][]CashFlowTransaction)
+
+	// Expand a little beyond the window so charges shifted into it by the
+	// business-day adjustment are not missed.
+	events := expandBillingEvents(activeSubs, shareMap,
+		today.AddDate(0, 0, -maxBusinessDayShift), end.AddDate(0, 0, maxBusinessDayShift), today, s.holidays)
+	for _, ev := range events {
+		if ev.AdjustedDate.Before(today) || ev.AdjustedDate.After(end) {
+			continue
+		}
+		key := ev.AdjustedDate.Format("2006-01-02")
+		byDate[key] = append(byDate[key], CashFlowTransaction{
+			Type:           "expense",
+			Name:           ev.Subscription.ServiceName,
+			Amount:         ev.Amount,
+			SubscriptionID: ev.Subscription.ID.String(),
+			NominalDate:    ev.Date.Format("2006-01-02"),
+		})
+	}
+
+	for _, income := range req.Incomes {
+		for _, pd := range s.expandPaydays(income, today, end) {
+			key := pd.adjusted.Format("2006-01-02")
+			byDate[key] = append(byDate[key], CashFlowTransaction{
+				Type:        "income",
+				Name:        incomeName(income),
+				Amount:      income.Amount,
+				NominalDate: pd.nominal.Format("2006-01-02"),
+			})
+		}
+	}
+
+	forecast := &CashFlowForecast{
+		StartDate:         today.Format("2006-01-02"),
+		EndDate:           end.Format("2006-01-02"),
+		OpeningBalance:    req.Balance,
+		LowestBalance:     req.Balance,
+		LowestBalanceDate: today.Format("2006-01-02"),
+		Threshold:         req.Threshold,
+		Days:              make([]CashFlowDay, 0),
+	}
+
+	balance := req.Balance
+	for d := today; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		txs := byDate[key]
+		// Income first so a same-day payday covers same-day charges.
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].Type == "income" && txs[j].Type != "income"
+		})
+
+		day := CashFlowDay{Date: key, Transactions: make([]CashFlowTransaction, 0, len(txs))}
+		for _, tx := range txs {
+			if tx.Type == "income" {
+				day.Income += tx.Amount
+			} else {
+				day.Expense += tx.Amount
+			}
+			day.Transactions = append(day.Transactions, tx)
+		}
+
+		balance += day.Income - day.Expense
+		day.Balance = balance
+		day.BelowThreshold = balance < req.Threshold
+
+		forecast.TotalIncome += day.Income
+		forecast.TotalExpense += day.Expense
+		if day.BelowThreshold {
+			forecast.LowBalanceDays++
+		}
+		if balance < forecast.LowestBalance {
+			forecast.LowestBalance = balance
+			forecast.LowestBalanceDate = key
+		}
+
+		forecast.Days = append(forecast.Days, day)
+	}
+	forecast.ClosingBalance = balance
+
+	return forecast, nil
+}
+
+// payday is a single nominal income date and its adjusted posting date.
+type payday struct {
+	nominal  time.Time
+	adjusted time.Time
+}
+
+// expandPaydays returns the income dates whose adjusted date falls within [from, to].
+func (s *ForecastService) expandPaydays(income RecurringIncome, from, to time.Time) []payday {
+	mode := models.BusinessDayAdjustment(income.BusinessDayAdjustment)
+	if mode == "" {
+		mode = models.BusinessDayAdjustmentPrevious
+	}
+
+	paydays := make([]payday, 0)
+	// Start one month early: a payday early next month may shift back into range, and vice versa.
+	for m := monthStart(from).AddDate(0, -1, 0); !m.After(monthStart(to).AddDate(0, 1, 0)); m = m.AddDate(0, 1, 0) {
+		nominal := clampedDate(m.Year(), m.Month(), income.DayOfMonth)
+		adjusted := s.holidays.Adjust(nominal, mode)
+		if adjusted.Before(from) || adjusted.After(to) {
+			continue
+		}
+		paydays = append(paydays, payday{nominal: nominal, adjusted: adjusted})
+	}
+	return paydays
+}
+
+// incomeName returns the display name of an income, defaulting to "수입".
+func incomeName(income RecurringIncome) string {
+	if income.Name == "" {
+		return "수입"
+	}
+	return income.Name
+}
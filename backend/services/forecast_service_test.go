@@ -0,0 +1,183 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+func newTestForecastService(t *testing.T, now time.Time) (*ForecastService, *mockSubRepoForCalendar) {
+	t.Helper()
+	holidays, err := LoadHolidayCalendar("")
+	if err != nil {
+		t.Fatalf("load holidays: %v", err)
+	}
+	repo := newMockSubRepoForCalendar()
+	clock := NewUserClock(fixedClock(now), nil)
+	return NewForecastService(repo, newMockShareRepoForCalendar(), holidays, clock), repo
+}
+
+func findForecastDay(t *testing.T, forecast *CashFlowForecast, date string) CashFlowDay {
+	t.Helper()
+	for _, d := range forecast.Days {
+		if d.Date == date {
+			return d
+		}
+	}
+	t.Fatalf("day %s not found in forecast", date)
+	return CashFlowDay{}
+}
+
+func TestGetCashFlowForecast_BalanceProjection(t *testing.T) {
+	// Today is Sunday 2026-09-20 in Seoul.
+	svc, repo := newTestForecastService(t, time.Date(2026, 9, 20, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+
+	seedCalendarSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly,
+		time.Date(2026, 9, 21, 0, 0, 0, 0, time.UTC), nil)
+	seedCalendarSub(repo, userID, "Cloud", 90000, models.BillingCycleMonthly,
+		time.Date(2026, 9, 22, 0, 0, 0, 0, time.UTC), nil)
+
+	forecast, err := svc.GetCashFlowForecast(userID.String(), &CashFlowForecastRequest{
+		Balance:   100000,
+		Months:    1,
+		Threshold: 50000,
+		// The 25th is 추석 in 2026, so salary arrives on Wednesday the 23rd.
+		Incomes: []RecurringIncome{{Name: "급여", Amount: 3000000, DayOfMonth: 25}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertEqual(t, forecast.StartDate, "2026-09-20")
+	assertEqual(t, forecast.EndDate, "2026-10-19")
+	assertEqual(t, len(forecast.Days), 30)
+
+	assertEqual(t, findForecastDay(t, forecast, "2026-09-21").Balance, 83000)
+	low := findForecastDay(t, forecast, "2026-09-22")
+	assertEqual(t, low.Balance, -7000)
+	assertEqual(t, low.BelowThreshold, true)
+
+	payday := findForecastDay(t, forecast, "2026-09-23")
+	assertEqual(t, payday.Income, 3000000)
+	assertEqual(t, payday.Transactions[0].NominalDate, "2026-09-25")
+	assertEqual(t, payday.BelowThreshold, false)
+
+	assertEqual(t, forecast.LowestBalance, -7000)
+	assertEqual(t, forecast.LowestBalanceDate, "2026-09-22")
+	assertEqual(t, forecast.LowBalanceDays, 1)
+	assertEqual(t, forecast.TotalIncome, 3000000)
+	// The October charges fall after the window ends on the 19th.
+	assertEqual(t, forecast.TotalExpense, 17000+90000)
+	assertEqual(t, forecast.ClosingBalance, 100000+3000000-17000-90000)
+}
+
+func TestGetCashFlowForecast_ChargeShiftedByBusinessDay(t *testing.T) {
+	svc, repo := newTestForecastService(t, time.Date(2026, 9, 20, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+
+	// 2026-10-03 is 개천절 (Saturday) and 10-05 is its substitute holiday.
+	sub := seedCalendarSub(repo, userID, "Insurance", 50000, models.BillingCycleMonthly,
+		time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), nil)
+	sub.BusinessDayAdjustment = models.BusinessDayAdjustmentNext
+
+	forecast, err := svc.GetCashFlowForecast(userID.String(), &CashFlowForecastRequest{Balance: 100000, Months: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertEqual(t, findForecastDay(t, forecast, "2026-10-03").Expense, 0)
+	shifted := findForecastDay(t, forecast, "2026-10-06")
+	assertEqual(t, shifted.Expense, 50000)
+	assertEqual(t, shifted.Transactions[0].NominalDate, "2026-10-03")
+}
+
+func TestGetCashFlowForecast_DefaultsAndValidation(t *testing.T) {
+	svc, _ := newTestForecastService(t, time.Date(2026, 1, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New().String()
+
+	forecast, err := svc.GetCashFlowForecast(userID, &CashFlowForecastRequest{Balance: -1000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertEqual(t, forecast.EndDate, "2026-04-09")
+	// Without a threshold, days with a negative balance are flagged.
+	assertEqual(t, forecast.LowBalanceDays, len(forecast.Days))
+
+	_, err = svc.GetCashFlowForecast(userID, &CashFlowForecastRequest{Months: 13})
+	assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+	_, err = svc.GetCashFlowForecast(userID, &CashFlowForecastRequest{
+		Incomes: []RecurringIncome{{Amount: 1000, DayOfMonth: 32}},
+	})
+	assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+}
+
+func TestGetSpendingProjection(t *testing.T) {
+	svc, repo := newTestForecastService(t, time.Date(2026, 1, 15, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	seedCalendarSub(repo, userID, "Netflix", 10000, models.BillingCycleMonthly, date(2026, 1, 20), nil)
+	seedCalendarSub(repo, userID, "Yearly", 120000, models.BillingCycleYearly, date(2026, 3, 10), nil)
+
+	promo := seedCalendarSub(repo, userID, "Promo", 10000, models.BillingCycleMonthly, date(2026, 2, 1), nil)
+	promo.PromoAmount = intPtr(3000)
+	promoEnd := date(2026, 4, 1)
+	promo.PromoEndDate = &promoEnd
+
+	pausing := seedCalendarSub(repo, userID, "Pausing", 5000, models.BillingCycleMonthly, date(2026, 1, 25), nil)
+	pauseStart, pauseEnd := date(2026, 5, 1), date(2026, 7, 1)
+	pausing.PauseStartDate, pausing.PauseEndDate = &pauseStart, &pauseEnd
+
+	ending := seedCalendarSub(repo, userID, "Ending", 7000, models.BillingCycleMonthly, date(2026, 2, 5), nil)
+	ending.AutoRenew = false
+
+	resuming := seedCalendarSub(repo, userID, "Resuming", 4000, models.BillingCycleMonthly, date(2026, 1, 3), nil)
+	resuming.Status = models.SubscriptionStatusPaused
+	resumeStart, resumeOn := date(2025, 12, 1), date(2026, 6, 1)
+	resuming.PauseStartDate, resuming.PauseEndDate = &resumeStart, &resumeOn
+
+	cancelled := seedCalendarSub(repo, userID, "Cancelled", 9000, models.BillingCycleMonthly, date(2026, 1, 20), nil)
+	cancelled.Status = models.SubscriptionStatusCancelled
+
+	projection, err := svc.GetSpendingProjection(userID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertEqual(t, projection.From, date(2026, 1, 15))
+	assertEqual(t, projection.To, date(2026, 12, 31))
+	assertEqual(t, len(projection.Months), 12)
+
+	cash := make([]int, 12)
+	for i, m := range projection.Months {
+		cash[i] = m.CashAmount
+	}
+	assertEqual(t, cash, []int{15000, 25000, 138000, 25000, 20000, 24000, 29000, 29000, 29000, 29000, 29000, 29000})
+	assertEqual(t, projection.TotalCash, 421000)
+	assertEqual(t, projection.AverageMonthlyCash, 35083)
+
+	for i, m := range projection.Months {
+		assertEqual(t, m.Spike, i == 2)
+	}
+
+	// January only counts the 17 days from today.
+	assertEqual(t, projection.Months[0].AmortizedAmount, 19194)
+	// Ending is in service until March 4 and Promo is still promotional.
+	assertEqual(t, projection.Months[2].AmortizedAmount, 28903)
+	// Pausing is paused throughout May.
+	assertEqual(t, projection.Months[4].AmortizedAmount, 30000)
+
+	feb := projection.Months[1].Charges
+	assertEqual(t, len(feb), 4)
+	assertEqual(t, feb[0].ServiceName, "Promo")
+	assertEqual(t, feb[0].Amount, 3000)
+	assertEqual(t, feb[0].Promotional, true)
+	assertEqual(t, feb[1].ServiceName, "Ending")
+	assertEqual(t, feb[1].Final, true)
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/subkeep/backend/models"
+)
+
+// bundledHolidays holds the Korean public holiday list shipped with the binary.
+//
+//go:embed data/holidays_kr.json
+var bundledHolidays []byte
+
+// maxBusinessDayShift bounds the search for a business day so that a
+// malformed holiday file cannot cause an endless loop.
+const maxBusinessDayShift = 31
+
+// Holiday represents a single public holiday entry in a holiday data file.
+type Holiday struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Name string `json:"name"`
+}
+
+// HolidayCalendar answers business-day questions using weekends and a list of
+// public holidays. A nil *HolidayCalendar treats only weekends as non-business days.
+//
+// The list only covers the years between its earliest and latest holiday.
+// Dates outside that range are adjusted for weekends only, and a warning is
+// logged once per year so that a stale holiday file gets noticed.
+type HolidayCalendar struct {
+	holidays  map[string]string // YYYY-MM-DD -> holiday name
+	firstYear int               // 0 when the list is empty
+	lastYear  int
+
+	warnMu sync.Mutex
+	warned map[int]bool // uncovered years already logged
+}
+
+// NewHolidayCalendar creates a HolidayCalendar from the given holidays.
+func NewHolidayCalendar(holidays []Holiday) (*HolidayCalendar, error) {
+	cal := &HolidayCalendar{
+		holidays: make(map[string]string, len(holidays)),
+		warned:   make(map[int]bool),
+	}
+	for _, h := range holidays {
+		date, err := time.Parse("2006-01-02", h.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parse holiday date %q: %w", h.Date, err)
+		}
+		cal.holidays[h.Date] = h.Name
+		if cal.firstYear == 0 || date.Year() < cal.firstYear {
+			cal.firstYear = date.Year()
+		}
+		if date.Year() > cal.lastYear {
+			cal.lastYear = date.Year()
+		}
+	}
+	return cal, nil
+}
+
+// Covers reports whether the holiday list includes the year of the date. A
+// nil calendar covers no year.
+func (c *HolidayCalendar) Covers(t time.Time) bool {
+	if c == nil || c.firstYear == 0 {
+		return false
+	}
+	return t.Year() >= c.firstYear && t.Year() <= c.lastYear
+}
+
+// warnUncovered logs once per year that a date is adjusted without holiday data.
+func (c *HolidayCalendar) warnUncovered(t time.Time) {
+	c.warnMu.Lock()
+	defer c.warnMu.Unlock()
+	if c.warned[t.Year()] {
+		return
+	}
+	c.warned[t.Year()] = true
+	slog.Warn("공휴일 데이터가 없는 연도입니다. 주말만 기준으로 영업일을 계산합니다",
+		"year", t.Year(), "firstYear", c.firstYear, "lastYear", c.lastYear)
+}
+
+// LoadHolidayCalendar reads a holiday data file. When path is empty the bundled
+// Korean public holiday list is used.
+func LoadHolidayCalendar(path string) (*HolidayCalendar, error) {
+	data := bundledHolidays
+	if path != "" {
+		fileData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read holiday file: %w", err)
+		}
+		data = fileData
+	}
+
+	var holidays []Holiday
+	if err := json.Unmarshal(data, &holidays); err != nil {
+		return nil, fmt.Errorf("decode holiday file: %w", err)
+	}
+	return NewHolidayCalendar(holidays)
+}
+
+// HolidayName returns the holiday name for a date, if any.
+func (c *HolidayCalendar) HolidayName(t time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	name, ok := c.holidays[t.Format("2006-01-02")]
+	return name, ok
+}
+
+// IsBusinessDay reports whether the date is neither a weekend nor a holiday.
+func (c *HolidayCalendar) IsBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	_, isHoliday := c.HolidayName(t)
+	return !isHoliday
+}
+
+// Adjust shifts a nominal billing date according to the adjustment mode.
+//   - none:                  unchanged
+//   - next_business_day:     first business day on or after the date
+//   - previous_business_day: last business day on or before the date
+//
+// Dates outside the years covered by the holiday list only skip weekends.
+func (c *HolidayCalendar) Adjust(t time.Time, mode models.BusinessDayAdjustment) time.Time {
+	step := 0
+	switch mode {
+	case models.BusinessDayAdjustmentNext:
+		step = 1
+	case models.BusinessDayAdjustmentPrevious:
+		step = -1
+	default:
+		return t
+	}
+	if c != nil && !c.Covers(t) {
+		c.warnUncovered(t)
+	}
+
+	adjusted := t
+	for i := 0; i < maxBusinessDayShift && !c.IsBusinessDay(adjusted); i++ {
+		adjusted = adjusted.AddDate(0, 0, step)
+	}
+	return adjusted
+}
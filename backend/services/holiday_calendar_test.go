@@ -0,0 +1,122 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/subkeep/backend/models"
+)
+
+func TestLoadHolidayCalendar_Bundled(t *testing.T) {
+	cal, err := LoadHolidayCalendar("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	name, ok := cal.HolidayName(time.Date(2026, 2, 17, 0, 0, 0, 0, time.UTC))
+	if !ok || name != "설날" {
+		t.Errorf("expected 2026-02-17 to be 설날, got %q (%v)", name, ok)
+	}
+	if cal.IsBusinessDay(time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected substitute holiday 2026-10-05 not to be a business day")
+	}
+	if !cal.IsBusinessDay(time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected 2026-10-06 to be a business day")
+	}
+}
+
+func TestLoadHolidayCalendar_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	data := []byte(`[{"date": "2026-07-01", "name": "회사 창립기념일"}]`)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write holiday file: %v", err)
+	}
+
+	cal, err := LoadHolidayCalendar(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cal.IsBusinessDay(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected custom holiday not to be a business day")
+	}
+	// The bundled list is replaced, not merged.
+	if _, ok := cal.HolidayName(time.Date(2026, 2, 17, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("expected bundled holidays to be replaced by the file")
+	}
+}
+
+func TestLoadHolidayCalendar_InvalidDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	if err := os.WriteFile(path, []byte(`[{"date": "2026/07/01", "name": "x"}]`), 0o600); err != nil {
+		t.Fatalf("write holiday file: %v", err)
+	}
+	if _, err := LoadHolidayCalendar(path); err == nil {
+		t.Error("expected error for malformed holiday date")
+	}
+}
+
+func TestHolidayCalendar_Adjust(t *testing.T) {
+	cal, err := NewHolidayCalendar([]Holiday{
+		{Date: "2026-09-24", Name: "추석 연휴"},
+		{Date: "2026-09-25", Name: "추석"},
+		{Date: "2026-09-26", Name: "추석 연휴"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		mode models.BusinessDayAdjustment
+		want string
+	}{
+		{"none keeps the nominal date", scheduleDate(2026, 9, 25), models.BusinessDayAdjustmentNone, "2026-09-25"},
+		{"empty mode keeps the nominal date", scheduleDate(2026, 9, 25), "", "2026-09-25"},
+		{"next skips holidays and the weekend", scheduleDate(2026, 9, 24), models.BusinessDayAdjustmentNext, "2026-09-28"},
+		{"previous moves before the holidays", scheduleDate(2026, 9, 26), models.BusinessDayAdjustmentPrevious, "2026-09-23"},
+		{"business day is unchanged", scheduleDate(2026, 9, 23), models.BusinessDayAdjustmentNext, "2026-09-23"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cal.Adjust(tt.date, tt.mode).Format("2006-01-02")
+			assertEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestHolidayCalendar_NilTreatsOnlyWeekends(t *testing.T) {
+	var cal *HolidayCalendar
+	// Saturday 2026-03-14 → Monday 2026-03-16.
+	got := cal.Adjust(scheduleDate(2026, 3, 14), models.BusinessDayAdjustmentNext)
+	assertEqual(t, got.Format("2006-01-02"), "2026-03-16")
+}
+
+func TestHolidayCalendar_OutOfRangeFallsBackToWeekends(t *testing.T) {
+	cal, err := LoadHolidayCalendar("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertEqual(t, cal.Covers(scheduleDate(2026, 12, 25)), true)
+	assertEqual(t, cal.Covers(scheduleDate(2024, 12, 25)), false)
+	assertEqual(t, cal.Covers(scheduleDate(2028, 1, 3)), false)
+
+	// Christmas 2024 is not in the bundled list, so the Wednesday is kept.
+	got := cal.Adjust(scheduleDate(2024, 12, 25), models.BusinessDayAdjustmentNext)
+	assertEqual(t, got.Format("2006-01-02"), "2024-12-25")
+	// Weekends are still skipped: Saturday 2028-01-01 → Monday 2028-01-03.
+	got = cal.Adjust(scheduleDate(2028, 1, 1), models.BusinessDayAdjustmentNext)
+	assertEqual(t, got.Format("2006-01-02"), "2028-01-03")
+	got = cal.Adjust(scheduleDate(2028, 2, 5), models.BusinessDayAdjustmentNext)
+	assertEqual(t, got.Format("2006-01-02"), "2028-02-07")
+
+	// Each uncovered year is reported once; covered years are not reported.
+	cal.Adjust(scheduleDate(2026, 12, 25), models.BusinessDayAdjustmentNext)
+	assertEqual(t, len(cal.warned), 2)
+	assertEqual(t, cal.warned[2024], true)
+	assertEqual(t, cal.warned[2028], true)
+}
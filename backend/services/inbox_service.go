@@ -0,0 +1,244 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+const (
+	defaultInboxLimit = 20
+	maxInboxLimit     = 100
+)
+
+// InboxEntry is a notification produced by another service for a user's inbox.
+// DedupKey identifies the underlying event; recording the same key twice for a
+// user stores a single entry.
+type InboxEntry struct {
+	Type           models.NotificationType
+	Title          string
+	Body           string
+	SubscriptionID *uuid.UUID
+	DedupKey       string
+}
+
+// InboxListQuery holds the query parameters for listing inbox notifications.
+type InboxListQuery struct {
+	Cursor     string
+	Limit      int
+	UnreadOnly bool
+	Type       string
+}
+
+// InboxPage is a page of inbox notifications. NextCursor is empty on the last page.
+type InboxPage struct {
+	Notifications []*models.Notification
+	Limit         int
+	NextCursor    string
+}
+
+// UnreadCountResponse holds the number of unread inbox notifications.
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+// MarkAllReadResponse holds the number of notifications marked as read.
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// InboxService manages the in-app notification inbox.
+// A nil *InboxService ignores recorded entries, so producers can treat the
+// inbox as optional.
+type InboxService struct {
+	repo  repositories.NotificationRepository
+	clock Clock
+}
+
+// NewInboxService creates a new InboxService. clock defaults to SystemClock when nil.
+func NewInboxService(repo repositories.NotificationRepository, clock Clock) *InboxService {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &InboxService{repo: repo, clock: clock}
+}
+
+// Record stores an entry in the user's inbox unless an entry with the same
+// dedup key already exists. Failures are logged rather than returned so that
+// the inbox never interrupts the operation that produced the entry.
+func (s *InboxService) Record(userID uuid.UUID, entry InboxEntry) {
+	if s == nil {
+		return
+	}
+	n := &models.Notification{
+		UserID:         userID,
+		Type:           entry.Type,
+		Title:          entry.Title,
+		Body:           entry.Body,
+		SubscriptionID: entry.SubscriptionID,
+		DedupKey:       entry.DedupKey,
+		CreatedAt:      s.clock.Now().UTC().Truncate(time.Microsecond),
+	}
+	if _, err := s.repo.CreateIfAbsent(n); err != nil {
+		slog.Error("알림함 기록 실패", "userID", userID, "dedupKey", entry.DedupKey, "error", err)
+	}
+}
+
+// List returns a page of the user's notifications, newest first.
+func (s *InboxService) List(userID string, query InboxListQuery) (*InboxPage, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+	if query.Type != "" && !validNotificationType(query.Type) {
+		return nil, utils.ErrBadRequest("유효하지 않은 알림 유형입니다")
+	}
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultInboxLimit
+	}
+	if limit > maxInboxLimit {
+		limit = maxInboxLimit
+	}
+
+	filter := repositories.NotificationFilter{
+		UnreadOnly: query.UnreadOnly,
+		Type:       query.Type,
+		Limit:      limit + 1,
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeInboxCursor(query.Cursor)
+		if err != nil {
+			return nil, utils.ErrBadRequest("유효하지 않은 커서입니다")
+		}
+		filter.After = cursor
+	}
+
+	notifications, err := s.repo.FindByUserID(userID, filter)
+	if err != nil {
+		slog.Error("알림함 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("알림 목록을 조회할 수 없습니다")
+	}
+
+	page := &InboxPage{Notifications: notifications, Limit: limit}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		last := page.Notifications[limit-1]
+		page.NextCursor = encodeInboxCursor(repositories.NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+// UnreadCount returns the number of unread notifications of the user.
+func (s *InboxService) UnreadCount(userID string) (*UnreadCountResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+	count, err := s.repo.CountUnread(userID)
+	if err != nil {
+		slog.Error("읽지 않은 알림 수 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("읽지 않은 알림 수를 조회할 수 없습니다")
+	}
+	return &UnreadCountResponse{Count: count}, nil
+}
+
+// MarkRead marks one of the user's notifications as read.
+func (s *InboxService) MarkRead(userID, id string) (*models.Notification, error) {
+	return s.setRead(userID, id, true)
+}
+
+// MarkUnread marks one of the user's notifications as unread.
+func (s *InboxService) MarkUnread(userID, id string) (*models.Notification, error) {
+	return s.setRead(userID, id, false)
+}
+
+// MarkAllRead marks every unread notification of the user as read.
+func (s *InboxService) MarkAllRead(userID string) (*MarkAllReadResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+	updated, err := s.repo.MarkAllRead(userID, s.clock.Now().UTC())
+	if err != nil {
+		slog.Error("전체 알림 읽음 처리 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("알림을 읽음 처리할 수 없습니다")
+	}
+	return &MarkAllReadResponse{Updated: updated}, nil
+}
+
+// setRead loads a notification owned by the user and updates its read state.
+func (s *InboxService) setRead(userID, id string, read bool) (*models.Notification, error) {
+	n, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("알림을 찾을 수 없습니다")
+		}
+		slog.Error("알림 조회 실패", "notificationID", id, "error", err)
+		return nil, utils.ErrInternal("알림을 조회할 수 없습니다")
+	}
+	if n.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 알림에 대한 접근 권한이 없습니다")
+	}
+
+	if n.IsRead() == read {
+		return n, nil
+	}
+	if read {
+		now := s.clock.Now().UTC()
+		n.ReadAt = &now
+	} else {
+		n.ReadAt = nil
+	}
+	if err := s.repo.Update(n); err != nil {
+		slog.Error("알림 읽음 상태 변경 실패", "notificationID", id, "error", err)
+		return nil, utils.ErrInternal("알림 상태를 변경할 수 없습니다")
+	}
+	return n, nil
+}
+
+// validNotificationType reports whether t is a known inbox notification type.
+func validNotificationType(t string) bool {
+	for _, known := range models.NotificationTypes {
+		if string(known) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeInboxCursor turns a cursor into an opaque URL-safe token.
+func encodeInboxCursor(c repositories.NotificationCursor) string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeInboxCursor parses a token produced by encodeInboxCursor.
+func decodeInboxCursor(token string) (*repositories.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse cursor time: %w", err)
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("parse cursor id: %w", err)
+	}
+	return &repositories.NotificationCursor{CreatedAt: time.Unix(0, n).UTC(), ID: uid}, nil
+}
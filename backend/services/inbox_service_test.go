@@ -0,0 +1,290 @@
+package services
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// ---------------------------------------------------------------------------
+// Mock Notification repository
+// ---------------------------------------------------------------------------
+
+type mockNotificationRepo struct {
+	notifications map[string]*models.Notification
+}
+
+func newMockNotificationRepo() *mockNotificationRepo {
+	return &mockNotificationRepo{notifications: make(map[string]*models.Notification)}
+}
+
+func (m *mockNotificationRepo) FindByID(id string) (*models.Notification, error) {
+	n, ok := m.notifications[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *n
+	return &cp, nil
+}
+
+func (m *mockNotificationRepo) FindByUserID(userID string, filter repositories.NotificationFilter) ([]*models.Notification, error) {
+	var result []*models.Notification
+	for _, n := range m.notifications {
+		if n.UserID.String() != userID {
+			continue
+		}
+		if filter.UnreadOnly && n.IsRead() {
+			continue
+		}
+		if filter.Type != "" && string(n.Type) != filter.Type {
+			continue
+		}
+		if a := filter.After; a != nil {
+			if n.CreatedAt.After(a.CreatedAt) ||
+				(n.CreatedAt.Equal(a.CreatedAt) && n.ID.String() >= a.ID.String()) {
+				continue
+			}
+		}
+		cp := *n
+		result = append(result, &cp)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID.String() > result[j].ID.String()
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (m *mockNotificationRepo) CountUnread(userID string) (int64, error) {
+	var count int64
+	for _, n := range m.notifications {
+		if n.UserID.String() == userID && !n.IsRead() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockNotificationRepo) CreateIfAbsent(n *models.Notification) (bool, error) {
+	for _, existing := range m.notifications {
+		if existing.UserID == n.UserID && existing.DedupKey == n.DedupKey {
+			return false, nil
+		}
+	}
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	cp := *n
+	m.notifications[n.ID.String()] = &cp
+	return true, nil
+}
+
+func (m *mockNotificationRepo) Update(n *models.Notification) error {
+	cp := *n
+	m.notifications[n.ID.String()] = &cp
+	return nil
+}
+
+func (m *mockNotificationRepo) MarkAllRead(userID string, readAt time.Time) (int64, error) {
+	var updated int64
+	for _, n := range m.notifications {
+		if n.UserID.String() == userID && !n.IsRead() {
+			at := readAt
+			n.ReadAt = &at
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (m *mockNotificationRepo) byType(userID uuid.UUID, t models.NotificationType) []*models.Notification {
+	var result []*models.Notification
+	for _, n := range m.notifications {
+		if n.UserID == userID && n.Type == t {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// newTestInbox returns an inbox on a clock the test advances by hand.
+func newTestInbox() (*InboxService, *mockNotificationRepo, *stepClock) {
+	repo := newMockNotificationRepo()
+	clock := &stepClock{t: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)}
+	return NewInboxService(repo, clock), repo, clock
+}
+
+// recordTestEntries records n billing reminders one minute apart.
+func recordTestEntries(inbox *InboxService, clock *stepClock, userID uuid.UUID, n int) {
+	for i := 0; i < n; i++ {
+		clock.advance(time.Minute)
+		inbox.Record(userID, InboxEntry{
+			Type:     models.NotificationTypeBillingReminder,
+			Title:    "Netflix 결제 예정",
+			Body:     "Netflix 구독료 17,000원이 결제될 예정입니다.",
+			DedupKey: uuid.NewString(),
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestInboxService_Record(t *testing.T) {
+	t.Run("deduplicates by key", func(t *testing.T) {
+		inbox, repo, _ := newTestInbox()
+		userID := uuid.New()
+		entry := InboxEntry{Type: models.NotificationTypePriceChanged, Title: "가격 변경", Body: "본문", DedupKey: "price:1"}
+
+		inbox.Record(userID, entry)
+		inbox.Record(userID, entry)
+		inbox.Record(uuid.New(), entry)
+
+		assertEqual(t, 1, len(repo.byType(userID, models.NotificationTypePriceChanged)))
+		assertEqual(t, 2, len(repo.notifications))
+	})
+
+	t.Run("nil inbox is a no-op", func(t *testing.T) {
+		var inbox *InboxService
+		inbox.Record(uuid.New(), InboxEntry{DedupKey: "x"})
+	})
+}
+
+func TestInboxService_List(t *testing.T) {
+	t.Run("pages with a cursor, newest first", func(t *testing.T) {
+		inbox, _, clock := newTestInbox()
+		userID := uuid.New()
+		recordTestEntries(inbox, clock, userID, 5)
+
+		first, err := inbox.List(userID.String(), InboxListQuery{Limit: 2})
+		assertNil(t, err)
+		assertEqual(t, 2, len(first.Notifications))
+		if first.NextCursor == "" {
+			t.Fatal("expected a next cursor")
+		}
+		if !first.Notifications[0].CreatedAt.After(first.Notifications[1].CreatedAt) {
+			t.Error("expected newest first")
+		}
+
+		second, err := inbox.List(userID.String(), InboxListQuery{Limit: 2, Cursor: first.NextCursor})
+		assertNil(t, err)
+		assertEqual(t, 2, len(second.Notifications))
+		if !second.Notifications[0].CreatedAt.Before(first.Notifications[1].CreatedAt) {
+			t.Error("second page overlaps the first")
+		}
+
+		last, err := inbox.List(userID.String(), InboxListQuery{Limit: 2, Cursor: second.NextCursor})
+		assertNil(t, err)
+		assertEqual(t, 1, len(last.Notifications))
+		assertEqual(t, "", last.NextCursor)
+	})
+
+	t.Run("filters unread and by type", func(t *testing.T) {
+		inbox, repo, clock := newTestInbox()
+		userID := uuid.New()
+		recordTestEntries(inbox, clock, userID, 3)
+		inbox.Record(userID, InboxEntry{Type: models.NotificationTypeRecommendation, Title: "t", Body: "b", DedupKey: "rec"})
+
+		page, err := inbox.List(userID.String(), InboxListQuery{Type: string(models.NotificationTypeRecommendation)})
+		assertNil(t, err)
+		assertEqual(t, 1, len(page.Notifications))
+
+		_, err = inbox.MarkRead(userID.String(), repo.byType(userID, models.NotificationTypeRecommendation)[0].ID.String())
+		assertNil(t, err)
+		page, err = inbox.List(userID.String(), InboxListQuery{UnreadOnly: true})
+		assertNil(t, err)
+		assertEqual(t, 3, len(page.Notifications))
+	})
+
+	t.Run("clamps the limit", func(t *testing.T) {
+		inbox, _, _ := newTestInbox()
+		page, err := inbox.List(uuid.NewString(), InboxListQuery{Limit: 1000})
+		assertNil(t, err)
+		assertEqual(t, maxInboxLimit, page.Limit)
+
+		page, err = inbox.List(uuid.NewString(), InboxListQuery{})
+		assertNil(t, err)
+		assertEqual(t, defaultInboxLimit, page.Limit)
+	})
+
+	t.Run("rejects bad input", func(t *testing.T) {
+		inbox, _, _ := newTestInbox()
+		_, err := inbox.List(uuid.NewString(), InboxListQuery{Cursor: "not-a-cursor"})
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+
+		_, err = inbox.List(uuid.NewString(), InboxListQuery{Type: "unknown"})
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+
+		_, err = inbox.List("not-a-uuid", InboxListQuery{})
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestInboxService_ReadState(t *testing.T) {
+	inbox, repo, clock := newTestInbox()
+	userID := uuid.New()
+	recordTestEntries(inbox, clock, userID, 3)
+
+	count, err := inbox.UnreadCount(userID.String())
+	assertNil(t, err)
+	assertEqual(t, int64(3), count.Count)
+
+	var id string
+	for k := range repo.notifications {
+		id = k
+		break
+	}
+
+	_, err = inbox.MarkRead(uuid.NewString(), id)
+	assertAppErrorCode(t, err, http.StatusForbidden)
+	_, err = inbox.MarkRead(userID.String(), uuid.NewString())
+	assertAppErrorCode(t, err, http.StatusNotFound)
+
+	n, err := inbox.MarkRead(userID.String(), id)
+	assertNil(t, err)
+	assertNotNil(t, n.ReadAt)
+	count, _ = inbox.UnreadCount(userID.String())
+	assertEqual(t, int64(2), count.Count)
+
+	n, err = inbox.MarkUnread(userID.String(), id)
+	assertNil(t, err)
+	if n.ReadAt != nil {
+		t.Error("expected notification to be unread")
+	}
+
+	result, err := inbox.MarkAllRead(userID.String())
+	assertNil(t, err)
+	assertEqual(t, int64(3), result.Updated)
+	count, _ = inbox.UnreadCount(userID.String())
+	assertEqual(t, int64(0), count.Count)
+}
+
+func TestInboxCursor_RoundTrip(t *testing.T) {
+	c := repositories.NotificationCursor{
+		CreatedAt: time.Date(2026, 3, 10, 9, 30, 0, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+	decoded, err := decodeInboxCursor(encodeInboxCursor(c))
+	assertNil(t, err)
+	assertEqual(t, c.ID, decoded.ID)
+	if !decoded.CreatedAt.Equal(c.CreatedAt) {
+		t.Errorf("expected %v, got %v", c.CreatedAt, decoded.CreatedAt)
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// Default lead times for rules created without daysBefore.
+const (
+	defaultBillingReminderDays = 3
+	defaultTrialReminderDays   = 1
+)
+
+// CreateNotificationRuleRequest holds the body for creating a notification rule.
+type CreateNotificationRuleRequest struct {
+	SubscriptionID *string `json:"subscriptionId" validate:"omitempty,uuid"`
+	Type           string  `json:"type" validate:"required,oneof=billing_upcoming trial_ending price_changed"`
+	DaysBefore     *int    `json:"daysBefore" validate:"omitempty,gte=0,lte=30"`
+	Channel        string  `json:"channel" validate:"required,oneof=email webhook log push"`
+	Target         *string `json:"target" validate:"omitempty,max=500"`
+	Enabled        *bool   `json:"enabled"`
+}
+
+// UpdateNotificationRuleRequest holds the body for updating a notification rule.
+type UpdateNotificationRuleRequest struct {
+	DaysBefore *int    `json:"daysBefore" validate:"omitempty,gte=0,lte=30"`
+	Channel    *string `json:"channel" validate:"omitempty,oneof=email webhook log push"`
+	Target     *string `json:"target" validate:"omitempty,max=500"`
+	Enabled    *bool   `json:"enabled"`
+}
+
+// NotificationRuleService handles business logic for notification rules.
+type NotificationRuleService struct {
+	ruleRepo     repositories.NotificationRuleRepository
+	deliveryRepo repositories.NotificationDeliveryRepository
+	subRepo      repositories.SubscriptionRepository
+}
+
+// NewNotificationRuleService creates a new NotificationRuleService.
+func NewNotificationRuleService(ruleRepo repositories.NotificationRuleRepository, deliveryRepo repositories.NotificationDeliveryRepository, subRepo repositories.SubscriptionRepository) *NotificationRuleService {
+	return &NotificationRuleService{ruleRepo: ruleRepo, deliveryRepo: deliveryRepo, subRepo: subRepo}
+}
+
+// GetRules returns all notification rules of the user.
+func (s *NotificationRuleService) GetRules(userID string) ([]*models.NotificationRule, error) {
+	rules, err := s.ruleRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("알림 규칙 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("알림 규칙 목록을 조회할 수 없습니다")
+	}
+	return rules, nil
+}
+
+// CreateRule validates and creates a new notification rule.
+func (s *NotificationRuleService) CreateRule(userID string, req *CreateNotificationRuleRequest) (*models.NotificationRule, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	rule := &models.NotificationRule{
+		UserID:  uid,
+		Type:    models.NotificationRuleType(req.Type),
+		Channel: models.NotificationChannel(req.Channel),
+		Target:  req.Target,
+		Enabled: true,
+	}
+
+	if req.SubscriptionID != nil && *req.SubscriptionID != "" {
+		sub, appErr := s.findOwnedSubscription(userID, *req.SubscriptionID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		rule.SubscriptionID = &sub.ID
+	}
+
+	switch {
+	case req.DaysBefore != nil:
+		rule.DaysBefore = *req.DaysBefore
+	case rule.Type == models.NotificationRuleBillingUpcoming:
+		rule.DaysBefore = defaultBillingReminderDays
+	case rule.Type == models.NotificationRuleTrialEnding:
+		rule.DaysBefore = defaultTrialReminderDays
+	}
+
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	if appErr := validateRuleTarget(rule); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		slog.Error("알림 규칙 생성 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("알림 규칙을 생성할 수 없습니다")
+	}
+
+	return rule, nil
+}
+
+// UpdateRule validates ownership and applies partial updates to a rule.
+func (s *NotificationRuleService) UpdateRule(userID, ruleID string, req *UpdateNotificationRuleRequest) (*models.NotificationRule, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	rule, appErr := s.findOwnedRule(userID, ruleID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if req.DaysBefore != nil {
+		rule.DaysBefore = *req.DaysBefore
+	}
+	if req.Channel != nil {
+		rule.Channel = models.NotificationChannel(*req.Channel)
+	}
+	if req.Target != nil {
+		if *req.Target == "" {
+			rule.Target = nil
+		} else {
+			rule.Target = req.Target
+		}
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	if appErr := validateRuleTarget(rule); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		slog.Error("알림 규칙 수정 실패", "ruleID", ruleID, "error", err)
+		return nil, utils.ErrInternal("알림 규칙을 수정할 수 없습니다")
+	}
+
+	return rule, nil
+}
+
+// DeleteRule validates ownership and deletes a rule.
+func (s *NotificationRuleService) DeleteRule(userID, ruleID string) error {
+	if _, appErr := s.findOwnedRule(userID, ruleID); appErr != nil {
+		return appErr
+	}
+
+	if err := s.ruleRepo.Delete(ruleID); err != nil {
+		slog.Error("알림 규칙 삭제 실패", "ruleID", ruleID, "error", err)
+		return utils.ErrInternal("알림 규칙을 삭제할 수 없습니다")
+	}
+	return nil
+}
+
+// GetDeliveries returns the user's notification delivery history, newest first.
+func (s *NotificationRuleService) GetDeliveries(userID string, page, perPage int) ([]*models.NotificationDelivery, int64, error) {
+	deliveries, total, err := s.deliveryRepo.FindByUserID(userID, page, perPage)
+	if err != nil {
+		slog.Error("알림 발송 이력 조회 실패", "userID", userID, "error", err)
+		return nil, 0, utils.ErrInternal("알림 발송 이력을 조회할 수 없습니다")
+	}
+	return deliveries, total, nil
+}
+
+// findOwnedRule loads a rule and verifies it belongs to the user.
+func (s *NotificationRuleService) findOwnedRule(userID, ruleID string) (*models.NotificationRule, *utils.AppError) {
+	rule, err := s.ruleRepo.FindByID(ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("알림 규칙을 찾을 수 없습니다")
+		}
+		slog.Error("알림 규칙 조회 실패", "ruleID", ruleID, "error", err)
+		return nil, utils.ErrInternal("알림 규칙을 조회할 수 없습니다")
+	}
+	if rule.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 알림 규칙에 대한 접근 권한이 없습니다")
+	}
+	return rule, nil
+}
+
+// findOwnedSubscription loads a subscription and verifies it belongs to the user.
+func (s *NotificationRuleService) findOwnedSubscription(userID, subID string) (*models.Subscription, *utils.AppError) {
+	sub, err := s.subRepo.FindByID(subID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("구독을 찾을 수 없습니다")
+		}
+		slog.Error("알림 규칙 구독 조회 실패", "subID", subID, "error", err)
+		return nil, utils.ErrInternal("구독을 조회할 수 없습니다")
+	}
+	if sub.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 구독에 대한 접근 권한이 없습니다")
+	}
+	return sub, nil
+}
+
+// validateRuleTarget checks that the target matches the rule's channel.
+// Email rules may omit the target to use the account email address.
+func validateRuleTarget(rule *models.NotificationRule) *utils.AppError {
+	return validateChannelTarget(rule.Channel, rule.Target)
+}
+
+// validateChannelTarget checks that target is a valid address for channel.
+// Webhooks require an http(s) URL outside the server's own network; email
+// targets are optional.
+func validateChannelTarget(channel models.NotificationChannel, target *string) *utils.AppError {
+	value := ""
+	if target != nil {
+		value = *target
+	}
+
+	switch channel {
+	case models.NotificationChannelWebhook:
+		if err := validateOutboundURL(value); err != nil {
+			if errors.Is(err, errBlockedAddress) {
+				return utils.ErrValidation("내부 네트워크 주소로는 웹훅 알림을 보낼 수 없습니다")
+			}
+			return utils.ErrValidation("웹훅 알림에는 유효한 http(s) URL이 필요합니다")
+		}
+	case models.NotificationChannelEmail:
+		if value != "" {
+			if err := utils.GetValidator().Var(value, "email"); err != nil {
+				return utils.ErrValidation("유효한 이메일 주소를 입력해주세요")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+func newTestNotificationRuleService() (*NotificationRuleService, *mockNotificationRuleRepo, *mockSubscriptionRepo) {
+	rules := newMockNotificationRuleRepo()
+	subs := newMockRepo()
+	return NewNotificationRuleService(rules, newMockNotificationDeliveryRepo(), subs), rules, subs
+}
+
+func TestCreateNotificationRule(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("applies default lead time", func(t *testing.T) {
+		svc, _, _ := newTestNotificationRuleService()
+		rule, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			Type:    "billing_upcoming",
+			Channel: "log",
+		})
+		assertNil(t, err)
+		assertEqual(t, rule.DaysBefore, 3)
+		assertEqual(t, rule.Enabled, true)
+		assertNil(t, rule.SubscriptionID)
+	})
+
+	t.Run("scopes rule to an owned subscription", func(t *testing.T) {
+		svc, _, subs := newTestNotificationRuleService()
+		sub := subs.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		rule, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			SubscriptionID: strPtr(sub.ID.String()),
+			Type:           "trial_ending",
+			Channel:        "email",
+		})
+		assertNil(t, err)
+		assertEqual(t, *rule.SubscriptionID, sub.ID)
+		assertEqual(t, rule.DaysBefore, 1)
+	})
+
+	t.Run("rejects another user's subscription", func(t *testing.T) {
+		svc, _, subs := newTestNotificationRuleService()
+		sub := subs.seedSubscription(uuid.New(), "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			SubscriptionID: strPtr(sub.ID.String()),
+			Type:           "billing_upcoming",
+			Channel:        "log",
+		})
+		assertAppErrorCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("requires webhook url", func(t *testing.T) {
+		svc, _, _ := newTestNotificationRuleService()
+		_, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			Type:    "price_changed",
+			Channel: "webhook",
+			Target:  strPtr("not a url"),
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects internal webhook address", func(t *testing.T) {
+		svc, _, _ := newTestNotificationRuleService()
+		_, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			Type:    "price_changed",
+			Channel: "webhook",
+			Target:  strPtr("http://169.254.169.254/latest/meta-data"),
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects invalid email target", func(t *testing.T) {
+		svc, _, _ := newTestNotificationRuleService()
+		_, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			Type:    "billing_upcoming",
+			Channel: "email",
+			Target:  strPtr("nobody"),
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects unknown type", func(t *testing.T) {
+		svc, _, _ := newTestNotificationRuleService()
+		_, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+			Type:    "weather",
+			Channel: "log",
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestUpdateAndDeleteNotificationRule(t *testing.T) {
+	userID := uuid.New()
+	svc, rules, _ := newTestNotificationRuleService()
+
+	rule, err := svc.CreateRule(userID.String(), &CreateNotificationRuleRequest{
+		Type:    "billing_upcoming",
+		Channel: "log",
+	})
+	assertNil(t, err)
+
+	t.Run("other users cannot modify", func(t *testing.T) {
+		_, err := svc.UpdateRule(uuid.New().String(), rule.ID.String(), &UpdateNotificationRuleRequest{Enabled: boolPtr(false)})
+		assertAppErrorCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("switching to webhook requires a target", func(t *testing.T) {
+		_, err := svc.UpdateRule(userID.String(), rule.ID.String(), &UpdateNotificationRuleRequest{Channel: strPtr("webhook")})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("updates fields", func(t *testing.T) {
+		updated, err := svc.UpdateRule(userID.String(), rule.ID.String(), &UpdateNotificationRuleRequest{
+			DaysBefore: intPtr(7),
+			Enabled:    boolPtr(false),
+		})
+		assertNil(t, err)
+		assertEqual(t, updated.DaysBefore, 7)
+		assertEqual(t, updated.Enabled, false)
+	})
+
+	t.Run("deletes rule", func(t *testing.T) {
+		assertNil(t, svc.DeleteRule(userID.String(), rule.ID.String()))
+		assertEqual(t, len(rules.rules), 0)
+		assertAppErrorCode(t, svc.DeleteRule(userID.String(), rule.ID.String()), http.StatusNotFound)
+	})
+}
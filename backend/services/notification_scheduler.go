@@ -0,0 +1,525 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// pendingNotification is a rule match waiting to be delivered.
+type pendingNotification struct {
+	dedupKey     string
+	subscription *models.Subscription
+	subject      string
+	body         string
+
+	// inboxType and inboxKey are set for trial endings, which also belong in
+	// the user's inbox. The key is shared across rules so that several rules
+	// matching the same event produce a single inbox entry.
+	inboxType  models.NotificationType
+	inboxTitle string
+	inboxKey   string
+}
+
+// NotificationScheduler periodically evaluates notification rules against
+// upcoming billing events and delivers matches through the configured notifiers.
+//
+// Every match is recorded as a NotificationDelivery keyed by rule and event
+// before it is sent, so an event is delivered at most once per rule. Failed
+// sends are retried with linear backoff until MaxAttempts is reached.
+//
+// Billing reminders and share settlement requests are written to the in-app
+// inbox whether or not the user has rules; rules only decide what is sent
+// through the other channels. Trial endings reach the inbox when a rule
+// matches them.
+type NotificationScheduler struct {
+	ruleRepo     repositories.NotificationRuleRepository
+	deliveryRepo repositories.NotificationDeliveryRepository
+	subRepo      repositories.SubscriptionRepository
+	priceRepo    repositories.PriceChangeRepository
+	userRepo     repositories.UserRepository
+	shareRepo    repositories.SubscriptionShareRepository
+	notifiers    map[models.NotificationChannel]Notifier
+	holidays     *HolidayCalendar
+	clock        *UserClock
+	inbox        *InboxService
+	cfg          config.NotificationConfig
+}
+
+// NewNotificationScheduler creates a new NotificationScheduler.
+// Channels without an entry in notifiers are recorded as failed deliveries.
+// shareRepo and inbox may be nil, which disables the inbox entries.
+func NewNotificationScheduler(
+	ruleRepo repositories.NotificationRuleRepository,
+	deliveryRepo repositories.NotificationDeliveryRepository,
+	subRepo repositories.SubscriptionRepository,
+	priceRepo repositories.PriceChangeRepository,
+	userRepo repositories.UserRepository,
+	shareRepo repositories.SubscriptionShareRepository,
+	notifiers map[models.NotificationChannel]Notifier,
+	holidays *HolidayCalendar,
+	clock *UserClock,
+	inbox *InboxService,
+	cfg config.NotificationConfig,
+) *NotificationScheduler {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	return &NotificationScheduler{
+		ruleRepo:     ruleRepo,
+		deliveryRepo: deliveryRepo,
+		subRepo:      subRepo,
+		priceRepo:    priceRepo,
+		userRepo:     userRepo,
+		shareRepo:    shareRepo,
+		notifiers:    notifiers,
+		holidays:     holidays,
+		clock:        clock,
+		inbox:        inbox,
+		cfg:          cfg,
+	}
+}
+
+// Start runs the scheduler every cfg.Interval until ctx is cancelled.
+// The first evaluation happens immediately.
+func (s *NotificationScheduler) Start(ctx context.Context) {
+	interval := s.cfg.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			slog.Error("알림 스케줄러 실행 실패", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce evaluates every enabled rule once and returns the number of
+// notifications delivered. Upcoming billing reminders and settlement requests
+// for shared subscriptions billed today are recorded in the inbox afterwards;
+// they are not counted.
+func (s *NotificationScheduler) RunOnce(ctx context.Context) (int, error) {
+	rules, err := s.ruleRepo.FindEnabled()
+	if err != nil {
+		return 0, fmt.Errorf("load notification rules: %w", err)
+	}
+
+	// Group rules by user, preserving repository order.
+	userOrder := make([]string, 0)
+	byUser := make(map[string][]*models.NotificationRule)
+	for _, rule := range rules {
+		uid := rule.UserID.String()
+		if _, ok := byUser[uid]; !ok {
+			userOrder = append(userOrder, uid)
+		}
+		byUser[uid] = append(byUser[uid], rule)
+	}
+
+	delivered := 0
+	for _, userID := range userOrder {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+		delivered += s.runForUser(ctx, userID, byUser[userID])
+	}
+
+	s.recordBillingReminders(ctx)
+	s.recordSettlements(ctx)
+	return delivered, nil
+}
+
+// runForUser evaluates a single user's rules and delivers the matches.
+func (s *NotificationScheduler) runForUser(ctx context.Context, userID string, rules []*models.NotificationRule) int {
+	subs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("알림 대상 구독 조회 실패", "userID", userID, "error", err)
+		return 0
+	}
+
+	today := s.clock.Today(userID)
+	delivered := 0
+	for _, rule := range rules {
+		for _, p := range s.evaluate(rule, subs, today) {
+			if p.inboxKey != "" {
+				s.inbox.Record(rule.UserID, InboxEntry{
+					Type:           p.inboxType,
+					Title:          p.inboxTitle,
+					Body:           p.body,
+					SubscriptionID: &p.subscription.ID,
+					DedupKey:       p.inboxKey,
+				})
+			}
+			if s.deliver(ctx, rule, p) {
+				delivered++
+			}
+		}
+	}
+	return delivered
+}
+
+// evaluate returns the notifications a rule produces for the given day.
+// Windows start at today, so events missed while the scheduler was down are
+// still delivered as long as they have not yet passed.
+func (s *NotificationScheduler) evaluate(rule *models.NotificationRule, subs []*models.Subscription, today time.Time) []pendingNotification {
+	targets := make([]*models.Subscription, 0, len(subs))
+	for _, sub := range subs {
+		if rule.SubscriptionID == nil || *rule.SubscriptionID == sub.ID {
+			targets = append(targets, sub)
+		}
+	}
+
+	horizon := today.AddDate(0, 0, rule.DaysBefore)
+	pending := make([]pendingNotification, 0)
+
+	switch rule.Type {
+	case models.NotificationRuleBillingUpcoming:
+		for _, ev := range expandBillingEvents(targets, nil, today, horizon, today, s.holidays) {
+			pending = append(pending, pendingNotification{
+				dedupKey:     fmt.Sprintf("%s:billing:%s:%s", rule.ID, ev.Subscription.ID, ev.Date.Format("2006-01-02")),
+				subscription: ev.Subscription,
+				subject:      fmt.Sprintf("[SubKeep] %s 결제 예정 알림", ev.Subscription.ServiceName),
+				body:         billingReminderBody(ev, today),
+			})
+		}
+
+	case models.NotificationRuleTrialEnding:
+		for _, sub := range targets {
+			if sub.TrialEndDate == nil {
+				continue
+			}
+			end := truncateDate(*sub.TrialEndDate)
+			if end.Before(today) || end.After(horizon) {
+				continue
+			}
+			date := end.Format("2006-01-02")
+			pending = append(pending, pendingNotification{
+				dedupKey:     fmt.Sprintf("%s:trial:%s:%s", rule.ID, sub.ID, date),
+				subscription: sub,
+				subject:      fmt.Sprintf("[SubKeep] %s 무료 체험 종료 알림", sub.ServiceName),
+				body: fmt.Sprintf("%s 무료 체험이 %s(%s)에 종료됩니다. 이후 %s원이 결제됩니다.",
+					sub.ServiceName, date, dDayLabel(today, end), formatWon(sub.Amount)),
+				inboxType:  models.NotificationTypeTrialEnding,
+				inboxTitle: fmt.Sprintf("%s 무료 체험 종료 예정", sub.ServiceName),
+				inboxKey:   fmt.Sprintf("trial:%s:%s", sub.ID, date),
+			})
+		}
+
+	case models.NotificationRulePriceChanged:
+		if s.priceRepo == nil {
+			break
+		}
+		changes, err := s.priceRepo.FindByUserIDSince(rule.UserID.String(), rule.CreatedAt)
+		if err != nil {
+			slog.Error("가격 변경 이력 조회 실패", "userID", rule.UserID, "error", err)
+			break
+		}
+		subByID := make(map[string]*models.Subscription, len(targets))
+		for _, sub := range targets {
+			subByID[sub.ID.String()] = sub
+		}
+		for _, change := range changes {
+			sub, ok := subByID[change.SubscriptionID.String()]
+			if !ok {
+				continue
+			}
+			pending = append(pending, pendingNotification{
+				dedupKey:     fmt.Sprintf("%s:price:%s", rule.ID, change.ID),
+				subscription: sub,
+				subject:      fmt.Sprintf("[SubKeep] %s 가격 변경 알림", sub.ServiceName),
+				body: fmt.Sprintf("%s 가격이 %s원(%s)에서 %s원(%s)으로 변경되었습니다.",
+					sub.ServiceName,
+					formatWon(change.OldAmount), billingCycleLabel(change.OldBillingCycle),
+					formatWon(change.NewAmount), billingCycleLabel(change.NewBillingCycle)),
+			})
+		}
+	}
+
+	return pending
+}
+
+// billingReminderBody describes an upcoming charge, with the actual debit date
+// when it is moved to a business day.
+func billingReminderBody(ev BillingEvent, today time.Time) string {
+	body := fmt.Sprintf("%s 구독료 %s원이 %s(%s)에 결제될 예정입니다.",
+		ev.Subscription.ServiceName, formatWon(ev.Amount), ev.Date.Format("2006-01-02"), dDayLabel(today, ev.Date))
+	if !ev.AdjustedDate.Equal(ev.Date) {
+		body += fmt.Sprintf("\n영업일 기준 실제 출금일은 %s입니다.", ev.AdjustedDate.Format("2006-01-02"))
+	}
+	return body
+}
+
+// recordBillingReminders writes a reminder to the inbox of every user with an
+// active subscription charged within the next defaultBillingReminderDays
+// days, independently of their notification rules.
+func (s *NotificationScheduler) recordBillingReminders(ctx context.Context) {
+	if s.inbox == nil {
+		return
+	}
+	userIDs, err := s.subRepo.FindActiveUserIDs()
+	if err != nil {
+		slog.Error("결제 예정 알림 대상 사용자 조회 실패", "error", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		subs, err := findAllSubscriptions(s.subRepo, userID, repositories.SubscriptionFilter{Status: "active"})
+		if err != nil {
+			slog.Error("결제 예정 알림 대상 구독 조회 실패", "userID", userID, "error", err)
+			continue
+		}
+
+		today := s.clock.Today(userID)
+		horizon := today.AddDate(0, 0, defaultBillingReminderDays)
+		for _, ev := range expandBillingEvents(subs, nil, today, horizon, today, s.holidays) {
+			s.inbox.Record(ev.Subscription.UserID, InboxEntry{
+				Type:           models.NotificationTypeBillingReminder,
+				Title:          fmt.Sprintf("%s 결제 예정", ev.Subscription.ServiceName),
+				Body:           billingReminderBody(ev, today),
+				SubscriptionID: &ev.Subscription.ID,
+				DedupKey:       fmt.Sprintf("billing:%s:%s", ev.Subscription.ID, ev.Date.Format("2006-01-02")),
+			})
+		}
+	}
+}
+
+// recordSettlements writes a settlement request to the inbox of every user
+// whose shared subscription is charged today, reminding them to collect the
+// other members' portion.
+func (s *NotificationScheduler) recordSettlements(ctx context.Context) {
+	if s.inbox == nil || s.shareRepo == nil {
+		return
+	}
+	userIDs, err := s.shareRepo.FindSharingUserIDs()
+	if err != nil {
+		slog.Error("공유 구독 사용자 조회 실패", "error", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		subs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+			Status:  "active",
+			Page:    1,
+			PerPage: 100,
+		})
+		if err != nil {
+			slog.Error("정산 대상 구독 조회 실패", "userID", userID, "error", err)
+			continue
+		}
+		shareMap := buildShareMap(s.shareRepo, userID)
+		if len(shareMap) == 0 {
+			continue
+		}
+
+		// Look back far enough that a charge moved to today by a weekend or
+		// holiday is still found.
+		today := s.clock.Today(userID)
+		from := today.AddDate(0, 0, -maxBusinessDayShift)
+		for _, ev := range expandBillingEvents(subs, shareMap, from, today, today, s.holidays) {
+			collect := ev.Amount - ev.PersonalAmount
+			if _, ok := shareMap[ev.Subscription.ID.String()]; !ok || collect <= 0 || !ev.AdjustedDate.Equal(today) {
+				continue
+			}
+			date := ev.AdjustedDate.Format("2006-01-02")
+			s.inbox.Record(ev.Subscription.UserID, InboxEntry{
+				Type:  models.NotificationTypeShareSettlement,
+				Title: fmt.Sprintf("%s 공유 정산 요청", ev.Subscription.ServiceName),
+				Body: fmt.Sprintf("%s 구독료 %s원이 %s에 결제되었습니다. 공유 멤버에게 %s원을 정산받으세요.",
+					ev.Subscription.ServiceName, formatWon(ev.Amount), date, formatWon(collect)),
+				SubscriptionID: &ev.Subscription.ID,
+				DedupKey:       fmt.Sprintf("settlement:%s:%s", ev.Subscription.ID, date),
+			})
+		}
+	}
+}
+
+// deliver records and sends a single notification. It returns true when the
+// notification was sent during this call.
+func (s *NotificationScheduler) deliver(ctx context.Context, rule *models.NotificationRule, p pendingNotification) bool {
+	delivery, err := s.deliveryRepo.FindByDedupKey(p.dedupKey)
+	switch {
+	case err == nil:
+		if delivery.Status == models.NotificationDeliverySent || delivery.Attempts >= s.cfg.MaxAttempts {
+			return false
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		delivery = &models.NotificationDelivery{
+			RuleID:         rule.ID,
+			UserID:         rule.UserID,
+			SubscriptionID: &p.subscription.ID,
+			DedupKey:       p.dedupKey,
+			Channel:        rule.Channel,
+			Subject:        p.subject,
+			Body:           p.body,
+			Status:         models.NotificationDeliveryPending,
+		}
+		if createErr := s.deliveryRepo.Create(delivery); createErr != nil {
+			slog.Error("알림 발송 기록 생성 실패", "dedupKey", p.dedupKey, "error", createErr)
+			return false
+		}
+	default:
+		slog.Error("알림 발송 기록 조회 실패", "dedupKey", p.dedupKey, "error", err)
+		return false
+	}
+
+	n := &Notification{
+		UserID:         rule.UserID.String(),
+		SubscriptionID: p.subscription.ID.String(),
+		Event:          rule.Type,
+		Subject:        p.subject,
+		Body:           p.body,
+	}
+
+	sendErr := s.resolveTarget(rule, n)
+	notifier, ok := s.notifiers[rule.Channel]
+	if sendErr == nil && !ok {
+		sendErr = fmt.Errorf("notification channel %q is not configured", rule.Channel)
+	}
+	delivery.Target = n.Target
+
+	if sendErr != nil {
+		// Configuration problems will not resolve by retrying.
+		delivery.Attempts = s.cfg.MaxAttempts
+	} else {
+		sendErr = s.sendWithRetry(ctx, notifier, n, delivery)
+	}
+
+	if sendErr != nil {
+		msg := sendErr.Error()
+		delivery.Status = models.NotificationDeliveryFailed
+		delivery.LastError = &msg
+		slog.Warn("알림 발송 실패", "dedupKey", p.dedupKey, "attempts", delivery.Attempts, "error", sendErr)
+	} else {
+		sentAt := s.clock.now()
+		delivery.Status = models.NotificationDeliverySent
+		delivery.LastError = nil
+		delivery.SentAt = &sentAt
+	}
+
+	if err := s.deliveryRepo.Update(delivery); err != nil {
+		slog.Error("알림 발송 기록 갱신 실패", "dedupKey", p.dedupKey, "error", err)
+	}
+	return sendErr == nil
+}
+
+// sendWithRetry sends n until it succeeds or the delivery runs out of attempts,
+// waiting RetryBackoff × attempt between tries.
+func (s *NotificationScheduler) sendWithRetry(ctx context.Context, notifier Notifier, n *Notification, delivery *models.NotificationDelivery) error {
+	var err error
+	for delivery.Attempts < s.cfg.MaxAttempts {
+		delivery.Attempts++
+		if err = notifier.Send(ctx, n); err == nil {
+			return nil
+		}
+		if delivery.Attempts >= s.cfg.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.cfg.RetryBackoff * time.Duration(delivery.Attempts)):
+		}
+	}
+	return err
+}
+
+// resolveTarget fills in the delivery address for the rule's channel.
+func (s *NotificationScheduler) resolveTarget(rule *models.NotificationRule, n *Notification) error {
+	target, err := resolveChannelTarget(s.userRepo, rule.UserID.String(), rule.Channel, rule.Target)
+	if err != nil {
+		return err
+	}
+	n.Target = target
+	return nil
+}
+
+// resolveChannelTarget returns the delivery address for a channel.
+// Email without an explicit target falls back to the user's address.
+func resolveChannelTarget(userRepo repositories.UserRepository, userID string, channel models.NotificationChannel, target *string) (string, error) {
+	if target != nil && *target != "" {
+		return *target, nil
+	}
+
+	switch channel {
+	case models.NotificationChannelEmail:
+		user, err := userRepo.FindByID(userID)
+		if err != nil {
+			return "", fmt.Errorf("find recipient: %w", err)
+		}
+		if user.Email == nil || *user.Email == "" {
+			return "", fmt.Errorf("user has no email address")
+		}
+		return *user.Email, nil
+	case models.NotificationChannelWebhook:
+		return "", fmt.Errorf("webhook target url is missing")
+	}
+	return "", nil
+}
+
+// formatWon formats an amount with thousands separators, e.g. 17000 → "17,000".
+func formatWon(amount int) string {
+	s := strconv.Itoa(amount)
+	neg := false
+	if amount < 0 {
+		neg = true
+		s = s[1:]
+	}
+	out := make([]byte, 0, len(s)+len(s)/3)
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, s[i])
+	}
+	if neg {
+		return "-" + string(out)
+	}
+	return string(out)
+}
+
+// dDayLabel returns "D-day" or "D-n" for a date relative to today.
+func dDayLabel(today, date time.Time) string {
+	days := int(date.Sub(today).Hours() / 24)
+	if days <= 0 {
+		return "D-day"
+	}
+	return fmt.Sprintf("D-%d", days)
+}
+
+// billingCycleLabel returns the Korean label of a billing cycle.
+func billingCycleLabel(cycle models.BillingCycle) string {
+	switch cycle {
+	case models.BillingCycleWeekly:
+		return "주간"
+	case models.BillingCycleYearly:
+		return "연간"
+	default:
+		return "월간"
+	}
+}
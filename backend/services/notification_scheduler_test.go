@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock repositories
+// ---------------------------------------------------------------------------
+
+type mockNotificationRuleRepo struct {
+	rules map[string]*models.NotificationRule
+}
+
+func newMockNotificationRuleRepo() *mockNotificationRuleRepo {
+	return &mockNotificationRuleRepo{rules: make(map[string]*models.NotificationRule)}
+}
+
+func (m *mockNotificationRuleRepo) FindByID(id string) (*models.NotificationRule, error) {
+	rule, ok := m.rules[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *rule
+	return &copied, nil
+}
+
+func (m *mockNotificationRuleRepo) FindByUserID(userID string) ([]*models.NotificationRule, error) {
+	var result []*models.NotificationRule
+	for _, rule := range m.rules {
+		if rule.UserID.String() == userID {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockNotificationRuleRepo) FindEnabled() ([]*models.NotificationRule, error) {
+	var result []*models.NotificationRule
+	for _, rule := range m.rules {
+		if rule.Enabled {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockNotificationRuleRepo) Create(rule *models.NotificationRule) error {
+	if rule.ID == uuid.Nil {
+		rule.ID = uuid.New()
+	}
+	m.rules[rule.ID.String()] = rule
+	return nil
+}
+
+func (m *mockNotificationRuleRepo) Update(rule *models.NotificationRule) error {
+	m.rules[rule.ID.String()] = rule
+	return nil
+}
+
+func (m *mockNotificationRuleRepo) Delete(id string) error {
+	delete(m.rules, id)
+	return nil
+}
+
+type mockNotificationDeliveryRepo struct {
+	deliveries map[string]*models.NotificationDelivery // key: dedup key
+}
+
+func newMockNotificationDeliveryRepo() *mockNotificationDeliveryRepo {
+	return &mockNotificationDeliveryRepo{deliveries: make(map[string]*models.NotificationDelivery)}
+}
+
+func (m *mockNotificationDeliveryRepo) FindByDedupKey(key string) (*models.NotificationDelivery, error) {
+	d, ok := m.deliveries[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return d, nil
+}
+
+func (m *mockNotificationDeliveryRepo) FindByUserID(userID string, page, perPage int) ([]*models.NotificationDelivery, int64, error) {
+	var result []*models.NotificationDelivery
+	for _, d := range m.deliveries {
+		if d.UserID.String() == userID {
+			result = append(result, d)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (m *mockNotificationDeliveryRepo) Create(d *models.NotificationDelivery) error {
+	if _, exists := m.deliveries[d.DedupKey]; exists {
+		return errors.New("duplicate dedup key")
+	}
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	m.deliveries[d.DedupKey] = d
+	return nil
+}
+
+func (m *mockNotificationDeliveryRepo) Update(d *models.NotificationDelivery) error {
+	m.deliveries[d.DedupKey] = d
+	return nil
+}
+
+type mockPriceChangeRepo struct {
+	changes []*models.PriceChange
+}
+
+func (m *mockPriceChangeRepo) Create(change *models.PriceChange) error {
+	if change.ID == uuid.Nil {
+		change.ID = uuid.New()
+	}
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = time.Now()
+	}
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *mockPriceChangeRepo) FindBySubscriptionID(subID string) ([]*models.PriceChange, error) {
+	var result []*models.PriceChange
+	for _, c := range m.changes {
+		if c.SubscriptionID.String() == subID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPriceChangeRepo) FindBySubscriptionIDs(subIDs []string) ([]*models.PriceChange, error) {
+	var result []*models.PriceChange
+	for _, c := range m.changes {
+		for _, id := range subIDs {
+			if c.SubscriptionID.String() == id {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPriceChangeRepo) FindByUserIDSince(userID string, since time.Time) ([]*models.PriceChange, error) {
+	var result []*models.PriceChange
+	for _, c := range m.changes {
+		if c.UserID.String() == userID && !c.CreatedAt.Before(since) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// failingNotifier fails the first `failures` sends and then succeeds.
+type failingNotifier struct {
+	failures int
+	calls    int
+}
+
+func (f *failingNotifier) Send(ctx context.Context, n *Notification) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("temporary failure")
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+type schedulerFixture struct {
+	scheduler  *NotificationScheduler
+	rules      *mockNotificationRuleRepo
+	deliveries *mockNotificationDeliveryRepo
+	subs       *mockSubscriptionRepo
+	prices     *mockPriceChangeRepo
+	users      *mockUserRepo
+	shares     *mockShareRepoForCalendar
+	inboxRepo  *mockNotificationRepo
+	inbox      *InboxService
+	log        *LogNotifier
+	userID     uuid.UUID
+}
+
+// newSchedulerFixture builds a scheduler whose clock reads 2026-03-10 09:00 in Seoul.
+func newSchedulerFixture(t *testing.T, notifiers map[models.NotificationChannel]Notifier) *schedulerFixture {
+	t.Helper()
+	f := &schedulerFixture{
+		rules:      newMockNotificationRuleRepo(),
+		deliveries: newMockNotificationDeliveryRepo(),
+		subs:       newMockRepo(),
+		prices:     &mockPriceChangeRepo{},
+		users:      newMockUserRepo(),
+		shares:     newMockShareRepoForCalendar(),
+		inboxRepo:  newMockNotificationRepo(),
+		log:        NewLogNotifier(),
+		userID:     uuid.New(),
+	}
+	f.inbox = NewInboxService(f.inboxRepo, nil)
+	email := "user@example.com"
+	f.users.users[f.userID.String()] = &models.User{ID: f.userID, Email: &email}
+
+	if notifiers == nil {
+		notifiers = map[models.NotificationChannel]Notifier{models.NotificationChannelLog: f.log}
+	}
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)), f.users)
+	f.scheduler = NewNotificationScheduler(f.rules, f.deliveries, f.subs, f.prices, f.users, f.shares,
+		notifiers, nil, clock, f.inbox, config.NotificationConfig{MaxAttempts: 3})
+	return f
+}
+
+func (f *schedulerFixture) addRule(ruleType models.NotificationRuleType, channel models.NotificationChannel, daysBefore int) *models.NotificationRule {
+	rule := &models.NotificationRule{
+		ID:         uuid.New(),
+		UserID:     f.userID,
+		Type:       ruleType,
+		DaysBefore: daysBefore,
+		Channel:    channel,
+		Enabled:    true,
+		CreatedAt:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	f.rules.rules[rule.ID.String()] = rule
+	return rule
+}
+
+func (f *schedulerFixture) addSub(name string, amount int, next time.Time) *models.Subscription {
+	sub := f.subs.seedSubscription(f.userID, name, amount, models.BillingCycleMonthly)
+	sub.NextBillingDate = next
+	sub.StartDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return sub
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestNotificationScheduler_BillingUpcoming(t *testing.T) {
+	f := newSchedulerFixture(t, nil)
+	f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelLog, 3)
+	f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+	f.addSub("Spotify", 10900, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 1)
+
+	msgs := f.log.Sent()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(msgs))
+	}
+	assertEqual(t, msgs[0].Event, models.NotificationRuleBillingUpcoming)
+	if !strings.Contains(msgs[0].Body, "17,000원") || !strings.Contains(msgs[0].Body, "2026-03-12(D-2)") {
+		t.Errorf("unexpected body: %s", msgs[0].Body)
+	}
+
+	// A second run must not deliver the same event again.
+	sent, err = f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 0)
+	assertEqual(t, len(f.log.Sent()), 1)
+
+	for _, d := range f.deliveries.deliveries {
+		assertEqual(t, d.Status, models.NotificationDeliverySent)
+		assertEqual(t, d.Attempts, 1)
+	}
+}
+
+func TestNotificationScheduler_SubscriptionScopedRule(t *testing.T) {
+	f := newSchedulerFixture(t, nil)
+	rule := f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelLog, 7)
+	f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+	spotify := f.addSub("Spotify", 10900, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC))
+	rule.SubscriptionID = &spotify.ID
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 1)
+	assertEqual(t, f.log.Sent()[0].SubscriptionID, spotify.ID.String())
+}
+
+func TestNotificationScheduler_TrialEnding(t *testing.T) {
+	f := newSchedulerFixture(t, nil)
+	f.addRule(models.NotificationRuleTrialEnding, models.NotificationChannelLog, 1)
+	tomorrow := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)
+	trial := f.addSub("Disney+", 9900, time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC))
+	trial.TrialEndDate = &tomorrow
+	later := f.addSub("Wavve", 7900, time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC))
+	later.TrialEndDate = &nextWeek
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 1)
+	if !strings.Contains(f.log.Sent()[0].Body, "Disney+ 무료 체험이 2026-03-11(D-1)에 종료됩니다") {
+		t.Errorf("unexpected body: %s", f.log.Sent()[0].Body)
+	}
+}
+
+func TestNotificationScheduler_PriceChanged(t *testing.T) {
+	f := newSchedulerFixture(t, nil)
+	rule := f.addRule(models.NotificationRulePriceChanged, models.NotificationChannelLog, 0)
+	sub := f.addSub("YouTube Premium", 14900, time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC))
+
+	// Changes recorded before the rule existed are ignored.
+	_ = f.prices.Create(&models.PriceChange{
+		SubscriptionID: sub.ID, UserID: f.userID, OldAmount: 10450, NewAmount: 14900,
+		OldBillingCycle: models.BillingCycleMonthly, NewBillingCycle: models.BillingCycleMonthly,
+		CreatedAt: rule.CreatedAt.Add(-time.Hour),
+	})
+	_ = f.prices.Create(&models.PriceChange{
+		SubscriptionID: sub.ID, UserID: f.userID, OldAmount: 14900, NewAmount: 149000,
+		OldBillingCycle: models.BillingCycleMonthly, NewBillingCycle: models.BillingCycleYearly,
+		CreatedAt: rule.CreatedAt.Add(time.Hour),
+	})
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 1)
+	assertEqual(t, f.log.Sent()[0].Body, "YouTube Premium 가격이 14,900원(월간)에서 149,000원(연간)으로 변경되었습니다.")
+}
+
+func TestNotificationScheduler_RetriesThenSucceeds(t *testing.T) {
+	flaky := &failingNotifier{failures: 2}
+	f := newSchedulerFixture(t, map[models.NotificationChannel]Notifier{models.NotificationChannelWebhook: flaky})
+	rule := f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelWebhook, 3)
+	rule.Target = strPtr("https://hooks.example.com/subkeep")
+	f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 1)
+	assertEqual(t, flaky.calls, 3)
+
+	for _, d := range f.deliveries.deliveries {
+		assertEqual(t, d.Status, models.NotificationDeliverySent)
+		assertEqual(t, d.Attempts, 3)
+		assertEqual(t, d.Target, "https://hooks.example.com/subkeep")
+	}
+}
+
+func TestNotificationScheduler_GivesUpAfterMaxAttempts(t *testing.T) {
+	broken := &failingNotifier{failures: 100}
+	f := newSchedulerFixture(t, map[models.NotificationChannel]Notifier{models.NotificationChannelEmail: broken})
+	f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelEmail, 3)
+	f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 0)
+	assertEqual(t, broken.calls, 3)
+
+	// Exhausted deliveries are not retried on later runs.
+	_, _ = f.scheduler.RunOnce(context.Background())
+	assertEqual(t, broken.calls, 3)
+
+	for _, d := range f.deliveries.deliveries {
+		assertEqual(t, d.Status, models.NotificationDeliveryFailed)
+		assertEqual(t, d.Target, "user@example.com")
+		assertNotNil(t, d.LastError)
+	}
+}
+
+func TestNotificationScheduler_UnconfiguredChannel(t *testing.T) {
+	f := newSchedulerFixture(t, nil)
+	f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelEmail, 3)
+	f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+
+	sent, err := f.scheduler.RunOnce(context.Background())
+	assertNil(t, err)
+	assertEqual(t, sent, 0)
+	assertEqual(t, len(f.deliveries.deliveries), 1)
+	for _, d := range f.deliveries.deliveries {
+		assertEqual(t, d.Status, models.NotificationDeliveryFailed)
+		if d.LastError == nil || !strings.Contains(*d.LastError, "not configured") {
+			t.Errorf("expected not configured error, got %v", d.LastError)
+		}
+	}
+}
+
+func TestNotificationScheduler_Inbox(t *testing.T) {
+	t.Run("billing reminders are recorded once across rules", func(t *testing.T) {
+		f := newSchedulerFixture(t, nil)
+		f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelLog, 3)
+		f.addRule(models.NotificationRuleBillingUpcoming, models.NotificationChannelLog, 7)
+		f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+
+		_, err := f.scheduler.RunOnce(context.Background())
+		assertNil(t, err)
+		_, err = f.scheduler.RunOnce(context.Background())
+		assertNil(t, err)
+
+		entries := f.inboxRepo.byType(f.userID, models.NotificationTypeBillingReminder)
+		assertEqual(t, len(entries), 1)
+		assertEqual(t, entries[0].Title, "Netflix 결제 예정")
+	})
+
+	t.Run("billing reminders do not depend on rules", func(t *testing.T) {
+		f := newSchedulerFixture(t, nil)
+		f.addSub("Netflix", 17000, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC))
+		f.addSub("Spotify", 10900, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+		delivered, err := f.scheduler.RunOnce(context.Background())
+		assertNil(t, err)
+		assertEqual(t, delivered, 0)
+
+		entries := f.inboxRepo.byType(f.userID, models.NotificationTypeBillingReminder)
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 billing reminder, got %d", len(entries))
+		}
+		assertEqual(t, entries[0].Title, "Netflix 결제 예정")
+	})
+
+	t.Run("shared charges billed today produce a settlement request", func(t *testing.T) {
+		f := newSchedulerFixture(t, nil)
+		shared := f.addSub("YouTube Premium", 14900, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+		f.addSub("Netflix", 17000, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+		f.shares.shares["s1"] = &models.SubscriptionShare{
+			SubscriptionID:       shared.ID,
+			SplitType:            models.SplitTypeEqual,
+			TotalMembersSnapshot: 4,
+			Subscription:         *shared,
+		}
+
+		_, err := f.scheduler.RunOnce(context.Background())
+		assertNil(t, err)
+		_, err = f.scheduler.RunOnce(context.Background())
+		assertNil(t, err)
+
+		entries := f.inboxRepo.byType(f.userID, models.NotificationTypeShareSettlement)
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 settlement entry, got %d", len(entries))
+		}
+		assertEqual(t, *entries[0].SubscriptionID, shared.ID)
+		if !strings.Contains(entries[0].Body, "11,175원을 정산받으세요") {
+			t.Errorf("unexpected body: %s", entries[0].Body)
+		}
+	})
+}
+
+func TestFormatWon(t *testing.T) {
+	assertEqual(t, formatWon(0), "0")
+	assertEqual(t, formatWon(900), "900")
+	assertEqual(t, formatWon(17000), "17,000")
+	assertEqual(t, formatWon(1234567), "1,234,567")
+	assertEqual(t, formatWon(-45000), "-45,000")
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+// webhookTimeout bounds a single webhook delivery attempt.
+const webhookTimeout = 10 * time.Second
+
+// Notification is a rendered message ready to be delivered over a channel.
+type Notification struct {
+	UserID         string                      `json:"userId"`
+	SubscriptionID string                      `json:"subscriptionId,omitempty"`
+	Event          models.NotificationRuleType `json:"event"`
+	Target         string                      `json:"-"` // email address or webhook URL
+	Subject        string                      `json:"subject"`
+	Body           string                      `json:"body"`
+	HTMLBody       string                      `json:"html,omitempty"` // optional HTML alternative of Body
+}
+
+// Notifier delivers notifications over a single channel.
+type Notifier interface {
+	Send(ctx context.Context, n *Notification) error
+}
+
+// LogNotifier writes notifications to the application log and keeps them in
+// memory. It is used for the "log" channel and as a test double.
+type LogNotifier struct {
+	mu   sync.Mutex
+	sent []Notification
+}
+
+// NewLogNotifier creates a new LogNotifier.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Send logs the notification and records it.
+func (l *LogNotifier) Send(ctx context.Context, n *Notification) error {
+	slog.Info("알림 발송", "userID", n.UserID, "event", n.Event, "subject", n.Subject)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sent = append(l.sent, *n)
+	return nil
+}
+
+// Sent returns a copy of every notification sent so far.
+func (l *LogNotifier) Sent() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]Notification, len(l.sent))
+	copy(out, l.sent)
+	return out
+}
+
+// sendMailFunc matches smtp.SendMail so tests can capture outgoing mail.
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// SMTPNotifier delivers notifications as plain-text email.
+type SMTPNotifier struct {
+	cfg      config.SMTPConfig
+	sendMail sendMailFunc
+}
+
+// NewSMTPNotifier creates a new SMTPNotifier.
+func NewSMTPNotifier(cfg config.SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg, sendMail: smtp.SendMail}
+}
+
+// Send emails the notification to n.Target.
+func (s *SMTPNotifier) Send(ctx context.Context, n *Notification) error {
+	if n.Target == "" {
+		return fmt.Errorf("send email: no recipient address")
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	addr := s.cfg.Host + ":" + s.cfg.Port
+	if err := s.sendMail(addr, auth, envelopeAddress(s.cfg.From), []string{n.Target}, s.buildMessage(n)); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// buildMessage renders an RFC 5322 message with a UTF-8 encoded subject.
+// Notifications with an HTML body are sent as multipart/alternative.
+func (s *SMTPNotifier) buildMessage(n *Notification) []byte {
+	var b bytes.Buffer
+	b.WriteString("From: " + s.cfg.From + "\r\n")
+	b.WriteString("To: " + n.Target + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", n.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if n.HTMLBody == "" {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(crlf(n.Body))
+		b.WriteString("\r\n")
+		return b.Bytes()
+	}
+
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+	b.WriteString("Content-Type: multipart/alternative; boundary=" + mw.Boundary() + "\r\n")
+	b.WriteString("\r\n")
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", n.Body},
+		{"text/html; charset=UTF-8", n.HTMLBody},
+	} {
+		w, _ := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		_, _ = w.Write([]byte(crlf(part.body) + "\r\n"))
+	}
+	_ = mw.Close()
+	b.Write(parts.Bytes())
+	return b.Bytes()
+}
+
+// crlf converts bare line feeds to CRLF line endings.
+func crlf(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
+
+// envelopeAddress extracts the bare address from a "Name <addr>" header value.
+func envelopeAddress(from string) string {
+	if start := strings.LastIndex(from, "<"); start >= 0 {
+		if end := strings.LastIndex(from, ">"); end > start {
+			return from[start+1 : end]
+		}
+	}
+	return from
+}
+
+// WebhookNotifier delivers notifications as a JSON POST to n.Target.
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier. client may be nil, in which
+// case a client with a 10 second timeout that refuses internal addresses is
+// used.
+func NewWebhookNotifier(client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = newGuardedHTTPClient(webhookTimeout)
+	}
+	return &WebhookNotifier{client: client}
+}
+
+// Send posts the notification as JSON. Any non-2xx response is an error.
+func (w *WebhookNotifier) Send(ctx context.Context, n *Notification) error {
+	if n.Target == "" {
+		return fmt.Errorf("send webhook: no target url")
+	}
+
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.Target, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SubKeep-Notifier/1.0")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("send webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+func testNotification(target string) *Notification {
+	return &Notification{
+		UserID:         "user-1",
+		SubscriptionID: "sub-1",
+		Event:          models.NotificationRuleBillingUpcoming,
+		Target:         target,
+		Subject:        "[SubKeep] Netflix 결제 예정 알림",
+		Body:           "Netflix 구독료 17,000원이 결제될 예정입니다.",
+	}
+}
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertEqual(t, r.Method, http.MethodPost)
+		assertEqual(t, r.Header.Get("Content-Type"), "application/json")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.Client()).Send(context.Background(), testNotification(server.URL))
+	assertNil(t, err)
+	assertEqual(t, received["event"], "billing_upcoming")
+	assertEqual(t, received["subscriptionId"], "sub-1")
+	if _, leaked := received["Target"]; leaked {
+		t.Error("target URL must not be part of the payload")
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.Client()).Send(context.Background(), testNotification(server.URL))
+	assertError(t, err)
+	if !strings.Contains(err.Error(), "502") {
+		t.Errorf("expected status code in error, got %v", err)
+	}
+}
+
+func TestWebhookNotifier_DefaultClientRefusesLoopback(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(nil).Send(context.Background(), testNotification(server.URL))
+	assertError(t, err)
+	assertEqual(t, hit, false)
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+
+	n := NewSMTPNotifier(config.SMTPConfig{
+		Host: "smtp.example.com",
+		Port: "587",
+		From: "SubKeep <no-reply@subkeep.app>",
+	})
+	n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		return nil
+	}
+
+	err := n.Send(context.Background(), testNotification("user@example.com"))
+	assertNil(t, err)
+	assertEqual(t, gotAddr, "smtp.example.com:587")
+	assertEqual(t, gotFrom, "no-reply@subkeep.app")
+	assertEqual(t, gotTo, []string{"user@example.com"})
+
+	msg := string(gotMsg)
+	if !strings.Contains(msg, "Subject: =?UTF-8?b?") {
+		t.Errorf("expected encoded subject, got %q", msg)
+	}
+	if !strings.Contains(msg, "\r\n\r\nNetflix 구독료 17,000원이 결제될 예정입니다.\r\n") {
+		t.Errorf("expected body after headers, got %q", msg)
+	}
+}
+
+func TestSMTPNotifier_RequiresRecipient(t *testing.T) {
+	n := NewSMTPNotifier(config.SMTPConfig{Host: "smtp.example.com", Port: "25"})
+	assertError(t, n.Send(context.Background(), testNotification("")))
+}
+
+func TestLogNotifier_RecordsSent(t *testing.T) {
+	n := NewLogNotifier()
+	assertNil(t, n.Send(context.Background(), testNotification("")))
+	sent := n.Sent()
+	assertEqual(t, len(sent), 1)
+	assertEqual(t, sent[0].Subject, "[SubKeep] Netflix 결제 예정 알림")
+}
+
+func TestSMTPNotifier_SendsHTMLAlternative(t *testing.T) {
+	var gotMsg []byte
+	n := NewSMTPNotifier(config.SMTPConfig{Host: "smtp.example.com", Port: "587", From: "no-reply@subkeep.app"})
+	n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotMsg = msg
+		return nil
+	}
+
+	notification := testNotification("user@example.com")
+	notification.HTMLBody = "<p>Netflix 17,000원</p>"
+	assertNil(t, n.Send(context.Background(), notification))
+
+	msg := string(gotMsg)
+	for _, want := range []string{
+		"Content-Type: multipart/alternative; boundary=",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Type: text/html; charset=UTF-8",
+		"<p>Netflix 17,000원</p>",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected message to contain %q, got %q", want, msg)
+		}
+	}
+	if strings.Index(msg, "text/plain") > strings.Index(msg, "text/html") {
+		t.Error("expected the plain-text part before the HTML part")
+	}
+}
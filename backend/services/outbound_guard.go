@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// outboundResolveTimeout bounds the DNS lookup made when a user-supplied URL
+// is registered.
+const outboundResolveTimeout = 3 * time.Second
+
+// errBlockedAddress is returned when an outbound request would reach an
+// address inside the server's own network.
+var errBlockedAddress = errors.New("destination address is not allowed")
+
+// carrierGradeNAT is the shared address space of RFC 6598, routed like a
+// private range by most providers.
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isBlockedIP reports whether ip is a loopback, private, link-local,
+// unspecified or multicast address, which user-supplied URLs may not reach.
+// This covers cloud metadata services such as 169.254.169.254.
+func isBlockedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		carrierGradeNAT.Contains(ip)
+}
+
+// validateOutboundURL checks that raw is an absolute http(s) URL whose host
+// does not resolve to a blocked address. Hosts that cannot be resolved now
+// are accepted, since the guarded dialer checks the address again on every
+// connection.
+func validateOutboundURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" {
+		return fmt.Errorf("invalid http(s) url")
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if isBlockedIP(ip) {
+			return errBlockedAddress
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outboundResolveTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if isBlockedIP(addr.IP) {
+			return errBlockedAddress
+		}
+	}
+	return nil
+}
+
+// guardedControl rejects connections to blocked addresses. It runs after DNS
+// resolution, on the address actually dialled, so a host re-resolving to an
+// internal address after validation is still refused.
+func guardedControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isBlockedIP(ip) {
+		return errBlockedAddress
+	}
+	return nil
+}
+
+// newGuardedHTTPClient returns a client for user-supplied URLs that refuses
+// to connect to blocked addresses. Proxies from the environment are not used,
+// since the guard would only see the proxy's address.
+func newGuardedHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second, Control: guardedControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
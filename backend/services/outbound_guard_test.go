@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsBlockedIP(t *testing.T) {
+	blocked := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1", "::1", "fe80::1", "fd00::1", "224.0.0.1"}
+	for _, raw := range blocked {
+		if !isBlockedIP(net.ParseIP(raw)) {
+			t.Errorf("expected %s to be blocked", raw)
+		}
+	}
+	allowed := []string{"8.8.8.8", "203.0.113.10", "2001:4860:4860::8888"}
+	for _, raw := range allowed {
+		if isBlockedIP(net.ParseIP(raw)) {
+			t.Errorf("expected %s to be allowed", raw)
+		}
+	}
+}
+
+func TestValidateOutboundURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		blocked bool
+		invalid bool
+	}{
+		{url: "https://8.8.8.8/hook"},
+		{url: "http://127.0.0.1:9000/hook", blocked: true},
+		{url: "http://169.254.169.254/latest/meta-data", blocked: true},
+		{url: "http://[::1]/hook", blocked: true},
+		{url: "http://localhost/hook", blocked: true},
+		{url: "ftp://8.8.8.8/hook", invalid: true},
+		{url: "https:///hook", invalid: true},
+	}
+	for _, tt := range tests {
+		err := validateOutboundURL(tt.url)
+		switch {
+		case tt.blocked:
+			if !errors.Is(err, errBlockedAddress) {
+				t.Errorf("%s: expected blocked address, got %v", tt.url, err)
+			}
+		case tt.invalid:
+			if err == nil || errors.Is(err, errBlockedAddress) {
+				t.Errorf("%s: expected invalid url, got %v", tt.url, err)
+			}
+		default:
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.url, err)
+			}
+		}
+	}
+}
+
+func TestGuardedHTTPClient_RefusesLoopback(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	resp, err := newGuardedHTTPClient(time.Second).Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the guarded client to refuse a loopback server")
+	}
+	if !errors.Is(err, errBlockedAddress) {
+		t.Errorf("expected blocked address error, got %v", err)
+	}
+	assertEqual(t, hit, false)
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/subkeep/backend/utils"
+)
+
+// Comparison periods for period reports.
+const (
+	// ReportComparePrevious compares with the period just before: the same
+	// number of months for whole-month periods, otherwise the same number of days.
+	ReportComparePrevious = "previous"
+	// ReportCompareYear compares with the same period one year earlier.
+	ReportCompareYear = "year"
+)
+
+// Markers for how an item differs from the comparison period.
+const (
+	PeriodDiffNew       = "new"
+	PeriodDiffRemoved   = "removed"
+	PeriodDiffChanged   = "changed"
+	PeriodDiffUnchanged = "unchanged"
+)
+
+// AmountDiff compares an amount with the comparison period. ChangePercent is
+// nil when the comparison amount is zero.
+type AmountDiff struct {
+	Amount         int      `json:"amount"`
+	PreviousAmount int      `json:"previousAmount"`
+	Change         int      `json:"change"`
+	ChangePercent  *float64 `json:"changePercent"`
+}
+
+// PeriodReport compares the spending of a period with a comparison period.
+// Cash amounts are what was charged; amortized amounts spread every plan
+// evenly over the days it was active.
+type PeriodReport struct {
+	From          time.Time                `json:"from"`
+	To            time.Time                `json:"to"`
+	CompareFrom   time.Time                `json:"compareFrom"`
+	CompareTo     time.Time                `json:"compareTo"`
+	Cash          AmountDiff               `json:"cash"`
+	Amortized     AmountDiff               `json:"amortized"`
+	Categories    []PeriodCategoryDiff     `json:"categories"`
+	Subscriptions []PeriodSubscriptionDiff `json:"subscriptions"`
+}
+
+// PeriodCategoryDiff compares a category's spending between the periods.
+// It is new or removed when none of its subscriptions were billed in the
+// comparison period or in the period, and changed when its amounts differ.
+type PeriodCategoryDiff struct {
+	CategoryID   string     `json:"categoryId"`
+	CategoryName string     `json:"categoryName"`
+	Color        string     `json:"color"`
+	Status       string     `json:"status"`
+	Cash         AmountDiff `json:"cash"`
+	Amortized    AmountDiff `json:"amortized"`
+}
+
+// PeriodSubscriptionDiff compares a subscription's spending between the
+// periods. It is new or removed when it was not billed in the comparison
+// period or in the period, and changed when its personal monthly amount on
+// the last billed day differs between them.
+type PeriodSubscriptionDiff struct {
+	SubscriptionID        string     `json:"subscriptionId"`
+	ServiceName           string     `json:"serviceName"`
+	CategoryName          string     `json:"categoryName"`
+	Status                string     `json:"status"`
+	MonthlyAmount         int        `json:"monthlyAmount"`
+	PreviousMonthlyAmount int        `json:"previousMonthlyAmount"`
+	Cash                  AmountDiff `json:"cash"`
+	Amortized             AmountDiff `json:"amortized"`
+}
+
+// GetPeriodReport compares the spending of [from, to] with a comparison
+// period. compareFrom and compareTo set the comparison period explicitly;
+// otherwise it is derived from compare. Reports are cached per day, since
+// periods may run up to today.
+func (s *ReportService) GetPeriodReport(userID string, from, to time.Time, compare string, compareFrom, compareTo *time.Time) (*PeriodReport, error) {
+	from, to = truncateDate(from), truncateDate(to)
+	if appErr := validateReportPeriod(from, to); appErr != nil {
+		return nil, appErr
+	}
+
+	var prevFrom, prevTo time.Time
+	switch {
+	case compareFrom != nil && compareTo != nil:
+		prevFrom, prevTo = truncateDate(*compareFrom), truncateDate(*compareTo)
+		if appErr := validateReportPeriod(prevFrom, prevTo); appErr != nil {
+			return nil, appErr
+		}
+	case compareFrom != nil || compareTo != nil:
+		return nil, utils.ErrBadRequest("compareFrom과 compareTo는 함께 지정해야 합니다")
+	case compare == "" || compare == ReportComparePrevious:
+		prevFrom, prevTo = previousPeriod(from, to)
+	case compare == ReportCompareYear:
+		prevFrom, prevTo = yearAgoPeriod(from, to)
+	default:
+		return nil, utils.ErrBadRequest("compare는 previous, year 중 하나여야 합니다")
+	}
+
+	today := s.clock.Today(userID)
+	key := aggregateKey(userID, "period",
+		from.Format("2006-01-02"), to.Format("2006-01-02"),
+		prevFrom.Format("2006-01-02"), prevTo.Format("2006-01-02"),
+		today.Format("2006-01-02"))
+	return cachedAggregate(s.cache, key, func() (*PeriodReport, error) {
+		report, err := s.buildPeriodReport(userID, from, to, prevFrom, prevTo, today)
+		if err != nil {
+			slog.Error("기간 리포트 생성 실패", "userID", userID, "error", err)
+			return nil, utils.ErrInternal("기간 리포트를 조회할 수 없습니다")
+		}
+		return report, nil
+	})
+}
+
+// validateReportPeriod checks that [from, to] is ordered and not too long.
+func validateReportPeriod(from, to time.Time) *utils.AppError {
+	if to.Before(from) {
+		return utils.ErrBadRequest("to는 from 이후 날짜여야 합니다")
+	}
+	if !to.Before(from.AddDate(0, maxRangeMonths, 0)) {
+		return utils.ErrBadRequest(fmt.Sprintf("조회 기간은 최대 %d개월입니다", maxRangeMonths))
+	}
+	return nil
+}
+
+// wholeMonths returns the number of months [from, to] spans when it starts on
+// the first and ends on the last day of a month, or 0 otherwise.
+func wholeMonths(from, to time.Time) int {
+	if from.Day() != 1 || to.AddDate(0, 0, 1).Day() != 1 {
+		return 0
+	}
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+}
+
+// previousPeriod returns the period of the same length ending the day before from.
+func previousPeriod(from, to time.Time) (time.Time, time.Time) {
+	if months := wholeMonths(from, to); months > 0 {
+		return from.AddDate(0, -months, 0), from.AddDate(0, 0, -1)
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	return from.AddDate(0, 0, -days), from.AddDate(0, 0, -1)
+}
+
+// yearAgoPeriod returns the same period one year earlier. Whole-month periods
+// keep ending on the last day of the month, e.g. in leap years.
+func yearAgoPeriod(from, to time.Time) (time.Time, time.Time) {
+	if wholeMonths(from, to) > 0 {
+		return from.AddDate(-1, 0, 0), monthStart(to).AddDate(-1, 1, -1)
+	}
+	return from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+}
+
+// buildPeriodReport compares the subscription histories over the two periods.
+func (s *ReportService) buildPeriodReport(userID string, from, to, prevFrom, prevTo, today time.Time) (*PeriodReport, error) {
+	since := from
+	if prevFrom.Before(since) {
+		since = prevFrom
+	}
+	histories, err := s.loadHistories(userID, buildShareMap(s.shareRepo, userID), since)
+	if err != nil {
+		return nil, err
+	}
+
+	type categoryTotals struct {
+		diff              PeriodCategoryDiff
+		cash, prevCash    int
+		amort, prevAmort  int
+		current, previous bool
+	}
+	categories := make(map[string]*categoryTotals)
+	subscriptions := make([]PeriodSubscriptionDiff, 0)
+	cash, prevCash, amort, prevAmort := 0, 0, 0, 0
+
+	for _, h := range histories {
+		last, current := h.lastBilledOn(from, to)
+		prevLast, previous := h.lastBilledOn(prevFrom, prevTo)
+		if !current && !previous {
+			continue
+		}
+
+		row := PeriodSubscriptionDiff{
+			SubscriptionID: h.sub.ID.String(),
+			ServiceName:    h.sub.ServiceName,
+			CategoryName:   "미분류",
+		}
+		if current {
+			row.MonthlyAmount = h.personalMonthlyOn(last)
+		}
+		if previous {
+			row.PreviousMonthlyAmount = h.personalMonthlyOn(prevLast)
+		}
+		switch {
+		case !previous:
+			row.Status = PeriodDiffNew
+		case !current:
+			row.Status = PeriodDiffRemoved
+		case row.MonthlyAmount != row.PreviousMonthlyAmount:
+			row.Status = PeriodDiffChanged
+		default:
+			row.Status = PeriodDiffUnchanged
+		}
+
+		subCash, subPrevCash := sumCharges(h.charges(from, to, today)), sumCharges(h.charges(prevFrom, prevTo, today))
+		subAmort := int(math.Round(h.amortizedCost(from, to)))
+		subPrevAmort := int(math.Round(h.amortizedCost(prevFrom, prevTo)))
+		row.Cash = newAmountDiff(subCash, subPrevCash)
+		row.Amortized = newAmountDiff(subAmort, subPrevAmort)
+		cash, prevCash = cash+subCash, prevCash+subPrevCash
+		amort, prevAmort = amort+subAmort, prevAmort+subPrevAmort
+
+		categoryID, color := "", "#B2BEC3"
+		if h.sub.Category != nil {
+			categoryID, row.CategoryName = h.sub.Category.ID.String(), h.sub.Category.Name
+			if h.sub.Category.Color != nil {
+				color = *h.sub.Category.Color
+			}
+		}
+		totals, ok := categories[categoryID]
+		if !ok {
+			totals = &categoryTotals{diff: PeriodCategoryDiff{CategoryID: categoryID, CategoryName: row.CategoryName, Color: color}}
+			categories[categoryID] = totals
+		}
+		totals.cash += subCash
+		totals.prevCash += subPrevCash
+		totals.amort += subAmort
+		totals.prevAmort += subPrevAmort
+		totals.current = totals.current || current
+		totals.previous = totals.previous || previous
+
+		subscriptions = append(subscriptions, row)
+	}
+
+	categoryDiffs := make([]PeriodCategoryDiff, 0, len(categories))
+	for _, totals := range categories {
+		diff := totals.diff
+		diff.Cash = newAmountDiff(totals.cash, totals.prevCash)
+		diff.Amortized = newAmountDiff(totals.amort, totals.prevAmort)
+		switch {
+		case !totals.previous:
+			diff.Status = PeriodDiffNew
+		case !totals.current:
+			diff.Status = PeriodDiffRemoved
+		case diff.Cash.Change != 0 || diff.Amortized.Change != 0:
+			diff.Status = PeriodDiffChanged
+		default:
+			diff.Status = PeriodDiffUnchanged
+		}
+		categoryDiffs = append(categoryDiffs, diff)
+	}
+
+	// Largest movements first.
+	sort.Slice(categoryDiffs, func(i, j int) bool {
+		a, b := abs(categoryDiffs[i].Amortized.Change), abs(categoryDiffs[j].Amortized.Change)
+		if a != b {
+			return a > b
+		}
+		return categoryDiffs[i].CategoryName < categoryDiffs[j].CategoryName
+	})
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		a, b := abs(subscriptions[i].Amortized.Change), abs(subscriptions[j].Amortized.Change)
+		if a != b {
+			return a > b
+		}
+		return subscriptions[i].ServiceName < subscriptions[j].ServiceName
+	})
+
+	return &PeriodReport{
+		From:          from,
+		To:            to,
+		CompareFrom:   prevFrom,
+		CompareTo:     prevTo,
+		Cash:          newAmountDiff(cash, prevCash),
+		Amortized:     newAmountDiff(amort, prevAmort),
+		Categories:    categoryDiffs,
+		Subscriptions: subscriptions,
+	}, nil
+}
+
+// newAmountDiff compares amount with previous, rounding the percentage to
+// one decimal place.
+func newAmountDiff(amount, previous int) AmountDiff {
+	diff := AmountDiff{Amount: amount, PreviousAmount: previous, Change: amount - previous}
+	if previous != 0 {
+		percent := math.Round(float64(diff.Change)/float64(previous)*1000) / 10
+		diff.ChangePercent = &percent
+	}
+	return diff
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/utils"
+)
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestGetPeriodReport_MonthOverMonth(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	prices := &mockPriceChangeRepo{}
+	statuses := &mockStatusChangeRepo{}
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock, PriceRepo: prices, StatusRepo: statuses})
+	userID := uuid.New()
+
+	video := rptMakeCategory("엔터테인먼트", "#FF6B6B")
+	cloud := rptMakeCategory("클라우드", "#45B7D1")
+
+	netflix := seedReportSub(repo, userID, "Netflix", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, video)
+	netflix.StartDate = ymd(2025, 1, 10)
+	netflix.NextBillingDate = ymd(2026, 7, 10)
+
+	raised := seedReportSub(repo, userID, "Raised", 13000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, video)
+	raised.StartDate = ymd(2025, 1, 1)
+	raised.NextBillingDate = ymd(2026, 7, 1)
+	_ = prices.Create(&models.PriceChange{
+		SubscriptionID: raised.ID, UserID: userID,
+		OldAmount: 10000, NewAmount: 13000,
+		OldBillingCycle: models.BillingCycleMonthly, NewBillingCycle: models.BillingCycleMonthly,
+		EffectiveDate: ymd(2026, 5, 1), CreatedAt: ymd(2026, 5, 1),
+	})
+
+	added := seedReportSub(repo, userID, "New", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	added.StartDate = ymd(2026, 5, 1)
+	added.NextBillingDate = ymd(2026, 7, 1)
+
+	gone := seedReportSub(repo, userID, "Gone", 8000, models.BillingCycleMonthly, models.SubscriptionStatusCancelled, nil, cloud)
+	gone.StartDate = ymd(2025, 1, 20)
+	gone.NextBillingDate = ymd(2026, 7, 20)
+	_ = statuses.Create(&models.StatusChange{
+		SubscriptionID: gone.ID, UserID: userID,
+		OldStatus: models.SubscriptionStatusActive, NewStatus: models.SubscriptionStatusCancelled,
+		EffectiveDate: ymd(2026, 5, 1),
+	})
+
+	report, err := svc.GetPeriodReport(userID.String(), ymd(2026, 5, 1), ymd(2026, 5, 31), "", nil, nil)
+	assertNil(t, err)
+
+	assertEqual(t, report.CompareFrom, ymd(2026, 4, 1))
+	assertEqual(t, report.CompareTo, ymd(2026, 4, 30))
+	assertEqual(t, report.Cash, AmountDiff{Amount: 28000, PreviousAmount: 28000, Change: 0, ChangePercent: floatPtr(0)})
+
+	assertEqual(t, report.Subscriptions, []PeriodSubscriptionDiff{
+		{
+			SubscriptionID: gone.ID.String(), ServiceName: "Gone", CategoryName: "클라우드", Status: PeriodDiffRemoved,
+			MonthlyAmount: 0, PreviousMonthlyAmount: 8000,
+			Cash:      AmountDiff{Amount: 0, PreviousAmount: 8000, Change: -8000, ChangePercent: floatPtr(-100)},
+			Amortized: AmountDiff{Amount: 0, PreviousAmount: 8000, Change: -8000, ChangePercent: floatPtr(-100)},
+		},
+		{
+			SubscriptionID: added.ID.String(), ServiceName: "New", CategoryName: "미분류", Status: PeriodDiffNew,
+			MonthlyAmount: 5000, PreviousMonthlyAmount: 0,
+			Cash:      AmountDiff{Amount: 5000, PreviousAmount: 0, Change: 5000},
+			Amortized: AmountDiff{Amount: 5000, PreviousAmount: 0, Change: 5000},
+		},
+		{
+			SubscriptionID: raised.ID.String(), ServiceName: "Raised", CategoryName: "엔터테인먼트", Status: PeriodDiffChanged,
+			MonthlyAmount: 13000, PreviousMonthlyAmount: 10000,
+			Cash:      AmountDiff{Amount: 13000, PreviousAmount: 10000, Change: 3000, ChangePercent: floatPtr(30)},
+			Amortized: AmountDiff{Amount: 13000, PreviousAmount: 10000, Change: 3000, ChangePercent: floatPtr(30)},
+		},
+		{
+			SubscriptionID: netflix.ID.String(), ServiceName: "Netflix", CategoryName: "엔터테인먼트", Status: PeriodDiffUnchanged,
+			MonthlyAmount: 10000, PreviousMonthlyAmount: 10000,
+			Cash:      AmountDiff{Amount: 10000, PreviousAmount: 10000, Change: 0, ChangePercent: floatPtr(0)},
+			Amortized: AmountDiff{Amount: 10000, PreviousAmount: 10000, Change: 0, ChangePercent: floatPtr(0)},
+		},
+	})
+
+	categoryStatuses := make(map[string]string)
+	changes := make(map[string]int)
+	for _, c := range report.Categories {
+		categoryStatuses[c.CategoryName] = c.Status
+		changes[c.CategoryName] = c.Amortized.Change
+	}
+	assertEqual(t, categoryStatuses, map[string]string{"클라우드": PeriodDiffRemoved, "미분류": PeriodDiffNew, "엔터테인먼트": PeriodDiffChanged})
+	assertEqual(t, changes, map[string]int{"클라우드": -8000, "미분류": 5000, "엔터테인먼트": 3000})
+	assertEqual(t, report.Categories[0].CategoryName, "클라우드")
+	assertEqual(t, *report.Categories[2].Amortized.ChangePercent, 15.0)
+}
+
+func TestGetPeriodReport_YearOverYear(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "Yearly", 120000, models.BillingCycleYearly, models.SubscriptionStatusActive, nil, nil)
+	sub.StartDate = ymd(2024, 3, 10)
+	sub.NextBillingDate = ymd(2027, 3, 10)
+
+	report, err := svc.GetPeriodReport(userID.String(), ymd(2026, 1, 1), ymd(2026, 3, 31), ReportCompareYear, nil, nil)
+	assertNil(t, err)
+	assertEqual(t, report.CompareFrom, ymd(2025, 1, 1))
+	assertEqual(t, report.CompareTo, ymd(2025, 3, 31))
+	assertEqual(t, report.Cash, AmountDiff{Amount: 120000, PreviousAmount: 120000, Change: 0, ChangePercent: floatPtr(0)})
+	assertEqual(t, report.Amortized, AmountDiff{Amount: 30000, PreviousAmount: 30000, Change: 0, ChangePercent: floatPtr(0)})
+	assertEqual(t, report.Subscriptions[0].Status, PeriodDiffUnchanged)
+}
+
+func TestGetPeriodReport_ExplicitComparison(t *testing.T) {
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewReportServiceWithDeps(newMockSubRepoForReport(), newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	compareFrom, compareTo := ymd(2025, 7, 1), ymd(2025, 7, 10)
+
+	report, err := svc.GetPeriodReport(uuid.New().String(), ymd(2026, 6, 1), ymd(2026, 6, 10), ReportCompareYear, &compareFrom, &compareTo)
+	assertNil(t, err)
+	assertEqual(t, report.CompareFrom, compareFrom)
+	assertEqual(t, report.CompareTo, compareTo)
+	assertEqual(t, len(report.Subscriptions), 0)
+	assertEqual(t, report.Cash.ChangePercent == nil, true)
+}
+
+func TestGetPeriodReport_InvalidRequests(t *testing.T) {
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewReportServiceWithDeps(newMockSubRepoForReport(), newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	compareFrom := ymd(2025, 1, 1)
+
+	cases := []struct {
+		name        string
+		from, to    time.Time
+		compare     string
+		compareFrom *time.Time
+	}{
+		{"to before from", ymd(2026, 5, 1), ymd(2026, 4, 1), "", nil},
+		{"range too long", ymd(2024, 1, 1), ymd(2026, 1, 1), "", nil},
+		{"unknown compare", ymd(2026, 5, 1), ymd(2026, 5, 31), "week", nil},
+		{"compareFrom without compareTo", ymd(2026, 5, 1), ymd(2026, 5, 31), "", &compareFrom},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.GetPeriodReport(uuid.New().String(), tc.from, tc.to, tc.compare, tc.compareFrom, nil)
+			appErr, ok := err.(*utils.AppError)
+			if !ok || appErr.Code != 400 {
+				t.Errorf("expected bad request, got %v", err)
+			}
+		})
+	}
+}
+
+func TestComparisonPeriods(t *testing.T) {
+	cases := []struct {
+		name           string
+		from, to       time.Time
+		year           bool
+		wantFrom, want time.Time
+	}{
+		{"previous quarter", ymd(2026, 1, 1), ymd(2026, 3, 31), false, ymd(2025, 10, 1), ymd(2025, 12, 31)},
+		{"previous month into February", ymd(2026, 3, 1), ymd(2026, 3, 31), false, ymd(2026, 2, 1), ymd(2026, 2, 28)},
+		{"previous days", ymd(2026, 5, 10), ymd(2026, 5, 19), false, ymd(2026, 4, 30), ymd(2026, 5, 9)},
+		{"year ago leap February", ymd(2024, 2, 1), ymd(2024, 2, 29), true, ymd(2023, 2, 1), ymd(2023, 2, 28)},
+		{"year ago days", ymd(2026, 5, 10), ymd(2026, 5, 19), true, ymd(2025, 5, 10), ymd(2025, 5, 19)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := previousPeriod(tc.from, tc.to)
+			if tc.year {
+				from, to = yearAgoPeriod(tc.from, tc.to)
+			}
+			assertEqual(t, from, tc.wantFrom)
+			assertEqual(t, to, tc.want)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// SetPlanAlternativeRequest holds the body for entering an alternative plan price.
+type SetPlanAlternativeRequest struct {
+	Amount int `json:"amount" validate:"required,gt=0,lte=99999999"`
+}
+
+// PlanAlternativeService manages user-entered alternative plan prices.
+type PlanAlternativeService struct {
+	repo    repositories.PlanAlternativeRepository
+	subRepo repositories.SubscriptionRepository
+	cache   *AggregateCache
+}
+
+// NewPlanAlternativeService creates a new PlanAlternativeService.
+// cache may be nil, in which case no cached aggregates are invalidated.
+func NewPlanAlternativeService(repo repositories.PlanAlternativeRepository, subRepo repositories.SubscriptionRepository, cache *AggregateCache) *PlanAlternativeService {
+	return &PlanAlternativeService{repo: repo, subRepo: subRepo, cache: cache}
+}
+
+// GetAlternatives returns the alternative plans entered for a subscription.
+func (s *PlanAlternativeService) GetAlternatives(userID, subID string) ([]*models.PlanAlternative, error) {
+	if _, appErr := s.findOwnedSubscription(userID, subID); appErr != nil {
+		return nil, appErr
+	}
+
+	alts, err := s.repo.FindBySubscriptionID(subID)
+	if err != nil {
+		slog.Error("대체 요금제 조회 실패", "subID", subID, "error", err)
+		return nil, utils.ErrInternal("대체 요금제를 조회할 수 없습니다")
+	}
+	return alts, nil
+}
+
+// SetAlternative enters or replaces the subscription's price for another
+// billing cycle.
+func (s *PlanAlternativeService) SetAlternative(userID, subID, cycle string, req *SetPlanAlternativeRequest) (*models.PlanAlternative, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	sub, appErr := s.findOwnedSubscription(userID, subID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	billingCycle, appErr := parseAlternativeCycle(cycle)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if billingCycle == sub.BillingCycle {
+		return nil, utils.ErrValidation("현재 결제 주기와 다른 결제 주기를 선택해주세요")
+	}
+
+	alt := &models.PlanAlternative{
+		SubscriptionID: sub.ID,
+		BillingCycle:   billingCycle,
+		Amount:         req.Amount,
+	}
+	if err := s.repo.Upsert(alt); err != nil {
+		slog.Error("대체 요금제 저장 실패", "subID", subID, "error", err)
+		return nil, utils.ErrInternal("대체 요금제를 저장할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+
+	// Re-fetch so that an updated row is returned with its original ID.
+	alts, err := s.repo.FindBySubscriptionID(subID)
+	if err != nil {
+		return alt, nil
+	}
+	for _, stored := range alts {
+		if stored.BillingCycle == billingCycle {
+			return stored, nil
+		}
+	}
+	return alt, nil
+}
+
+// DeleteAlternative removes the subscription's price for a billing cycle.
+func (s *PlanAlternativeService) DeleteAlternative(userID, subID, cycle string) error {
+	if _, appErr := s.findOwnedSubscription(userID, subID); appErr != nil {
+		return appErr
+	}
+
+	billingCycle, appErr := parseAlternativeCycle(cycle)
+	if appErr != nil {
+		return appErr
+	}
+
+	deleted, err := s.repo.Delete(subID, billingCycle)
+	if err != nil {
+		slog.Error("대체 요금제 삭제 실패", "subID", subID, "error", err)
+		return utils.ErrInternal("대체 요금제를 삭제할 수 없습니다")
+	}
+	if !deleted {
+		return utils.ErrNotFound("대체 요금제를 찾을 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+	return nil
+}
+
+// findOwnedSubscription loads a subscription and verifies it belongs to the user.
+func (s *PlanAlternativeService) findOwnedSubscription(userID, subID string) (*models.Subscription, *utils.AppError) {
+	sub, err := s.subRepo.FindByID(subID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("구독을 찾을 수 없습니다")
+		}
+		slog.Error("대체 요금제 구독 조회 실패", "subID", subID, "error", err)
+		return nil, utils.ErrInternal("구독을 조회할 수 없습니다")
+	}
+	if sub.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 구독에 대한 접근 권한이 없습니다")
+	}
+	return sub, nil
+}
+
+// parseAlternativeCycle validates a billing cycle path parameter.
+func parseAlternativeCycle(cycle string) (models.BillingCycle, *utils.AppError) {
+	switch c := models.BillingCycle(cycle); c {
+	case models.BillingCycleWeekly, models.BillingCycleMonthly, models.BillingCycleYearly:
+		return c, nil
+	default:
+		return "", utils.ErrValidation("결제 주기는 weekly, monthly, yearly 중 하나여야 합니다")
+	}
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock PlanAlternative repository
+// ---------------------------------------------------------------------------
+
+type mockPlanAlternativeRepo struct {
+	alts []*models.PlanAlternative
+}
+
+func (m *mockPlanAlternativeRepo) FindBySubscriptionID(subID string) ([]*models.PlanAlternative, error) {
+	return m.FindBySubscriptionIDs([]string{subID})
+}
+
+func (m *mockPlanAlternativeRepo) FindBySubscriptionIDs(subIDs []string) ([]*models.PlanAlternative, error) {
+	var result []*models.PlanAlternative
+	for _, alt := range m.alts {
+		for _, id := range subIDs {
+			if alt.SubscriptionID.String() == id {
+				cp := *alt
+				result = append(result, &cp)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPlanAlternativeRepo) Upsert(alt *models.PlanAlternative) error {
+	for _, existing := range m.alts {
+		if existing.SubscriptionID == alt.SubscriptionID && existing.BillingCycle == alt.BillingCycle {
+			existing.Amount = alt.Amount
+			return nil
+		}
+	}
+	if alt.ID == uuid.Nil {
+		alt.ID = uuid.New()
+	}
+	cp := *alt
+	m.alts = append(m.alts, &cp)
+	return nil
+}
+
+func (m *mockPlanAlternativeRepo) Delete(subID string, cycle models.BillingCycle) (bool, error) {
+	for i, alt := range m.alts {
+		if alt.SubscriptionID.String() == subID && alt.BillingCycle == cycle {
+			m.alts = append(m.alts[:i], m.alts[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestPlanAlternativeService(t *testing.T) {
+	userID := uuid.New()
+
+	setup := func() (*PlanAlternativeService, *mockPlanAlternativeRepo, *models.Subscription) {
+		subs := newMockRepo()
+		repo := &mockPlanAlternativeRepo{}
+		sub := subs.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+		return NewPlanAlternativeService(repo, subs, nil), repo, sub
+	}
+
+	t.Run("sets, replaces and deletes an alternative", func(t *testing.T) {
+		svc, repo, sub := setup()
+
+		first, err := svc.SetAlternative(userID.String(), sub.ID.String(), "yearly", &SetPlanAlternativeRequest{Amount: 170000})
+		assertNil(t, err)
+		second, err := svc.SetAlternative(userID.String(), sub.ID.String(), "yearly", &SetPlanAlternativeRequest{Amount: 160000})
+		assertNil(t, err)
+		assertEqual(t, first.ID, second.ID)
+		assertEqual(t, 160000, second.Amount)
+		assertEqual(t, 1, len(repo.alts))
+
+		alts, err := svc.GetAlternatives(userID.String(), sub.ID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(alts))
+
+		assertNil(t, svc.DeleteAlternative(userID.String(), sub.ID.String(), "yearly"))
+		err = svc.DeleteAlternative(userID.String(), sub.ID.String(), "yearly")
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		svc, _, sub := setup()
+
+		_, err := svc.SetAlternative(userID.String(), sub.ID.String(), "monthly", &SetPlanAlternativeRequest{Amount: 15000})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+		_, err = svc.SetAlternative(userID.String(), sub.ID.String(), "daily", &SetPlanAlternativeRequest{Amount: 15000})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+		_, err = svc.SetAlternative(userID.String(), sub.ID.String(), "yearly", &SetPlanAlternativeRequest{})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+		_, err = svc.SetAlternative(uuid.NewString(), sub.ID.String(), "yearly", &SetPlanAlternativeRequest{Amount: 1})
+		assertAppErrorCode(t, err, http.StatusForbidden)
+		_, err = svc.GetAlternatives(userID.String(), uuid.NewString())
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+}
+
+func TestGetRecommendations_PlanSwitch(t *testing.T) {
+	userID := uuid.New()
+	longAgo := time.Now().AddDate(-1, 0, 0)
+
+	t.Run("suggests a yearly plan from user-entered prices", func(t *testing.T) {
+		subs := newMockRepo()
+		alts := &mockPlanAlternativeRepo{}
+		svc := NewDashboardServiceWithDeps(subs, newMockShareRepo(), DashboardDeps{AltRepo: alts})
+
+		sub := subs.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+		sub.StartDate = longAgo
+		alts.alts = append(alts.alts, &models.PlanAlternative{SubscriptionID: sub.ID, BillingCycle: models.BillingCycleYearly, Amount: 170000})
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		rec := recs[0]
+		assertEqual(t, RecommendationTypeSwitchPlan, rec.Type)
+		assertEqual(t, 34000, rec.AnnualSaving)
+		assertEqual(t, "연간 결제로 전환하면 연 34,000원 절약", rec.Reason)
+		assertNotNil(t, rec.PlanSwitch)
+		assertEqual(t, models.BillingCycleYearly, rec.PlanSwitch.ToCycle)
+		assertEqual(t, PlanPriceSourceUser, rec.PlanSwitch.PriceSource)
+		assertEqual(t, 170000, rec.PlanSwitch.UpfrontCost)
+		assertEqual(t, 16.7, rec.PlanSwitch.SavingPercent)
+	})
+
+	t.Run("uses catalog prices only for the catalog plan", func(t *testing.T) {
+		catalog, err := LoadServiceCatalog("")
+		assertNil(t, err)
+		subs := newMockRepo()
+		svc := NewDashboardServiceWithDeps(subs, newMockShareRepo(), DashboardDeps{Catalog: catalog})
+
+		standard := subs.seedSubscriptionWithDetails(userID, "Microsoft 365", 8900, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(4), nil)
+		standard.StartDate = longAgo
+		premium := subs.seedSubscriptionWithDetails(userID, "Google One", 11900, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(4), nil)
+		premium.StartDate = longAgo
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		assertEqual(t, standard.ID.String(), recs[0].SubscriptionID)
+		assertEqual(t, PlanPriceSourceCatalog, recs[0].PlanSwitch.PriceSource)
+		assertEqual(t, 8900*12-89000, recs[0].AnnualSaving)
+	})
+
+	t.Run("requires satisfaction and tenure and scales by personal share", func(t *testing.T) {
+		subs := newMockRepo()
+		shares := newMockShareRepo()
+		alts := &mockPlanAlternativeRepo{}
+		svc := NewDashboardServiceWithDeps(subs, shares, DashboardDeps{AltRepo: alts})
+
+		recent := subs.seedSubscriptionWithDetails(userID, "Recent", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+		lukewarm := subs.seedSubscriptionWithDetails(userID, "Lukewarm", 4000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(3), nil)
+		lukewarm.StartDate = longAgo
+		shared := subs.seedSubscriptionWithDetails(userID, "Shared", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+		shared.StartDate = longAgo
+		shares.shares[uuid.NewString()] = &models.SubscriptionShare{ID: uuid.New(), SubscriptionID: shared.ID, SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 2}
+		for _, sub := range []*models.Subscription{recent, lukewarm, shared} {
+			alts.alts = append(alts.alts, &models.PlanAlternative{SubscriptionID: sub.ID, BillingCycle: models.BillingCycleYearly, Amount: sub.Amount * 10})
+		}
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		assertEqual(t, "Shared", recs[0].ServiceName)
+		assertEqual(t, 10000, recs[0].AnnualSaving)
+		assertEqual(t, 100000, recs[0].PlanSwitch.UpfrontCost)
+	})
+}
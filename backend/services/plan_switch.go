@@ -0,0 +1,142 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/subkeep/backend/models"
+)
+
+// Plan switches are only suggested for services the user likes and has kept
+// long enough that paying up front is unlikely to be wasted.
+const (
+	planSwitchMinSatisfaction = 4
+	planSwitchMinTenureDays   = 180
+)
+
+// Sources of alternative plan prices.
+const (
+	PlanPriceSourceUser    = "user"
+	PlanPriceSourceCatalog = "catalog"
+)
+
+// PlanSwitchDetail describes switching a subscription to a longer billing cycle.
+// AnnualSaving is the user's personal share; UpfrontCost is the full first
+// charge under the new cycle.
+type PlanSwitchDetail struct {
+	FromCycle         models.BillingCycle `json:"fromCycle"`
+	ToCycle           models.BillingCycle `json:"toCycle"`
+	CurrentAmount     int                 `json:"currentAmount"`
+	AlternativeAmount int                 `json:"alternativeAmount"`
+	PriceSource       string              `json:"priceSource"`
+	AnnualSaving      int                 `json:"annualSaving"`
+	SavingPercent     float64             `json:"savingPercent"`
+	UpfrontCost       int                 `json:"upfrontCost"`
+	SwitchDate        string              `json:"switchDate"`
+}
+
+// planPrice is a known price of a subscription under some billing cycle.
+type planPrice struct {
+	cycle  models.BillingCycle
+	amount int
+	source string
+}
+
+// cycleRank orders billing cycles from shortest to longest.
+func cycleRank(cycle models.BillingCycle) int {
+	switch cycle {
+	case models.BillingCycleWeekly:
+		return 0
+	case models.BillingCycleYearly:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// annualCost returns what an amount billed every cycle costs per year.
+func annualCost(cycle models.BillingCycle, amount int) int {
+	switch cycle {
+	case models.BillingCycleWeekly:
+		return amount * 52
+	case models.BillingCycleYearly:
+		return amount
+	default:
+		return amount * 12
+	}
+}
+
+// alternativePlanPrices returns the known prices of the subscription under
+// other billing cycles. User-entered prices win over catalog prices; catalog
+// prices are only trusted when the catalog's price for the current cycle
+// matches what the user pays, i.e. the user is on the catalog's plan.
+func alternativePlanPrices(sub *models.Subscription, userAlts []*models.PlanAlternative, catalog *ServiceCatalog) []planPrice {
+	prices := make([]planPrice, 0)
+	seen := make(map[models.BillingCycle]bool)
+	for _, alt := range userAlts {
+		if alt.BillingCycle == sub.BillingCycle {
+			continue
+		}
+		prices = append(prices, planPrice{cycle: alt.BillingCycle, amount: alt.Amount, source: PlanPriceSourceUser})
+		seen[alt.BillingCycle] = true
+	}
+
+	entry, ok := catalog.Lookup(sub.ServiceName)
+	if !ok || entry.Plans[sub.BillingCycle] != sub.Amount {
+		return prices
+	}
+	for cycle, amount := range entry.Plans {
+		if cycle == sub.BillingCycle || seen[cycle] {
+			continue
+		}
+		prices = append(prices, planPrice{cycle: cycle, amount: amount, source: PlanPriceSourceCatalog})
+	}
+	return prices
+}
+
+// suggestPlanSwitch returns the most valuable switch to a longer billing cycle
+// for a candidate, or nil when none saves money or the candidate does not
+// qualify.
+func suggestPlanSwitch(c recommendationCandidate, prices []planPrice, today time.Time) *PlanSwitchDetail {
+	sub := c.sub
+	if sub.SatisfactionScore == nil || *sub.SatisfactionScore < planSwitchMinSatisfaction {
+		return nil
+	}
+	start := truncateDate(sub.StartDate)
+	if start.IsZero() || today.Sub(start) < planSwitchMinTenureDays*24*time.Hour {
+		return nil
+	}
+	fullMonthly := sub.MonthlyAmount()
+	if fullMonthly <= 0 {
+		return nil
+	}
+	share := float64(c.monthly) / float64(fullMonthly)
+
+	currentAnnual := annualCost(sub.BillingCycle, sub.Amount)
+	var best *PlanSwitchDetail
+	for _, p := range prices {
+		if cycleRank(p.cycle) <= cycleRank(sub.BillingCycle) {
+			continue
+		}
+		saving := currentAnnual - annualCost(p.cycle, p.amount)
+		if saving <= 0 {
+			continue
+		}
+		personalSaving := int(math.Round(float64(saving) * share))
+		if best != nil && personalSaving <= best.AnnualSaving {
+			continue
+		}
+		best = &PlanSwitchDetail{
+			FromCycle:         sub.BillingCycle,
+			ToCycle:           p.cycle,
+			CurrentAmount:     sub.Amount,
+			AlternativeAmount: p.amount,
+			PriceSource:       p.source,
+			AnnualSaving:      personalSaving,
+			SavingPercent:     math.Round(float64(saving)/float64(currentAnnual)*1000) / 10,
+			UpfrontCost:       p.amount,
+			SwitchDate:        truncateDate(sub.NextBillingDate).Format("2006-01-02"),
+		}
+	}
+	return best
+}
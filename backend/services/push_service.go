@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdh"
+	"errors"
+	"log/slog"
+	"net/url"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// maxPushSubscriptionsPerUser caps the number of devices a user can register.
+const maxPushSubscriptionsPerUser = 20
+
+// CreatePushSubscriptionRequest holds the body for registering a browser push
+// subscription. It mirrors the browser's PushSubscription.toJSON() output.
+type CreatePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,url,max=1000"`
+	Keys     struct {
+		P256dh string `json:"p256dh" validate:"required,max=200"`
+		Auth   string `json:"auth" validate:"required,max=100"`
+	} `json:"keys"`
+	DeviceName *string `json:"deviceName" validate:"omitempty,max=100"`
+}
+
+// VAPIDPublicKeyResponse is the application server key the browser needs to
+// call pushManager.subscribe().
+type VAPIDPublicKeyResponse struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// PushService manages browser push subscriptions.
+type PushService struct {
+	subRepo  repositories.PushSubscriptionRepository
+	notifier *PushNotifier
+	keys     *VAPIDKeys
+}
+
+// NewPushService creates a new PushService.
+func NewPushService(subRepo repositories.PushSubscriptionRepository, notifier *PushNotifier, keys *VAPIDKeys) *PushService {
+	return &PushService{subRepo: subRepo, notifier: notifier, keys: keys}
+}
+
+// GetPublicKey returns the VAPID public key.
+func (s *PushService) GetPublicKey() *VAPIDPublicKeyResponse {
+	return &VAPIDPublicKeyResponse{PublicKey: s.keys.PublicKey}
+}
+
+// GetSubscriptions returns the user's registered devices.
+func (s *PushService) GetSubscriptions(userID string) ([]*models.PushSubscription, error) {
+	subs, err := s.subRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("푸시 구독 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("푸시 구독 목록을 조회할 수 없습니다")
+	}
+	return subs, nil
+}
+
+// Subscribe registers a browser push subscription. Re-registering an existing
+// endpoint refreshes its keys and moves it to the calling user, since the
+// endpoint identifies the browser rather than the account.
+func (s *PushService) Subscribe(userID string, req *CreatePushSubscriptionRequest, userAgent string) (*models.PushSubscription, error) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if appErr := validatePushSubscription(req); appErr != nil {
+		return nil, appErr
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	var agent *string
+	if userAgent != "" {
+		if len(userAgent) > 500 {
+			userAgent = userAgent[:500]
+		}
+		agent = &userAgent
+	}
+
+	existing, err := s.subRepo.FindByEndpoint(req.Endpoint)
+	switch {
+	case err == nil:
+		existing.UserID = uid
+		existing.P256dh = req.Keys.P256dh
+		existing.Auth = req.Keys.Auth
+		existing.UserAgent = agent
+		if req.DeviceName != nil {
+			existing.DeviceName = req.DeviceName
+		}
+		if err := s.subRepo.Update(existing); err != nil {
+			slog.Error("푸시 구독 갱신 실패", "userID", userID, "error", err)
+			return nil, utils.ErrInternal("푸시 구독을 저장할 수 없습니다")
+		}
+		return existing, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		slog.Error("푸시 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("푸시 구독을 조회할 수 없습니다")
+	}
+
+	current, err := s.subRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("푸시 구독 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("푸시 구독 목록을 조회할 수 없습니다")
+	}
+	if len(current) >= maxPushSubscriptionsPerUser {
+		return nil, utils.ErrBadRequest("푸시 알림 기기는 최대 20개까지 등록할 수 있습니다")
+	}
+
+	sub := &models.PushSubscription{
+		UserID:     uid,
+		Endpoint:   req.Endpoint,
+		P256dh:     req.Keys.P256dh,
+		Auth:       req.Keys.Auth,
+		DeviceName: req.DeviceName,
+		UserAgent:  agent,
+	}
+	if err := s.subRepo.Create(sub); err != nil {
+		slog.Error("푸시 구독 생성 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("푸시 구독을 저장할 수 없습니다")
+	}
+
+	slog.Info("푸시 구독 등록", "userID", userID, "pushSubscriptionID", sub.ID)
+	return sub, nil
+}
+
+// Unsubscribe removes one of the user's push subscriptions.
+func (s *PushService) Unsubscribe(userID, subscriptionID string) error {
+	sub, err := s.subRepo.FindByID(subscriptionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound("푸시 구독을 찾을 수 없습니다")
+		}
+		slog.Error("푸시 구독 조회 실패", "pushSubscriptionID", subscriptionID, "error", err)
+		return utils.ErrInternal("푸시 구독을 조회할 수 없습니다")
+	}
+	if sub.UserID.String() != userID {
+		return utils.ErrForbidden("해당 푸시 구독에 대한 접근 권한이 없습니다")
+	}
+
+	if err := s.subRepo.Delete(subscriptionID); err != nil {
+		slog.Error("푸시 구독 삭제 실패", "pushSubscriptionID", subscriptionID, "error", err)
+		return utils.ErrInternal("푸시 구독을 삭제할 수 없습니다")
+	}
+	return nil
+}
+
+// SendTest pushes a test notification to every device of the user.
+func (s *PushService) SendTest(ctx context.Context, userID string) (*PushSendResult, error) {
+	subs, err := s.subRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("푸시 구독 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("푸시 구독 목록을 조회할 수 없습니다")
+	}
+	if len(subs) == 0 {
+		return nil, utils.ErrBadRequest("등록된 푸시 알림 기기가 없습니다")
+	}
+
+	result, err := s.notifier.SendToUser(ctx, userID, PushMessage{
+		Title: "[SubKeep] 테스트 알림",
+		Body:  "푸시 알림이 정상적으로 설정되었습니다.",
+		Tag:   "test",
+	})
+	if err != nil {
+		slog.Error("테스트 푸시 발송 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("테스트 알림을 발송할 수 없습니다")
+	}
+	return result, nil
+}
+
+// validatePushSubscription checks the endpoint, which must be an https URL
+// outside the server's own network, and the browser keys.
+func validatePushSubscription(req *CreatePushSubscriptionRequest) *utils.AppError {
+	u, err := url.Parse(req.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return utils.ErrValidation("푸시 엔드포인트는 https URL이어야 합니다")
+	}
+	if err := validateOutboundURL(req.Endpoint); err != nil {
+		if errors.Is(err, errBlockedAddress) {
+			return utils.ErrValidation("내부 네트워크 주소로는 푸시 알림을 보낼 수 없습니다")
+		}
+		return utils.ErrValidation("푸시 엔드포인트는 https URL이어야 합니다")
+	}
+
+	p256dh, err := b64url.DecodeString(req.Keys.P256dh)
+	if err != nil {
+		return utils.ErrValidation("p256dh 키 형식이 올바르지 않습니다")
+	}
+	if _, err := ecdh.P256().NewPublicKey(p256dh); err != nil {
+		return utils.ErrValidation("p256dh 키 형식이 올바르지 않습니다")
+	}
+	auth, err := b64url.DecodeString(req.Keys.Auth)
+	if err != nil || len(auth) != 16 {
+		return utils.ErrValidation("auth 키 형식이 올바르지 않습니다")
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+func newTestPushService(t *testing.T, client *http.Client) (*PushService, *mockPushSubscriptionRepo) {
+	t.Helper()
+	repo := newMockPushSubscriptionRepo()
+	keys := newTestVAPIDKeys(t)
+	notifier := NewPushNotifier(repo, keys, client, nil, config.PushConfig{Subject: "mailto:ops@subkeep.app"})
+	return NewPushService(repo, notifier, keys), repo
+}
+
+func pushRequest(endpoint string, b *pushBrowser) *CreatePushSubscriptionRequest {
+	req := &CreatePushSubscriptionRequest{Endpoint: endpoint}
+	req.Keys.P256dh = b.p256dh()
+	req.Keys.Auth = b.authKey()
+	return req
+}
+
+func TestPushService_Subscribe(t *testing.T) {
+	t.Run("registers a device", func(t *testing.T) {
+		svc, repo := newTestPushService(t, nil)
+		userID := uuid.New().String()
+
+		req := pushRequest("https://fcm.googleapis.com/fcm/send/abc", newPushBrowser(t))
+		req.DeviceName = strPtr("iPhone")
+		sub, err := svc.Subscribe(userID, req, "Mozilla/5.0")
+		assertNil(t, err)
+		assertEqual(t, userID, sub.UserID.String())
+		assertEqual(t, "iPhone", *sub.DeviceName)
+		assertEqual(t, "Mozilla/5.0", *sub.UserAgent)
+		assertEqual(t, 1, len(repo.subs))
+	})
+
+	t.Run("re-registering an endpoint updates it in place", func(t *testing.T) {
+		svc, repo := newTestPushService(t, nil)
+		endpoint := "https://fcm.googleapis.com/fcm/send/abc"
+		first, err := svc.Subscribe(uuid.New().String(), pushRequest(endpoint, newPushBrowser(t)), "")
+		assertNil(t, err)
+
+		otherUser := uuid.New().String()
+		browser := newPushBrowser(t)
+		second, err := svc.Subscribe(otherUser, pushRequest(endpoint, browser), "")
+		assertNil(t, err)
+
+		assertEqual(t, first.ID, second.ID)
+		assertEqual(t, 1, len(repo.subs))
+		stored := repo.subs[first.ID.String()]
+		assertEqual(t, otherUser, stored.UserID.String())
+		assertEqual(t, browser.p256dh(), stored.P256dh)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		svc, _ := newTestPushService(t, nil)
+		userID := uuid.New().String()
+		browser := newPushBrowser(t)
+
+		_, err := svc.Subscribe(userID, pushRequest("http://push.example.com/x", browser), "")
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+		req := pushRequest("https://push.example.com/x", browser)
+		req.Keys.P256dh = b64url.EncodeToString(make([]byte, 65))
+		_, err = svc.Subscribe(userID, req, "")
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+		req = pushRequest("https://push.example.com/x", browser)
+		req.Keys.Auth = b64url.EncodeToString(make([]byte, 8))
+		_, err = svc.Subscribe(userID, req, "")
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+		_, err = svc.Subscribe(userID, &CreatePushSubscriptionRequest{}, "")
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects internal endpoints", func(t *testing.T) {
+		svc, repo := newTestPushService(t, nil)
+		userID := uuid.New().String()
+		browser := newPushBrowser(t)
+
+		for _, endpoint := range []string{
+			"https://127.0.0.1/push",
+			"https://localhost/push",
+			"https://10.0.0.5/push",
+			"https://169.254.169.254/latest/meta-data",
+		} {
+			_, err := svc.Subscribe(userID, pushRequest(endpoint, browser), "")
+			assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+		}
+		assertEqual(t, 0, len(repo.subs))
+	})
+
+	t.Run("limits devices per user", func(t *testing.T) {
+		svc, repo := newTestPushService(t, nil)
+		userID := uuid.New()
+		for i := 0; i < maxPushSubscriptionsPerUser; i++ {
+			assertNil(t, repo.Create(&models.PushSubscription{UserID: userID, Endpoint: uuid.NewString()}))
+		}
+		_, err := svc.Subscribe(userID.String(), pushRequest("https://push.example.com/new", newPushBrowser(t)), "")
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestPushService_Unsubscribe(t *testing.T) {
+	svc, repo := newTestPushService(t, nil)
+	owner := uuid.New()
+	sub := &models.PushSubscription{UserID: owner, Endpoint: "https://push.example.com/a"}
+	assertNil(t, repo.Create(sub))
+
+	assertAppErrorCode(t, svc.Unsubscribe(uuid.New().String(), sub.ID.String()), http.StatusForbidden)
+	assertAppErrorCode(t, svc.Unsubscribe(owner.String(), uuid.New().String()), http.StatusNotFound)
+
+	assertNil(t, svc.Unsubscribe(owner.String(), sub.ID.String()))
+	assertEqual(t, 0, len(repo.subs))
+}
+
+func TestPushService_SendTest(t *testing.T) {
+	service := newPushServiceStandIn(t)
+	svc, repo := newTestPushService(t, service.server.Client())
+	userID := uuid.New()
+
+	_, err := svc.SendTest(context.Background(), userID.String())
+	assertAppErrorCode(t, err, http.StatusBadRequest)
+
+	browser := newPushBrowser(t)
+	assertNil(t, repo.Create(&models.PushSubscription{
+		UserID: userID, Endpoint: service.server.URL + "/device", P256dh: browser.p256dh(), Auth: browser.authKey(),
+	}))
+
+	result, err := svc.SendTest(context.Background(), userID.String())
+	assertNil(t, err)
+	assertEqual(t, 1, result.Delivered)
+	assertEqual(t, 1, len(service.received()))
+}
+
+func TestPushService_GetPublicKey(t *testing.T) {
+	svc, _ := newTestPushService(t, nil)
+	assertEqual(t, svc.keys.PublicKey, svc.GetPublicKey().PublicKey)
+}
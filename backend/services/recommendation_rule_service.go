@@ -0,0 +1,518 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// RecommendationRuleConditions holds the optional conditions of a rule. A nil
+// field leaves that aspect unconstrained.
+type RecommendationRuleConditions struct {
+	MaxSatisfaction     *int     `json:"maxSatisfaction" validate:"omitempty,gte=1,lte=5"`
+	MinMonthlyAmount    *int     `json:"minMonthlyAmount" validate:"omitempty,gte=0"`
+	MaxMonthlyAmount    *int     `json:"maxMonthlyAmount" validate:"omitempty,gte=0"`
+	MinCostPercentile   *float64 `json:"minCostPercentile" validate:"omitempty,gte=0,lt=100"`
+	MinTenureDays       *int     `json:"minTenureDays" validate:"omitempty,gte=0"`
+	MinDaysSinceBilling *int     `json:"minDaysSinceBilling" validate:"omitempty,gte=0"`
+}
+
+// CreateRecommendationRuleRequest holds the body for creating a custom rule.
+type CreateRecommendationRuleRequest struct {
+	Name           string                       `json:"name" validate:"required,min=1,max=100"`
+	Weight         *float64                     `json:"weight" validate:"omitempty,gt=0,lte=100"`
+	ReasonTemplate string                       `json:"reasonTemplate" validate:"required,min=1,max=200"`
+	Enabled        *bool                        `json:"enabled"`
+	Conditions     RecommendationRuleConditions `json:"conditions"`
+}
+
+// UpdateRecommendationRuleRequest holds the body for tuning a rule. Conditions,
+// when present, replace all of the rule's conditions.
+type UpdateRecommendationRuleRequest struct {
+	Name           *string                       `json:"name" validate:"omitempty,min=1,max=100"`
+	Weight         *float64                      `json:"weight" validate:"omitempty,gt=0,lte=100"`
+	ReasonTemplate *string                       `json:"reasonTemplate" validate:"omitempty,min=1,max=200"`
+	Enabled        *bool                         `json:"enabled"`
+	Conditions     *RecommendationRuleConditions `json:"conditions"`
+}
+
+// FiredRule describes a rule that matched a recommended subscription.
+type FiredRule struct {
+	RuleID string  `json:"ruleId,omitempty"`
+	Key    *string `json:"key"`
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	Reason string  `json:"reason"`
+}
+
+// recommendationCandidate is a subscription under evaluation together with the
+// user's personal monthly share of it.
+type recommendationCandidate struct {
+	sub     *models.Subscription
+	monthly int
+}
+
+// defaultRecommendationRules returns the built-in rule set for a user. The
+// rules are kept in memory until the user edits one, so their IDs are derived
+// from the user and key to stay the same once stored.
+func defaultRecommendationRules(userID uuid.UUID) []*models.RecommendationRule {
+	lowKey := models.RecommendationRuleLowSatisfaction
+	costKey := models.RecommendationRuleHighCostLowSatisfaction
+	return []*models.RecommendationRule{
+		{
+			ID:              builtInRuleID(userID, lowKey),
+			UserID:          userID,
+			Key:             &lowKey,
+			Name:            "만족도 낮음",
+			Enabled:         true,
+			Weight:          2,
+			ReasonTemplate:  "만족도 낮음",
+			MaxSatisfaction: intValuePtr(2),
+			SortOrder:       0,
+		},
+		{
+			ID:                builtInRuleID(userID, costKey),
+			UserID:            userID,
+			Key:               &costKey,
+			Name:              "높은 비용 대비 낮은 만족도",
+			Enabled:           true,
+			Weight:            1,
+			ReasonTemplate:    "높은 비용 대비 낮은 만족도",
+			MaxSatisfaction:   intValuePtr(3),
+			MinCostPercentile: floatValuePtr(80),
+			SortOrder:         1,
+		},
+	}
+}
+
+// builtInRuleID returns the ID of the user's built-in rule with the given key.
+func builtInRuleID(userID uuid.UUID, key string) uuid.UUID {
+	return uuid.NewSHA1(userID, []byte(key))
+}
+
+// RecommendationRuleService manages the user's cancel recommendation rules and
+// evaluates them against subscriptions.
+type RecommendationRuleService struct {
+	repo  repositories.RecommendationRuleRepository
+	clock *UserClock
+	cache *AggregateCache
+}
+
+// NewRecommendationRuleService creates a new RecommendationRuleService.
+// cache may be nil, in which case no cached aggregates are invalidated.
+func NewRecommendationRuleService(repo repositories.RecommendationRuleRepository, clock *UserClock, cache *AggregateCache) *RecommendationRuleService {
+	return &RecommendationRuleService{repo: repo, clock: clock, cache: cache}
+}
+
+// GetRules returns the user's rules, or the built-in set while the user has
+// not edited any.
+func (s *RecommendationRuleService) GetRules(userID string) ([]*models.RecommendationRule, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	rules, err := s.loadRules(uid)
+	if err != nil {
+		slog.Error("해지 추천 규칙 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("해지 추천 규칙 목록을 조회할 수 없습니다")
+	}
+	return rules, nil
+}
+
+// CreateRule validates and creates a custom rule.
+func (s *RecommendationRuleService) CreateRule(userID string, req *CreateRecommendationRuleRequest) (*models.RecommendationRule, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	existing, err := s.loadRules(uid)
+	if err != nil {
+		slog.Error("해지 추천 규칙 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("해지 추천 규칙을 생성할 수 없습니다")
+	}
+	if len(existing) > 0 && !isStoredRule(existing[0]) {
+		if err := s.storeBuiltInRules(uid); err != nil {
+			slog.Error("기본 해지 추천 규칙 저장 실패", "userID", userID, "error", err)
+			return nil, utils.ErrInternal("해지 추천 규칙을 생성할 수 없습니다")
+		}
+	}
+
+	rule := &models.RecommendationRule{
+		UserID:         uid,
+		Name:           req.Name,
+		Enabled:        true,
+		Weight:         1,
+		ReasonTemplate: req.ReasonTemplate,
+		SortOrder:      len(existing),
+	}
+	if req.Weight != nil {
+		rule.Weight = *req.Weight
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	applyRuleConditions(rule, &req.Conditions)
+
+	if appErr := validateRecommendationRule(rule); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.repo.Create(rule); err != nil {
+		slog.Error("해지 추천 규칙 생성 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("해지 추천 규칙을 생성할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+	return rule, nil
+}
+
+// UpdateRule validates ownership and tunes a rule. Built-in rules can be
+// changed the same way as custom rules.
+func (s *RecommendationRuleService) UpdateRule(userID, ruleID string, req *UpdateRecommendationRuleRequest) (*models.RecommendationRule, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	rule, appErr := s.findOwnedRule(userID, ruleID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if req.Name != nil {
+		rule.Name = *req.Name
+	}
+	if req.Weight != nil {
+		rule.Weight = *req.Weight
+	}
+	if req.ReasonTemplate != nil {
+		rule.ReasonTemplate = *req.ReasonTemplate
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if req.Conditions != nil {
+		applyRuleConditions(rule, req.Conditions)
+	}
+
+	if appErr := validateRecommendationRule(rule); appErr != nil {
+		return nil, appErr
+	}
+
+	if !isStoredRule(rule) {
+		if err := s.storeBuiltInRules(rule.UserID); err != nil {
+			slog.Error("기본 해지 추천 규칙 저장 실패", "userID", userID, "error", err)
+			return nil, utils.ErrInternal("해지 추천 규칙을 수정할 수 없습니다")
+		}
+		stored, err := s.repo.FindByID(ruleID)
+		if err != nil {
+			slog.Error("해지 추천 규칙 조회 실패", "ruleID", ruleID, "error", err)
+			return nil, utils.ErrInternal("해지 추천 규칙을 수정할 수 없습니다")
+		}
+		rule.CreatedAt = stored.CreatedAt
+	}
+	if err := s.repo.Update(rule); err != nil {
+		slog.Error("해지 추천 규칙 수정 실패", "ruleID", ruleID, "error", err)
+		return nil, utils.ErrInternal("해지 추천 규칙을 수정할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+	return rule, nil
+}
+
+// DeleteRule deletes a custom rule. Built-in rules can only be disabled.
+func (s *RecommendationRuleService) DeleteRule(userID, ruleID string) error {
+	rule, appErr := s.findOwnedRule(userID, ruleID)
+	if appErr != nil {
+		return appErr
+	}
+	if rule.IsBuiltIn() {
+		return utils.ErrForbidden("기본 해지 추천 규칙은 삭제할 수 없습니다. 비활성화해주세요")
+	}
+
+	if err := s.repo.Delete(ruleID); err != nil {
+		slog.Error("해지 추천 규칙 삭제 실패", "ruleID", ruleID, "error", err)
+		return utils.ErrInternal("해지 추천 규칙을 삭제할 수 없습니다")
+	}
+	s.cache.InvalidateUser(userID)
+	return nil
+}
+
+// loadRules returns the user's stored rules, or the built-in set kept in
+// memory when the user has none yet.
+func (s *RecommendationRuleService) loadRules(userID uuid.UUID) ([]*models.RecommendationRule, error) {
+	rules, err := s.repo.FindByUserID(userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		return rules, nil
+	}
+	return defaultRecommendationRules(userID), nil
+}
+
+// storeBuiltInRules stores the built-in rule set before the user's first edit.
+// Built-in rules the user already has are skipped.
+func (s *RecommendationRuleService) storeBuiltInRules(userID uuid.UUID) error {
+	return s.repo.CreateAll(defaultRecommendationRules(userID))
+}
+
+// isStoredRule reports whether the rule was loaded from the repository rather
+// than taken from the in-memory built-in set.
+func isStoredRule(rule *models.RecommendationRule) bool {
+	return !rule.CreatedAt.IsZero()
+}
+
+// activeRules returns the enabled rules used for evaluation. A nil service, or
+// one whose rules cannot be loaded, falls back to the built-in set.
+func (s *RecommendationRuleService) activeRules(userID uuid.UUID) []*models.RecommendationRule {
+	rules := defaultRecommendationRules(userID)
+	if s != nil && s.repo != nil {
+		stored, err := s.loadRules(userID)
+		if err != nil {
+			slog.Error("해지 추천 규칙 조회 실패, 기본 규칙 사용", "userID", userID, "error", err)
+		} else {
+			rules = stored
+		}
+	}
+
+	active := make([]*models.RecommendationRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled && rule.HasConditions() {
+			active = append(active, rule)
+		}
+	}
+	return active
+}
+
+// today returns the user's current date; a nil service uses the system clock.
+func (s *RecommendationRuleService) today(userID string) time.Time {
+	var clock *UserClock
+	if s != nil {
+		clock = s.clock
+	}
+	return clock.Today(userID)
+}
+
+// evaluate runs the user's rules against the candidates and returns the fired
+// rules per subscription ID, heaviest first.
+func (s *RecommendationRuleService) evaluate(userID uuid.UUID, candidates []recommendationCandidate) map[string][]FiredRule {
+	rules := s.activeRules(userID)
+	fired := make(map[string][]FiredRule)
+	if len(rules) == 0 || len(candidates) == 0 {
+		return fired
+	}
+
+	today := s.today(userID.String())
+	amounts := make([]int, len(candidates))
+	for i, c := range candidates {
+		amounts[i] = c.monthly
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(amounts)))
+
+	for _, c := range candidates {
+		facts := newRecommendationFacts(c, amounts, today)
+		for _, rule := range rules {
+			if !facts.matches(rule) {
+				continue
+			}
+			firedRule := FiredRule{
+				Key:    rule.Key,
+				Name:   rule.Name,
+				Weight: rule.Weight,
+				Reason: facts.render(rule),
+			}
+			if rule.ID != uuid.Nil {
+				firedRule.RuleID = rule.ID.String()
+			}
+			fired[c.sub.ID.String()] = append(fired[c.sub.ID.String()], firedRule)
+		}
+	}
+
+	for _, list := range fired {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Weight > list[j].Weight })
+	}
+	return fired
+}
+
+// recommendationFacts holds the values a rule is evaluated against.
+type recommendationFacts struct {
+	candidate        recommendationCandidate
+	amounts          []int // personal monthly amounts, descending
+	tenureDays       int
+	daysSinceBilling *int
+}
+
+// newRecommendationFacts derives the evaluation facts for a candidate.
+func newRecommendationFacts(c recommendationCandidate, amounts []int, today time.Time) *recommendationFacts {
+	facts := &recommendationFacts{candidate: c, amounts: amounts}
+	if start := truncateDate(c.sub.StartDate); !start.IsZero() && !start.After(today) {
+		facts.tenureDays = int(today.Sub(start).Hours() / 24)
+	}
+	if last, ok := lastBillingDate(c.sub, today); ok {
+		days := int(today.Sub(last).Hours() / 24)
+		facts.daysSinceBilling = &days
+	}
+	return facts
+}
+
+// matches reports whether every condition of the rule holds.
+func (f *recommendationFacts) matches(rule *models.RecommendationRule) bool {
+	sub := f.candidate.sub
+	if rule.MaxSatisfaction != nil {
+		if sub.SatisfactionScore == nil || *sub.SatisfactionScore > *rule.MaxSatisfaction {
+			return false
+		}
+	}
+	if rule.MinMonthlyAmount != nil && f.candidate.monthly < *rule.MinMonthlyAmount {
+		return false
+	}
+	if rule.MaxMonthlyAmount != nil && f.candidate.monthly > *rule.MaxMonthlyAmount {
+		return false
+	}
+	if rule.MinCostPercentile != nil && f.candidate.monthly < f.percentileThreshold(*rule.MinCostPercentile) {
+		return false
+	}
+	if rule.MinTenureDays != nil && f.tenureDays < *rule.MinTenureDays {
+		return false
+	}
+	if rule.MinDaysSinceBilling != nil {
+		if f.daysSinceBilling == nil || *f.daysSinceBilling < *rule.MinDaysSinceBilling {
+			return false
+		}
+	}
+	return true
+}
+
+// percentileThreshold returns the smallest monthly amount that is still in
+// the top (100 - percentile)% of the user's subscriptions.
+func (f *recommendationFacts) percentileThreshold(percentile float64) int {
+	top := int(math.Ceil(float64(len(f.amounts)) * (100 - percentile) / 100))
+	if top < 1 {
+		top = 1
+	}
+	if top > len(f.amounts) {
+		top = len(f.amounts)
+	}
+	return f.amounts[top-1]
+}
+
+// render fills the rule's reason template for the candidate.
+func (f *recommendationFacts) render(rule *models.RecommendationRule) string {
+	sub := f.candidate.sub
+	satisfaction := "-"
+	if sub.SatisfactionScore != nil {
+		satisfaction = strconv.Itoa(*sub.SatisfactionScore)
+	}
+	daysSinceBilling := "-"
+	if f.daysSinceBilling != nil {
+		daysSinceBilling = strconv.Itoa(*f.daysSinceBilling)
+	}
+	topPercent := "-"
+	if rule.MinCostPercentile != nil {
+		topPercent = strconv.FormatFloat(100-*rule.MinCostPercentile, 'f', -1, 64)
+	}
+
+	return strings.NewReplacer(
+		"{serviceName}", sub.ServiceName,
+		"{satisfaction}", satisfaction,
+		"{monthlyAmount}", formatWon(f.candidate.monthly),
+		"{annualSaving}", formatWon(f.candidate.monthly*12),
+		"{tenureDays}", strconv.Itoa(f.tenureDays),
+		"{daysSinceBilling}", daysSinceBilling,
+		"{topPercent}", topPercent,
+	).Replace(rule.ReasonTemplate)
+}
+
+// lastBillingDate returns the most recent billing date on or before today.
+func lastBillingDate(sub *models.Subscription, today time.Time) (time.Time, bool) {
+	var last time.Time
+	found := false
+	for _, d := range expandBillingDates(sub, today.AddDate(-1, 0, -7), today, today) {
+		if !d.After(today) && (!found || d.After(last)) {
+			last = d
+			found = true
+		}
+	}
+	return last, found
+}
+
+// findOwnedRule loads a rule and verifies it belongs to the user. Built-in
+// rules not stored yet are returned from the in-memory set.
+func (s *RecommendationRuleService) findOwnedRule(userID, ruleID string) (*models.RecommendationRule, *utils.AppError) {
+	rule, err := s.repo.FindByID(ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if builtIn := findBuiltInRule(userID, ruleID); builtIn != nil {
+				return builtIn, nil
+			}
+			return nil, utils.ErrNotFound("해지 추천 규칙을 찾을 수 없습니다")
+		}
+		slog.Error("해지 추천 규칙 조회 실패", "ruleID", ruleID, "error", err)
+		return nil, utils.ErrInternal("해지 추천 규칙을 조회할 수 없습니다")
+	}
+	if rule.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 해지 추천 규칙에 대한 접근 권한이 없습니다")
+	}
+	return rule, nil
+}
+
+// findBuiltInRule returns the user's in-memory built-in rule with the given
+// ID, or nil when there is none.
+func findBuiltInRule(userID, ruleID string) *models.RecommendationRule {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	for _, rule := range defaultRecommendationRules(uid) {
+		if rule.ID.String() == ruleID {
+			return rule
+		}
+	}
+	return nil
+}
+
+// applyRuleConditions replaces the rule's conditions.
+func applyRuleConditions(rule *models.RecommendationRule, c *RecommendationRuleConditions) {
+	rule.MaxSatisfaction = c.MaxSatisfaction
+	rule.MinMonthlyAmount = c.MinMonthlyAmount
+	rule.MaxMonthlyAmount = c.MaxMonthlyAmount
+	rule.MinCostPercentile = c.MinCostPercentile
+	rule.MinTenureDays = c.MinTenureDays
+	rule.MinDaysSinceBilling = c.MinDaysSinceBilling
+}
+
+// validateRecommendationRule checks rule-level invariants.
+func validateRecommendationRule(rule *models.RecommendationRule) *utils.AppError {
+	if !rule.HasConditions() {
+		return utils.ErrValidation("해지 추천 규칙에는 하나 이상의 조건이 필요합니다")
+	}
+	if rule.MinMonthlyAmount != nil && rule.MaxMonthlyAmount != nil && *rule.MinMonthlyAmount > *rule.MaxMonthlyAmount {
+		return utils.ErrValidation("최소 월 금액은 최대 월 금액보다 클 수 없습니다")
+	}
+	return nil
+}
+
+// intValuePtr returns a pointer to v.
+func intValuePtr(v int) *int {
+	return &v
+}
+
+// floatValuePtr returns a pointer to v.
+func floatValuePtr(v float64) *float64 {
+	return &v
+}
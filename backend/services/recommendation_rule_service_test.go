@@ -0,0 +1,306 @@
+package services
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock RecommendationRule repository
+// ---------------------------------------------------------------------------
+
+type mockRecommendationRuleRepo struct {
+	rules map[string]*models.RecommendationRule
+}
+
+func newMockRecommendationRuleRepo() *mockRecommendationRuleRepo {
+	return &mockRecommendationRuleRepo{rules: make(map[string]*models.RecommendationRule)}
+}
+
+func (m *mockRecommendationRuleRepo) FindByID(id string) (*models.RecommendationRule, error) {
+	r, ok := m.rules[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *r
+	return &cp, nil
+}
+
+func (m *mockRecommendationRuleRepo) FindByUserID(userID string) ([]*models.RecommendationRule, error) {
+	var result []*models.RecommendationRule
+	for _, r := range m.rules {
+		if r.UserID.String() == userID {
+			cp := *r
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SortOrder < result[j].SortOrder })
+	return result, nil
+}
+
+func (m *mockRecommendationRuleRepo) CreateAll(rules []*models.RecommendationRule) error {
+	for _, r := range rules {
+		if r.Key != nil && m.ruleByKey(r.UserID, *r.Key) != nil {
+			continue
+		}
+		if err := m.Create(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockRecommendationRuleRepo) Create(rule *models.RecommendationRule) error {
+	if rule.ID == uuid.Nil {
+		rule.ID = uuid.New()
+	}
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now()
+	}
+	cp := *rule
+	m.rules[rule.ID.String()] = &cp
+	return nil
+}
+
+func (m *mockRecommendationRuleRepo) Update(rule *models.RecommendationRule) error {
+	cp := *rule
+	m.rules[rule.ID.String()] = &cp
+	return nil
+}
+
+func (m *mockRecommendationRuleRepo) Delete(id string) error {
+	delete(m.rules, id)
+	return nil
+}
+
+// ruleByKey returns the user's stored built-in rule with the given key.
+func (m *mockRecommendationRuleRepo) ruleByKey(userID uuid.UUID, key string) *models.RecommendationRule {
+	for _, r := range m.rules {
+		if r.UserID == userID && r.Key != nil && *r.Key == key {
+			return r
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// newTestRecommendationRules returns a rule service whose clock reads 2026-03-15.
+func newTestRecommendationRules() (*RecommendationRuleService, *mockRecommendationRuleRepo) {
+	repo := newMockRecommendationRuleRepo()
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)), nil)
+	return NewRecommendationRuleService(repo, clock, nil), repo
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestRecommendationRuleService_GetRules(t *testing.T) {
+	svc, repo := newTestRecommendationRules()
+	userID := uuid.New()
+
+	rules, err := svc.GetRules(userID.String())
+	assertNil(t, err)
+	assertEqual(t, 2, len(rules))
+	assertEqual(t, models.RecommendationRuleLowSatisfaction, *rules[0].Key)
+	assertEqual(t, models.RecommendationRuleHighCostLowSatisfaction, *rules[1].Key)
+
+	// The built-in rules are not stored until the user edits one.
+	again, err := svc.GetRules(userID.String())
+	assertNil(t, err)
+	assertEqual(t, 0, len(repo.rules))
+	assertEqual(t, rules[0].ID, again[0].ID)
+
+	_, err = svc.GetRules("not-a-uuid")
+	assertAppErrorCode(t, err, http.StatusBadRequest)
+}
+
+func TestRecommendationRuleService_CRUD(t *testing.T) {
+	t.Run("creates a custom rule after the built-ins", func(t *testing.T) {
+		svc, repo := newTestRecommendationRules()
+		userID := uuid.New()
+
+		rule, err := svc.CreateRule(userID.String(), &CreateRecommendationRuleRequest{
+			Name:           "비싼 구독",
+			ReasonTemplate: "월 {monthlyAmount}원",
+			Conditions:     RecommendationRuleConditions{MinMonthlyAmount: intPtr(30000)},
+		})
+		assertNil(t, err)
+		assertEqual(t, 1.0, rule.Weight)
+		assertEqual(t, 2, rule.SortOrder)
+		assertEqual(t, false, rule.IsBuiltIn())
+		assertEqual(t, 3, len(repo.rules))
+	})
+
+	t.Run("rejects invalid rules", func(t *testing.T) {
+		svc, _ := newTestRecommendationRules()
+		userID := uuid.New().String()
+
+		_, err := svc.CreateRule(userID, &CreateRecommendationRuleRequest{Name: "조건 없음", ReasonTemplate: "x"})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+		_, err = svc.CreateRule(userID, &CreateRecommendationRuleRequest{
+			Name:           "범위 오류",
+			ReasonTemplate: "x",
+			Conditions:     RecommendationRuleConditions{MinMonthlyAmount: intPtr(5000), MaxMonthlyAmount: intPtr(1000)},
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+
+		_, err = svc.CreateRule(userID, &CreateRecommendationRuleRequest{
+			Name:           "만족도 범위",
+			ReasonTemplate: "x",
+			Conditions:     RecommendationRuleConditions{MaxSatisfaction: intPtr(9)},
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("tunes built-in rules but does not delete them", func(t *testing.T) {
+		svc, repo := newTestRecommendationRules()
+		userID := uuid.New()
+		rules, err := svc.GetRules(userID.String())
+		assertNil(t, err)
+		builtIn := rules[0]
+
+		disabled := false
+		weight := 5.0
+		rule, err := svc.UpdateRule(userID.String(), builtIn.ID.String(), &UpdateRecommendationRuleRequest{Enabled: &disabled, Weight: &weight})
+		assertNil(t, err)
+		assertEqual(t, false, rule.Enabled)
+		assertEqual(t, 5.0, rule.Weight)
+		assertNotNil(t, rule.MaxSatisfaction)
+		// The first edit stores the whole built-in set.
+		assertEqual(t, 2, len(repo.rules))
+		stored := repo.ruleByKey(userID, models.RecommendationRuleLowSatisfaction)
+		assertEqual(t, builtIn.ID, stored.ID)
+		assertEqual(t, false, stored.Enabled)
+		assertEqual(t, false, stored.CreatedAt.IsZero())
+
+		err = svc.DeleteRule(userID.String(), builtIn.ID.String())
+		assertAppErrorCode(t, err, http.StatusForbidden)
+
+		_, err = svc.UpdateRule(uuid.NewString(), builtIn.ID.String(), &UpdateRecommendationRuleRequest{Enabled: &disabled})
+		assertAppErrorCode(t, err, http.StatusForbidden)
+		_, err = svc.UpdateRule(userID.String(), uuid.NewString(), &UpdateRecommendationRuleRequest{Enabled: &disabled})
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("deletes custom rules", func(t *testing.T) {
+		svc, repo := newTestRecommendationRules()
+		userID := uuid.New()
+		rule, err := svc.CreateRule(userID.String(), &CreateRecommendationRuleRequest{
+			Name:           "오래된 구독",
+			ReasonTemplate: "x",
+			Conditions:     RecommendationRuleConditions{MinTenureDays: intPtr(365)},
+		})
+		assertNil(t, err)
+
+		assertNil(t, svc.DeleteRule(userID.String(), rule.ID.String()))
+		assertEqual(t, 2, len(repo.rules))
+	})
+}
+
+func TestGetRecommendations_Rules(t *testing.T) {
+	t.Run("lists fired rules and sums their weights", func(t *testing.T) {
+		repo := newMockRepo()
+		rules, _ := newTestRecommendationRules()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Rules: rules})
+		userID := uuid.New()
+
+		repo.seedSubscriptionWithDetails(userID, "Cheap", 1000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+		repo.seedSubscriptionWithDetails(userID, "Bad", 30000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		assertEqual(t, "만족도 낮음", recs[0].Reason)
+		assertEqual(t, 3.0, recs[0].Score)
+		assertEqual(t, 2, len(recs[0].FiredRules))
+		assertEqual(t, models.RecommendationRuleLowSatisfaction, *recs[0].FiredRules[0].Key)
+		if recs[0].FiredRules[0].RuleID == "" {
+			t.Error("expected stored rule ID")
+		}
+	})
+
+	t.Run("skips disabled rules", func(t *testing.T) {
+		repo := newMockRepo()
+		rules, _ := newTestRecommendationRules()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Rules: rules})
+		userID := uuid.New()
+		repo.seedSubscriptionWithDetails(userID, "Bad", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+		repo.seedSubscriptionWithDetails(userID, "Other", 9000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+
+		disabled := false
+		ruleID := builtInRuleID(userID, models.RecommendationRuleLowSatisfaction).String()
+		_, err := rules.UpdateRule(userID.String(), ruleID, &UpdateRecommendationRuleRequest{Enabled: &disabled})
+		assertNil(t, err)
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 0, len(recs))
+	})
+
+	t.Run("renders custom templates with tenure and billing facts", func(t *testing.T) {
+		repo := newMockRepo()
+		rules, _ := newTestRecommendationRules()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Rules: rules})
+		userID := uuid.New()
+
+		sub := repo.seedSubscriptionWithDetails(userID, "Old", 12000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(4), nil)
+		sub.StartDate = time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+		sub.NextBillingDate = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+		fresh := repo.seedSubscriptionWithDetails(userID, "New", 12000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(4), nil)
+		fresh.StartDate = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		fresh.NextBillingDate = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+		weight := 4.0
+		_, err := rules.CreateRule(userID.String(), &CreateRecommendationRuleRequest{
+			Name:           "장기 구독",
+			Weight:         &weight,
+			ReasonTemplate: "{serviceName} {tenureDays}일째 구독, 마지막 결제 {daysSinceBilling}일 전, 월 {monthlyAmount}원",
+			Conditions:     RecommendationRuleConditions{MinTenureDays: intPtr(180), MinDaysSinceBilling: intPtr(10)},
+		})
+		assertNil(t, err)
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		assertEqual(t, "Old", recs[0].ServiceName)
+		assertEqual(t, "Old 365일째 구독, 마지막 결제 14일 전, 월 12,000원", recs[0].Reason)
+		assertEqual(t, 4.0, recs[0].Score)
+	})
+
+	t.Run("percentile condition follows the configured cut-off", func(t *testing.T) {
+		repo := newMockRepo()
+		rules, _ := newTestRecommendationRules()
+		svc := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Rules: rules})
+		userID := uuid.New()
+
+		for i, amount := range []int{1000, 2000, 3000, 4000} {
+			repo.seedSubscriptionWithDetails(userID, "Sub"+string(rune('A'+i)), amount, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(3), nil)
+		}
+
+		recs, err := svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 1, len(recs))
+		assertEqual(t, "SubD", recs[0].ServiceName)
+
+		ruleID := builtInRuleID(userID, models.RecommendationRuleHighCostLowSatisfaction).String()
+		_, err = rules.UpdateRule(userID.String(), ruleID, &UpdateRecommendationRuleRequest{
+			Conditions: &RecommendationRuleConditions{MaxSatisfaction: intPtr(3), MinCostPercentile: floatValuePtr(50)},
+		})
+		assertNil(t, err)
+		recs, err = svc.GetRecommendations(userID.String())
+		assertNil(t, err)
+		assertEqual(t, 2, len(recs))
+	})
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/repositories"
+)
+
+// RecommendationScheduler periodically evaluates the recommendations of every
+// user with an active subscription and writes new ones to the inbox, so
+// entries appear whether or not the user opens the dashboard.
+type RecommendationScheduler struct {
+	subRepo   repositories.SubscriptionRepository
+	dashboard *DashboardService
+	cfg       config.RecommendationConfig
+}
+
+// NewRecommendationScheduler creates a new RecommendationScheduler.
+func NewRecommendationScheduler(subRepo repositories.SubscriptionRepository, dashboard *DashboardService, cfg config.RecommendationConfig) *RecommendationScheduler {
+	return &RecommendationScheduler{subRepo: subRepo, dashboard: dashboard, cfg: cfg}
+}
+
+// Start runs the scheduler every cfg.Interval until ctx is cancelled.
+// The first run happens immediately.
+func (s *RecommendationScheduler) Start(ctx context.Context) {
+	interval := s.cfg.Interval
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			slog.Error("해지 추천 스케줄러 실행 실패", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce records the recommendations of every user with an active
+// subscription and returns the number of users evaluated. A failure for one
+// user is logged and does not stop the others.
+func (s *RecommendationScheduler) RunOnce(ctx context.Context) (int, error) {
+	userIDs, err := s.subRepo.FindActiveUserIDs()
+	if err != nil {
+		return 0, fmt.Errorf("load users with active subscriptions: %w", err)
+	}
+
+	evaluated := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return evaluated, ctx.Err()
+		}
+		if err := s.dashboard.RecordRecommendations(userID); err != nil {
+			slog.Error("해지 추천 기록 실패", "userID", userID, "error", err)
+			continue
+		}
+		evaluated++
+	}
+	return evaluated, nil
+}
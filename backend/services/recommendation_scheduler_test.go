@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+func TestRecommendationScheduler_RecordsEveryActiveUser(t *testing.T) {
+	repo := newMockRepo()
+	inboxRepo := newMockNotificationRepo()
+	dashboard := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Inbox: NewInboxService(inboxRepo, nil)})
+	scheduler := NewRecommendationScheduler(repo, dashboard, config.RecommendationConfig{})
+
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	repo.seedSubscriptionWithDetails(alice, "BadService", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+	repo.seedSubscriptionWithDetails(bob, "MehService", 9000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(2), nil)
+	repo.seedSubscriptionWithDetails(bob, "GoodService", 9000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+	repo.seedSubscriptionWithDetails(carol, "PausedService", 9000, models.BillingCycleMonthly, models.SubscriptionStatusPaused, intPtr(1), nil)
+
+	for i := 0; i < 2; i++ {
+		evaluated, err := scheduler.RunOnce(context.Background())
+		assertNil(t, err)
+		assertEqual(t, evaluated, 2)
+	}
+
+	assertEqual(t, len(inboxRepo.byType(alice, models.NotificationTypeRecommendation)), 1)
+	bobEntries := inboxRepo.byType(bob, models.NotificationTypeRecommendation)
+	if len(bobEntries) != 1 {
+		t.Fatalf("expected 1 inbox entry for bob, got %d", len(bobEntries))
+	}
+	assertEqual(t, bobEntries[0].Title, "MehService 해지 추천")
+	assertEqual(t, len(inboxRepo.byType(carol, models.NotificationTypeRecommendation)), 0)
+}
+
+func TestRecommendationScheduler_StopsWhenCancelled(t *testing.T) {
+	repo := newMockRepo()
+	dashboard := NewDashboardServiceWithDeps(repo, newMockShareRepo(), DashboardDeps{Inbox: NewInboxService(newMockNotificationRepo(), nil)})
+	scheduler := NewRecommendationScheduler(repo, dashboard, config.RecommendationConfig{})
+	repo.seedSubscriptionWithDetails(uuid.New(), "BadService", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	evaluated, err := scheduler.RunOnce(ctx)
+	assertEqual(t, err, context.Canceled)
+	assertEqual(t, evaluated, 0)
+}
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/utils"
+)
+
+// Report export formats.
+const (
+	ReportExportCSV  = "csv"
+	ReportExportXLSX = "xlsx"
+	ReportExportPDF  = "pdf"
+)
+
+// reportExportMaxMonths is the longest period a report export may cover.
+const reportExportMaxMonths = 36
+
+// ReportExport holds the data rendered into an exported report. The monthly
+// trend covers the requested period; the other sections describe the
+// subscriptions as they are today.
+type ReportExport struct {
+	From              time.Time
+	To                time.Time
+	GeneratedAt       time.Time
+	CategoryBreakdown []CategoryBreakdown
+	MonthlyTrend      []MonthlyTrend
+	AverageCost       AverageCost
+	Summary           ReportSummary
+	Subscriptions     []ReportExportRow
+}
+
+// ReportExportRow is one line of the exported subscription table.
+type ReportExportRow struct {
+	ServiceName       string
+	CategoryName      string
+	Status            models.SubscriptionStatus
+	BillingCycle      models.BillingCycle
+	Amount            int
+	MonthlyAmount     int // personal monthly-equivalent amount
+	Shared            bool
+	StartDate         time.Time
+	NextBillingDate   time.Time
+	SatisfactionScore *int
+}
+
+// ReportFile is an export ready to be written to the client. Its tables are
+// laid out as sections; the full report additionally keeps its data for the
+// charts drawn into PDFs.
+type ReportFile struct {
+	Filename    string
+	ContentType string
+	title       string
+	sections    []reportSection
+	export      *ReportExport
+	format      string
+	font        []byte
+}
+
+// Write renders the report into w. XLSX and PDF documents are assembled in
+// memory before they are written out, which the export period limit keeps
+// small.
+func (f *ReportFile) Write(w io.Writer) error {
+	switch f.format {
+	case ReportExportCSV:
+		return writeReportCSV(w, f.sections)
+	case ReportExportXLSX:
+		return writeReportXLSX(w, f.sections)
+	default:
+		if f.export != nil {
+			return writeReportPDF(w, f.export, f.font)
+		}
+		return writeTablesPDF(w, f.title, f.sections, f.font)
+	}
+}
+
+// ReportExporter renders reports as CSV, XLSX or PDF files.
+type ReportExporter struct {
+	reports *ReportService
+	font    []byte
+}
+
+// NewReportExporter creates a new ReportExporter. fontFile is a TrueType font
+// with Hangul glyphs used for PDF text; it is read and checked once here. It
+// may be empty, in which case PDF exports are refused, since the built-in PDF
+// fonts cannot render Korean.
+func NewReportExporter(reports *ReportService, fontFile string) (*ReportExporter, error) {
+	exporter := &ReportExporter{reports: reports}
+	if fontFile == "" {
+		return exporter, nil
+	}
+
+	font, err := os.ReadFile(fontFile)
+	if err != nil {
+		return nil, fmt.Errorf("read report font: %w", err)
+	}
+	// gofpdf only embeds TrueType outlines and logs, rather than returns,
+	// most parse errors, so the signature is checked up front.
+	if !bytes.HasPrefix(font, []byte{0, 1, 0, 0}) && !bytes.HasPrefix(font, []byte("true")) {
+		return nil, fmt.Errorf("report font %s is not a TrueType font", fontFile)
+	}
+	if _, err := newReportPDF(font); err != nil {
+		return nil, err
+	}
+	exporter.font = font
+	return exporter, nil
+}
+
+// reportContentType returns the MIME type of an export format.
+func reportContentType(format string) (string, *utils.AppError) {
+	contentType, ok := map[string]string{
+		ReportExportCSV:  "text/csv; charset=utf-8",
+		ReportExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		ReportExportPDF:  "application/pdf",
+	}[format]
+	if !ok {
+		return "", utils.ErrBadRequest("format은 csv, xlsx, pdf 중 하나여야 합니다")
+	}
+	return contentType, nil
+}
+
+// contentType returns the MIME type of an export format the exporter can
+// render. PDFs need a configured font.
+func (e *ReportExporter) contentType(format string) (string, *utils.AppError) {
+	contentType, appErr := reportContentType(format)
+	if appErr != nil {
+		return "", appErr
+	}
+	if format == ReportExportPDF && e.font == nil {
+		return "", utils.ErrBadRequest("PDF 내보내기에 필요한 한글 글꼴이 설정되지 않았습니다")
+	}
+	return contentType, nil
+}
+
+// Export gathers the report data for the period and returns a file to be
+// written. from defaults to the first day of the month 11 months ago and to
+// defaults to today, in the user's time zone.
+func (e *ReportExporter) Export(userID, format string, from, to *time.Time) (*ReportFile, error) {
+	contentType, appErr := e.contentType(format)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	export, err := e.reports.GetExport(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportFile{
+		Filename: fmt.Sprintf("subkeep-report-%s_%s.%s",
+			export.From.Format("2006-01-02"), export.To.Format("2006-01-02"), format),
+		ContentType: contentType,
+		title:       "SubKeep 구독 리포트",
+		sections:    reportSections(export),
+		export:      export,
+		format:      format,
+		font:        e.font,
+	}, nil
+}
+
+// GetExport collects the report data for an export covering [from, to].
+// Nil bounds default to the last 12 months up to today.
+func (s *ReportService) GetExport(userID string, from, to *time.Time) (*ReportExport, error) {
+	today := s.clock.Today(userID)
+	end := today
+	if to != nil {
+		end = truncateDate(*to)
+	}
+	start := monthStart(end).AddDate(0, -11, 0)
+	if from != nil {
+		start = truncateDate(*from)
+	}
+	if end.Before(start) {
+		return nil, utils.ErrBadRequest("to는 from 이후 날짜여야 합니다")
+	}
+	if !end.Before(start.AddDate(0, reportExportMaxMonths, 0)) {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("내보내기 기간은 최대 %d개월입니다", reportExportMaxMonths))
+	}
+
+	overview, err := s.GetOverview(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shareMap := buildShareMap(s.shareRepo, userID)
+	histories, err := s.loadHistories(userID, shareMap, monthStart(start))
+	if err != nil {
+		slog.Error("리포트 내보내기 이력 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("리포트를 내보낼 수 없습니다")
+	}
+
+	subs := make([]*models.Subscription, 0, len(histories))
+	for _, h := range histories {
+		if h.end.IsZero() {
+			subs = append(subs, h.sub)
+		}
+	}
+
+	return &ReportExport{
+		From:              start,
+		To:                end,
+		GeneratedAt:       s.clock.Now(userID),
+		CategoryBreakdown: overview.CategoryBreakdown,
+		MonthlyTrend:      monthlySpending(histories, start, end, today),
+		AverageCost:       overview.AverageCost,
+		Summary:           overview.Summary,
+		Subscriptions:     newReportExportRows(subs, shareMap),
+	}, nil
+}
+
+// newReportExportRows builds the subscription table, ordered by status and
+// then by personal monthly amount, largest first.
+func newReportExportRows(subs []*models.Subscription, shareMap map[string]*models.SubscriptionShare) []ReportExportRow {
+	statusOrder := map[models.SubscriptionStatus]int{
+		models.SubscriptionStatusActive:    0,
+		models.SubscriptionStatusPaused:    1,
+		models.SubscriptionStatusCancelled: 2,
+	}
+
+	rows := make([]ReportExportRow, len(subs))
+	for i, sub := range subs {
+		share := shareMap[sub.ID.String()]
+		row := ReportExportRow{
+			ServiceName:       sub.ServiceName,
+			CategoryName:      "미분류",
+			Status:            sub.Status,
+			BillingCycle:      sub.BillingCycle,
+			Amount:            sub.Amount,
+			MonthlyAmount:     personalMonthlyAmount(sub, share),
+			Shared:            share != nil,
+			StartDate:         sub.StartDate,
+			NextBillingDate:   sub.NextBillingDate,
+			SatisfactionScore: sub.SatisfactionScore,
+		}
+		if sub.Category != nil {
+			row.CategoryName = sub.Category.Name
+		}
+		rows[i] = row
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Status != rows[j].Status {
+			return statusOrder[rows[i].Status] < statusOrder[rows[j].Status]
+		}
+		if rows[i].MonthlyAmount != rows[j].MonthlyAmount {
+			return rows[i].MonthlyAmount > rows[j].MonthlyAmount
+		}
+		return rows[i].ServiceName < rows[j].ServiceName
+	})
+	return rows
+}
+
+// reportSection is a titled table shared by the CSV and XLSX renderers.
+type reportSection struct {
+	title  string
+	header []string
+	rows   [][]any
+}
+
+// reportSections lays out the export as tables in display order.
+func reportSections(export *ReportExport) []reportSection {
+	mostExpensive := ""
+	if export.Summary.MostExpensive != nil {
+		mostExpensive = *export.Summary.MostExpensive
+	}
+	summary := reportSection{
+		title:  "요약",
+		header: []string{"항목", "값"},
+		rows: [][]any{
+			{"기간", export.From.Format("2006-01-02") + " ~ " + export.To.Format("2006-01-02")},
+			{"생성 일시", export.GeneratedAt.Format("2006-01-02 15:04")},
+			{"전체 구독 수", export.Summary.TotalSubscriptions},
+			{"활성 구독 수", export.Summary.ActiveCount},
+			{"일시정지 구독 수", export.Summary.PausedCount},
+			{"가장 비싼 구독", mostExpensive},
+			{"가장 비싼 구독 금액", export.Summary.MostExpensiveAmount},
+			{"평균 만족도", export.Summary.AverageSatisfaction},
+			{"누적 총 지출", export.Summary.TotalSpent},
+			{"주간 평균 비용", export.AverageCost.Weekly},
+			{"월간 평균 비용", export.AverageCost.Monthly},
+			{"연간 평균 비용", export.AverageCost.Annual},
+		},
+	}
+
+	categories := reportSection{
+		title:  "카테고리별 지출",
+		header: []string{"카테고리", "월 금액", "비율(%)", "구독 수"},
+	}
+	for _, c := range export.CategoryBreakdown {
+		categories.rows = append(categories.rows, []any{c.CategoryName, c.MonthlyAmount, c.Percentage, c.Count})
+	}
+
+	trend := reportSection{
+		title:  "월별 추이",
+		header: []string{"월", "결제 금액", "월 환산 금액", "구독 수"},
+	}
+	for _, m := range export.MonthlyTrend {
+		trend.rows = append(trend.rows, []any{fmt.Sprintf("%04d-%02d", m.Year, m.Month), m.CashAmount, m.AmortizedAmount, m.Count})
+	}
+
+	subscriptions := reportSection{
+		title:  "구독 목록",
+		header: []string{"서비스", "카테고리", "상태", "결제 주기", "결제 금액", "월 환산 본인 부담", "공유", "시작일", "다음 결제일", "만족도"},
+	}
+	for _, r := range export.Subscriptions {
+		satisfaction := ""
+		if r.SatisfactionScore != nil {
+			satisfaction = strconv.Itoa(*r.SatisfactionScore)
+		}
+		shared := ""
+		if r.Shared {
+			shared = "Y"
+		}
+		subscriptions.rows = append(subscriptions.rows, []any{
+			r.ServiceName, r.CategoryName, subscriptionStatusLabel(r.Status), billingCycleLabel(r.BillingCycle),
+			r.Amount, r.MonthlyAmount, shared,
+			r.StartDate.Format("2006-01-02"), r.NextBillingDate.Format("2006-01-02"), satisfaction,
+		})
+	}
+
+	return []reportSection{summary, categories, trend, subscriptions}
+}
+
+// writeReportCSV writes every section as a titled table separated by blank
+// lines. A byte order mark lets spreadsheet applications detect UTF-8.
+func writeReportCSV(w io.Writer, sections []reportSection) error {
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	for i, section := range sections {
+		if i > 0 {
+			if err := cw.Write(nil); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write([]string{section.title}); err != nil {
+			return err
+		}
+		if err := cw.Write(section.header); err != nil {
+			return err
+		}
+		for _, row := range section.rows {
+			record := make([]string, len(row))
+			for j, v := range row {
+				record[j] = formatReportCell(v)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		// Flush per section so rows reach the client as they are produced.
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatReportCell renders a table value as CSV text.
+func formatReportCell(v any) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// writeReportXLSX writes each section to its own worksheet. Rows go through
+// excelize's stream writer, which spills large sheets to temporary files
+// instead of building them in memory.
+func writeReportXLSX(w io.Writer, sections []reportSection) error {
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			slog.Warn("엑셀 파일 정리 실패", "error", err)
+		}
+	}()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	for i, section := range sections {
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", section.title); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(section.title); err != nil {
+			return err
+		}
+
+		sw, err := f.NewStreamWriter(section.title)
+		if err != nil {
+			return err
+		}
+		header := make([]any, len(section.header))
+		for j, h := range section.header {
+			header[j] = excelize.Cell{StyleID: headerStyle, Value: h}
+		}
+		if err := sw.SetRow("A1", header); err != nil {
+			return err
+		}
+		for j, row := range section.rows {
+			cell, err := excelize.CoordinatesToCellName(1, j+2)
+			if err != nil {
+				return err
+			}
+			if err := sw.SetRow(cell, row); err != nil {
+				return err
+			}
+		}
+		if err := sw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// PDF layout, in millimetres on an A4 portrait page.
+const (
+	pdfMargin      = 15.0
+	pdfPageWidth   = 210.0 - 2*pdfMargin
+	pdfLineHeight  = 6.0
+	pdfChartHeight = 60.0
+	pdfFontFamily  = "report"
+)
+
+// Chart colours for cash and amortized amounts, and the fallback for
+// categories without a colour.
+var (
+	pdfCashColor      = [3]int{0x45, 0xB7, 0xD1}
+	pdfAmortizedColor = [3]int{0xFF, 0x6B, 0x6B}
+	pdfDefaultColor   = [3]int{0xB2, 0xBE, 0xC3}
+)
+
+// reportPDF wraps gofpdf with the font of the report.
+type reportPDF struct {
+	*gofpdf.Fpdf
+	family string
+}
+
+// newReportPDF starts an A4 document whose text is set in font, a TrueType
+// font with Hangul glyphs.
+func newReportPDF(font []byte) (*reportPDF, error) {
+	doc := gofpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	doc.SetAutoPageBreak(true, pdfMargin)
+
+	doc.AddUTF8FontFromBytes(pdfFontFamily, "", font)
+	if err := doc.Error(); err != nil {
+		return nil, fmt.Errorf("load report font: %w", err)
+	}
+	return &reportPDF{Fpdf: doc, family: pdfFontFamily}, nil
+}
+
+// writeReportPDF renders the report as a PDF with charts drawn from vector
+// primitives. gofpdf assembles the document before writing it out, so pages
+// are held in memory until the output is written to w.
+func writeReportPDF(w io.Writer, export *ReportExport, font []byte) error {
+	pdf, err := newReportPDF(font)
+	if err != nil {
+		return err
+	}
+	doc := pdf.Fpdf
+
+	doc.AddPage()
+	pdf.text(16, "SubKeep 구독 리포트")
+	pdf.text(9, fmt.Sprintf("기간 %s ~ %s · 생성 %s",
+		export.From.Format("2006-01-02"), export.To.Format("2006-01-02"),
+		export.GeneratedAt.Format("2006-01-02 15:04")))
+	doc.Ln(4)
+
+	sections := reportSections(export)
+
+	pdf.heading("요약")
+	pdf.table([]float64{70, 60}, sections[0].header, sections[0].rows)
+
+	pdf.heading("월별 추이")
+	pdf.trendChart(export.MonthlyTrend)
+	pdf.table([]float64{40, 45, 45, 30}, sections[2].header, sections[2].rows)
+
+	pdf.heading("카테고리별 지출")
+	pdf.categoryChart(export.CategoryBreakdown)
+	pdf.table([]float64{60, 45, 35, 30}, sections[1].header, sections[1].rows)
+
+	doc.AddPage()
+	pdf.heading("구독 목록")
+	pdf.table([]float64{30, 22, 14, 14, 18, 20, 10, 18, 18, 16}, sections[3].header, sections[3].rows)
+
+	return doc.Output(w)
+}
+
+// writeTablesPDF renders sections as a PDF of tables with equal column
+// widths, for reports without charts.
+func writeTablesPDF(w io.Writer, title string, sections []reportSection, font []byte) error {
+	pdf, err := newReportPDF(font)
+	if err != nil {
+		return err
+	}
+
+	pdf.AddPage()
+	pdf.text(16, title)
+	pdf.Ln(4)
+	for _, section := range sections {
+		pdf.heading(section.title)
+		widths := make([]float64, len(section.header))
+		for i := range widths {
+			widths[i] = pdfPageWidth / float64(len(widths))
+		}
+		pdf.table(widths, section.header, section.rows)
+	}
+	return pdf.Output(w)
+}
+
+// text writes a full-width line of text.
+func (p *reportPDF) text(size float64, s string) {
+	p.SetFont(p.family, "", size)
+	p.CellFormat(pdfPageWidth, size*0.5, s, "", 1, "L", false, 0, "")
+}
+
+// heading writes a section heading, starting a new page when the section
+// would begin at the bottom of the current one.
+func (p *reportPDF) heading(s string) {
+	_, pageHeight := p.GetPageSize()
+	if p.GetY() > pageHeight-pdfMargin-40 {
+		p.AddPage()
+	}
+	p.Ln(3)
+	p.text(12, s)
+	p.Ln(1)
+}
+
+// table writes a bordered table with a shaded header row.
+func (p *reportPDF) table(widths []float64, header []string, rows [][]any) {
+	p.SetFont(p.family, "", 8)
+	p.SetFillColor(0xEE, 0xEE, 0xEE)
+	for i, h := range header {
+		p.CellFormat(widths[i], pdfLineHeight, h, "1", 0, "C", true, 0, "")
+	}
+	p.Ln(-1)
+	for _, row := range rows {
+		for i, v := range row {
+			align := "L"
+			text := formatReportCell(v)
+			switch value := v.(type) {
+			case int:
+				align, text = "R", formatWon(value)
+			case float64:
+				align, text = "R", strconv.FormatFloat(value, 'f', 1, 64)
+			}
+			p.CellFormat(widths[i], pdfLineHeight, text, "1", 0, align, false, 0, "")
+		}
+		p.Ln(-1)
+	}
+}
+
+// trendChart draws paired cash and amortized bars for each month.
+func (p *reportPDF) trendChart(trend []MonthlyTrend) {
+	if len(trend) == 0 {
+		return
+	}
+	p.ensureSpace(pdfChartHeight + 16)
+
+	maxAmount := 0
+	for _, m := range trend {
+		maxAmount = max(maxAmount, m.CashAmount, m.AmortizedAmount)
+	}
+	if maxAmount == 0 {
+		maxAmount = 1
+	}
+
+	left, top := pdfMargin+18, p.GetY()
+	width := pdfPageWidth - 18
+	bottom := top + pdfChartHeight
+
+	p.SetFont(p.family, "", 6)
+	p.SetDrawColor(0xDD, 0xDD, 0xDD)
+	for i := 0; i <= 4; i++ {
+		y := bottom - pdfChartHeight*float64(i)/4
+		p.Line(left, y, left+width, y)
+		p.SetXY(pdfMargin, y-1.5)
+		p.CellFormat(17, 3, formatWon(maxAmount*i/4), "", 0, "R", false, 0, "")
+	}
+
+	slot := width / float64(len(trend))
+	bar := slot * 0.35
+	for i, m := range trend {
+		x := left + slot*float64(i) + slot*0.15
+		p.bar(x, bottom, bar, pdfChartHeight*float64(m.CashAmount)/float64(maxAmount), pdfCashColor)
+		p.bar(x+bar, bottom, bar, pdfChartHeight*float64(m.AmortizedAmount)/float64(maxAmount), pdfAmortizedColor)
+		p.SetXY(left+slot*float64(i), bottom+1)
+		p.CellFormat(slot, 3, fmt.Sprintf("%02d/%02d", m.Year%100, m.Month), "", 0, "C", false, 0, "")
+	}
+
+	p.SetXY(left, bottom+5)
+	p.legend(pdfCashColor, "결제 금액")
+	p.legend(pdfAmortizedColor, "월 환산 금액")
+	p.SetY(bottom + 11)
+}
+
+// categoryChart draws a horizontal bar for each category's share of spending.
+func (p *reportPDF) categoryChart(breakdown []CategoryBreakdown) {
+	if len(breakdown) == 0 {
+		return
+	}
+	const rowHeight = 6.0
+	p.ensureSpace(rowHeight*float64(len(breakdown)) + 4)
+
+	maxAmount := 1
+	for _, c := range breakdown {
+		maxAmount = max(maxAmount, c.MonthlyAmount)
+	}
+
+	labelWidth, valueWidth := 40.0, 35.0
+	barWidth := pdfPageWidth - labelWidth - valueWidth
+	p.SetFont(p.family, "", 8)
+	for _, c := range breakdown {
+		y := p.GetY()
+		p.SetX(pdfMargin)
+		p.CellFormat(labelWidth, rowHeight, c.CategoryName, "", 0, "L", false, 0, "")
+		p.bar(pdfMargin+labelWidth, y+rowHeight-1, barWidth*float64(c.MonthlyAmount)/float64(maxAmount), rowHeight-2, hexColor(c.Color))
+		p.SetXY(pdfMargin+labelWidth+barWidth, y)
+		p.CellFormat(valueWidth, rowHeight, fmt.Sprintf("%s (%.1f%%)", formatWon(c.MonthlyAmount), c.Percentage), "", 1, "R", false, 0, "")
+	}
+	p.Ln(2)
+}
+
+// bar fills a rectangle of the given size whose bottom edge is at bottom.
+func (p *reportPDF) bar(x, bottom, width, height float64, color [3]int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	p.SetFillColor(color[0], color[1], color[2])
+	p.Rect(x, bottom-height, width, height, "F")
+}
+
+// legend writes a colour swatch followed by its label at the current position.
+func (p *reportPDF) legend(color [3]int, label string) {
+	x, y := p.GetXY()
+	p.bar(x, y+3, 3, 3, color)
+	p.SetXY(x+4, y)
+	p.SetFont(p.family, "", 7)
+	p.CellFormat(30, 3, label, "", 0, "L", false, 0, "")
+}
+
+// ensureSpace starts a new page when fewer than height millimetres remain.
+func (p *reportPDF) ensureSpace(height float64) {
+	_, pageHeight := p.GetPageSize()
+	if p.GetY()+height > pageHeight-pdfMargin {
+		p.AddPage()
+	}
+}
+
+// hexColor parses a "#RRGGBB" colour, falling back to a neutral grey.
+func hexColor(s string) [3]int {
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || len(s) != 7 {
+		return pdfDefaultColor
+	}
+	return [3]int{int(v >> 16 & 0xFF), int(v >> 8 & 0xFF), int(v & 0xFF)}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/utils"
+)
+
+// writeTestReportFont writes a TrueType font for PDF exports into a temporary
+// directory and returns its path.
+func writeTestReportFont(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.ttf")
+	if err := os.WriteFile(path, goregular.TTF, 0o600); err != nil {
+		t.Fatalf("write font: %v", err)
+	}
+	return path
+}
+
+// newTestReportExporter creates an exporter with a PDF font.
+func newTestReportExporter(t *testing.T, reports *ReportService) *ReportExporter {
+	t.Helper()
+	exporter, err := NewReportExporter(reports, writeTestReportFont(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return exporter
+}
+
+func newReportExporterForTest(t *testing.T) (*ReportExporter, uuid.UUID) {
+	repo := newMockSubRepoForReport()
+	svc := NewReportService(repo, newMockShareRepoForReport())
+	userID := uuid.New()
+
+	cat := rptMakeCategory("엔터테인먼트", "#FF6B6B")
+	seedReportSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, rptIntPtr(4), cat)
+	seedReportSub(repo, userID, "iCloud", 1100, models.BillingCycleMonthly, models.SubscriptionStatusPaused, nil, nil)
+
+	return newTestReportExporter(t, svc), userID
+}
+
+func exportReport(t *testing.T, exporter *ReportExporter, userID uuid.UUID, format string) []byte {
+	t.Helper()
+	file, err := exporter.Export(userID.String(), format, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		t.Fatalf("write %s: %v", format, err)
+	}
+	return buf.Bytes()
+}
+
+func TestReportExport_CSV(t *testing.T) {
+	exporter, userID := newReportExporterForTest(t)
+
+	out := string(exportReport(t, exporter, userID, ReportExportCSV))
+	if !strings.HasPrefix(out, "\uFEFF") {
+		t.Error("expected UTF-8 byte order mark")
+	}
+	for _, want := range []string{"요약", "카테고리별 지출", "월별 추이", "구독 목록", "Netflix,엔터테인먼트,활성,월간,17000,17000,", "iCloud,미분류,일시정지"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected CSV to contain %q", want)
+		}
+	}
+}
+
+func TestReportExport_XLSX(t *testing.T) {
+	exporter, userID := newReportExporterForTest(t)
+
+	f, err := excelize.OpenReader(bytes.NewReader(exportReport(t, exporter, userID, ReportExportXLSX)))
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if strings.Join(sheets, ",") != "요약,카테고리별 지출,월별 추이,구독 목록" {
+		t.Errorf("unexpected sheets %v", sheets)
+	}
+	rows, err := f.GetRows("구독 목록")
+	if err != nil {
+		t.Fatalf("read rows: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(rows))
+	}
+	if rows[1][0] != "Netflix" || rows[2][0] != "iCloud" {
+		t.Errorf("expected active subscription first, got %q, %q", rows[1][0], rows[2][0])
+	}
+	trend, _ := f.GetRows("월별 추이")
+	if len(trend) != 13 {
+		t.Errorf("expected 12 months of trend, got %d rows", len(trend)-1)
+	}
+}
+
+func TestReportExport_PDF(t *testing.T) {
+	exporter, userID := newReportExporterForTest(t)
+
+	out := exportReport(t, exporter, userID, ReportExportPDF)
+	if !bytes.HasPrefix(out, []byte("%PDF")) {
+		t.Errorf("expected PDF header, got %q", out[:min(len(out), 8)])
+	}
+}
+
+func TestReportExport_Filename(t *testing.T) {
+	exporter, userID := newReportExporterForTest(t)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	file, err := exporter.Export(userID.String(), ReportExportXLSX, &from, &to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Filename != "subkeep-report-2026-01-01_2026-06-30.xlsx" {
+		t.Errorf("unexpected filename %q", file.Filename)
+	}
+	if len(file.export.MonthlyTrend) != 6 {
+		t.Errorf("expected 6 months of trend, got %d", len(file.export.MonthlyTrend))
+	}
+}
+
+func TestReportExport_InvalidRequests(t *testing.T) {
+	exporter, userID := newReportExporterForTest(t)
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	tooFar := time.Date(2029, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		format   string
+		from, to *time.Time
+	}{
+		{"unknown format", "docx", nil, nil},
+		{"to before from", ReportExportCSV, &from, &before},
+		{"range too long", ReportExportCSV, &from, &tooFar},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := exporter.Export(userID.String(), tc.format, tc.from, tc.to)
+			appErr, ok := err.(*utils.AppError)
+			if !ok || appErr.Code != 400 {
+				t.Errorf("expected bad request, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewReportExporter_Font(t *testing.T) {
+	svc := NewReportService(newMockSubRepoForReport(), newMockShareRepoForReport())
+
+	t.Run("fails on a missing font", func(t *testing.T) {
+		_, err := NewReportExporter(svc, filepath.Join(t.TempDir(), "missing.ttf"))
+		assertError(t, err)
+	})
+
+	t.Run("fails on a file that is not a font", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "broken.ttf")
+		if err := os.WriteFile(path, []byte("not a font"), 0o600); err != nil {
+			t.Fatalf("write font: %v", err)
+		}
+		_, err := NewReportExporter(svc, path)
+		assertError(t, err)
+	})
+
+	t.Run("refuses PDF without a font", func(t *testing.T) {
+		exporter, err := NewReportExporter(svc, "")
+		assertNil(t, err)
+		userID := uuid.New()
+
+		_, err = exporter.Export(userID.String(), ReportExportPDF, nil, nil)
+		assertAppErrorCode(t, err, 400)
+		_, err = exporter.ExportShareReceivables(userID.String(), ReportExportPDF, nil, nil)
+		assertAppErrorCode(t, err, 400)
+
+		_, err = exporter.Export(userID.String(), ReportExportCSV, nil, nil)
+		assertNil(t, err)
+	})
+}
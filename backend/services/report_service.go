@@ -17,14 +17,22 @@ type ReportOverview struct {
 	MonthlyTrend      []MonthlyTrend      `json:"monthlyTrend"`
 	AverageCost       AverageCost         `json:"averageCost"`
 	Summary           ReportSummary       `json:"summary"`
+	Budgets           []BudgetProgress    `json:"budgets"`
+	Savings           RealizedSavings     `json:"savings"`
 }
 
-// MonthlyTrend represents the cost trend for a specific month.
+// MonthlyTrend represents the personal spending of a specific month.
+// CashAmount is what was charged in the month, so a yearly plan appears once
+// in full. AmortizedAmount spreads every plan evenly over the days it was
+// active, so a yearly plan appears as 1/12 each month. Amount equals
+// AmortizedAmount. Count is the number of subscriptions active in the month.
 type MonthlyTrend struct {
-	Year   int `json:"year"`
-	Month  int `json:"month"`
-	Amount int `json:"amount"`
-	Count  int `json:"count"`
+	Year            int `json:"year"`
+	Month           int `json:"month"`
+	Amount          int `json:"amount"`
+	CashAmount      int `json:"cashAmount"`
+	AmortizedAmount int `json:"amortizedAmount"`
+	Count           int `json:"count"`
 }
 
 // AverageCost holds weekly, monthly, and annual average costs.
@@ -42,21 +50,114 @@ type ReportSummary struct {
 	MostExpensive       *string `json:"mostExpensive,omitempty"`
 	MostExpensiveAmount int     `json:"mostExpensiveAmount"`
 	AverageSatisfaction float64 `json:"averageSatisfaction"`
+	TotalSpent          int     `json:"totalSpent"` // personal amount charged by every subscription ever held
+}
+
+// RealizedSavings summarises the savings accumulated by cancellations.
+// MonthlyRunRate is the monthly amount the recorded cancellations remove.
+type RealizedSavings struct {
+	Lifetime       int `json:"lifetime"`
+	YearToDate     int `json:"yearToDate"`
+	MonthlyRunRate int `json:"monthlyRunRate"`
+	Cancellations  int `json:"cancellations"`
+}
+
+// RealizedSavingEntry is a savings ledger entry with the amount realized so far.
+type RealizedSavingEntry struct {
+	*models.CancellationSaving
+	Realized int `json:"realized"`
+}
+
+// SavingsReport holds the realized savings summary and its ledger.
+type SavingsReport struct {
+	Summary RealizedSavings       `json:"summary"`
+	Entries []RealizedSavingEntry `json:"entries"`
+}
+
+// ValueQuadrantItem places one subscription on the cost-versus-satisfaction chart.
+type ValueQuadrantItem struct {
+	SubscriptionID    string `json:"subscriptionId"`
+	ServiceName       string `json:"serviceName"`
+	MonthlyAmount     int    `json:"monthlyAmount"`
+	SatisfactionScore *int   `json:"satisfactionScore"`
+	ValueScore        int    `json:"valueScore"`
+	Expensive         bool   `json:"expensive"`
+	Quadrant          string `json:"quadrant"`
+}
+
+// ValueQuadrantReport sorts active subscriptions into keep, review and cancel.
+// Subscriptions whose personal monthly cost is above CostThreshold, the median,
+// are expensive; those rated SatisfactionThreshold or higher are satisfying.
+// Each list is ordered by value score, best first.
+type ValueQuadrantReport struct {
+	CostThreshold         int                 `json:"costThreshold"`
+	SatisfactionThreshold int                 `json:"satisfactionThreshold"`
+	Keep                  []ValueQuadrantItem `json:"keep"`
+	Review                []ValueQuadrantItem `json:"review"`
+	Cancel                []ValueQuadrantItem `json:"cancel"`
 }
 
 // ReportService handles report-related business logic.
 type ReportService struct {
-	subRepo   repositories.SubscriptionRepository
-	shareRepo repositories.SubscriptionShareRepository
+	subRepo    repositories.SubscriptionRepository
+	shareRepo  repositories.SubscriptionShareRepository
+	clock      *UserClock
+	budgets    repositories.BudgetRepository
+	savings    repositories.CancellationSavingRepository
+	cache      *AggregateCache
+	priceRepo  repositories.PriceChangeRepository
+	statusRepo repositories.StatusChangeRepository
+	scoreRepo  repositories.SatisfactionChangeRepository
 }
 
-// NewReportService creates a new ReportService.
+// ReportDeps holds the optional dependencies of a ReportService. Any of them
+// may be nil.
+type ReportDeps struct {
+	Clock      *UserClock                                // nil: the system clock and default time zone are used
+	Budgets    repositories.BudgetRepository             // nil: the overview reports no budget progress
+	Savings    repositories.CancellationSavingRepository // nil: no realized savings are reported
+	Cache      *AggregateCache                           // nil: overviews are computed on every request
+	PriceRepo  repositories.PriceChangeRepository        // nil: the current prices are assumed to have always held
+	StatusRepo repositories.StatusChangeRepository       // nil: the current statuses are assumed to have always held
+	ScoreRepo  repositories.SatisfactionChangeRepository // nil: year-in-review reports list no satisfaction changes
+}
+
+// NewReportService creates a new ReportService without optional dependencies.
 func NewReportService(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository) *ReportService {
-	return &ReportService{subRepo: subRepo, shareRepo: shareRepo}
+	return NewReportServiceWithDeps(subRepo, shareRepo, ReportDeps{})
+}
+
+// NewReportServiceWithDeps creates a new ReportService with the given optional dependencies.
+func NewReportServiceWithDeps(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, deps ReportDeps) *ReportService {
+	return &ReportService{
+		subRepo:    subRepo,
+		shareRepo:  shareRepo,
+		clock:      deps.Clock,
+		budgets:    deps.Budgets,
+		savings:    deps.Savings,
+		cache:      deps.Cache,
+		priceRepo:  deps.PriceRepo,
+		statusRepo: deps.StatusRepo,
+		scoreRepo:  deps.ScoreRepo,
+	}
+}
+
+// Today returns the user's current local date.
+func (s *ReportService) Today(userID string) time.Time {
+	return s.clock.Today(userID)
 }
 
-// GetOverview returns the full report overview for a user.
+// GetOverview returns the full report overview for a user. Overviews are
+// cached per day, since the monthly trend ends at the user's current month.
 func (s *ReportService) GetOverview(userID string) (*ReportOverview, error) {
+	key := aggregateKey(userID, "overview", s.clock.Today(userID).Format("2006-01-02"))
+	return cachedAggregate(s.cache, key, func() (*ReportOverview, error) {
+		return s.buildOverview(userID)
+	})
+}
+
+// buildOverview computes the full report overview for a user.
+func (s *ReportService) buildOverview(userID string) (*ReportOverview, error) {
 	// Fetch active subscriptions.
 	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
 		Status:  "active",
@@ -82,40 +183,183 @@ func (s *ReportService) GetOverview(userID string) (*ReportOverview, error) {
 	// Build share map for personal amount calculation.
 	shareMap := buildShareMap(s.shareRepo, userID)
 
-	// Combine active + paused for trend calculation.
-	allSubs := append(activeSubs, pausedSubs...)
-
-	// Calculate personal monthly amounts for each subscription.
-	subCosts := make([]subWithCostEntry, 0, len(allSubs))
-	for _, sub := range allSubs {
-		monthly := sub.MonthlyAmount()
-		personal := monthly
-		if share, ok := shareMap[sub.ID.String()]; ok {
-			personal = share.PersonalAmount(monthly)
-		}
-		subCosts = append(subCosts, subWithCostEntry{sub: sub, personalAmount: personal})
-	}
-
 	// --- Category Breakdown (active only) ---
 	categoryBreakdown := s.buildCategoryBreakdown(activeSubs, shareMap)
 
+	// --- Subscription history over the trend window ---
+	today := s.clock.Today(userID)
+	histories, err := s.loadHistories(userID, shareMap, trendStart(today))
+	if err != nil {
+		slog.Error("리포트 구독 이력 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("리포트 데이터를 조회할 수 없습니다")
+	}
+
 	// --- Monthly Trend (last 12 months) ---
-	monthlyTrend := s.buildMonthlyTrend(subCosts)
+	monthlyTrend := buildMonthlyTrend(histories, today)
 
 	// --- Average Cost (active subscriptions only) ---
 	averageCost := s.buildAverageCost(activeSubs, shareMap)
 
 	// --- Report Summary ---
 	summary := s.buildSummary(activeSubs, pausedSubs, shareMap)
+	summary.TotalSpent, err = s.totalSpent(userID, histories, shareMap, today)
+	if err != nil {
+		slog.Error("리포트 누적 지출 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("리포트 데이터를 조회할 수 없습니다")
+	}
+
+	// --- Realized Savings ---
+	savings, err := s.loadSavings(userID)
+	if err != nil {
+		slog.Error("리포트 절약 기록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("리포트 데이터를 조회할 수 없습니다")
+	}
+	realized, _ := buildRealizedSavings(savings, today)
 
 	return &ReportOverview{
 		CategoryBreakdown: categoryBreakdown,
 		MonthlyTrend:      monthlyTrend,
 		AverageCost:       averageCost,
 		Summary:           summary,
+		Budgets:           loadBudgetProgress(s.budgets, userID, activeSubs, shareMap),
+		Savings:           realized,
 	}, nil
 }
 
+// GetSavings returns the realized savings summary and the ledger of
+// cancellations it is built from.
+func (s *ReportService) GetSavings(userID string) (*SavingsReport, error) {
+	savings, err := s.loadSavings(userID)
+	if err != nil {
+		slog.Error("절약 기록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("절약 기록을 조회할 수 없습니다")
+	}
+	summary, entries := buildRealizedSavings(savings, s.clock.Today(userID))
+	return &SavingsReport{Summary: summary, Entries: entries}, nil
+}
+
+// GetValueQuadrant sorts the user's active subscriptions into the
+// cost-versus-satisfaction quadrants.
+func (s *ReportService) GetValueQuadrant(userID string) (*ValueQuadrantReport, error) {
+	activeSubs, _, err := s.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("가치 사분면 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("가치 사분면을 조회할 수 없습니다")
+	}
+
+	shareMap := buildShareMap(s.shareRepo, userID)
+	today := s.clock.Today(userID)
+
+	amounts := make([]int, len(activeSubs))
+	for i, sub := range activeSubs {
+		amounts[i] = personalMonthlyAmount(sub, shareMap[sub.ID.String()])
+	}
+	threshold := medianAmount(amounts)
+
+	items := make([]ValueQuadrantItem, len(activeSubs))
+	for i, sub := range activeSubs {
+		expensive := amounts[i] > threshold
+		items[i] = ValueQuadrantItem{
+			SubscriptionID:    sub.ID.String(),
+			ServiceName:       sub.ServiceName,
+			MonthlyAmount:     amounts[i],
+			SatisfactionScore: sub.SatisfactionScore,
+			ValueScore:        valueScore(sub, shareMap[sub.ID.String()], today),
+			Expensive:         expensive,
+			Quadrant:          valueQuadrant(sub, expensive),
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ValueScore != items[j].ValueScore {
+			return items[i].ValueScore > items[j].ValueScore
+		}
+		return items[i].ServiceName < items[j].ServiceName
+	})
+
+	report := &ValueQuadrantReport{
+		CostThreshold:         threshold,
+		SatisfactionThreshold: valueQuadrantSatisfiedScore,
+		Keep:                  make([]ValueQuadrantItem, 0),
+		Review:                make([]ValueQuadrantItem, 0),
+		Cancel:                make([]ValueQuadrantItem, 0),
+	}
+	for _, item := range items {
+		switch item.Quadrant {
+		case ValueQuadrantKeep:
+			report.Keep = append(report.Keep, item)
+		case ValueQuadrantCancel:
+			report.Cancel = append(report.Cancel, item)
+		default:
+			report.Review = append(report.Review, item)
+		}
+	}
+	return report, nil
+}
+
+// medianAmount returns the rounded median of the amounts, or 0 when empty.
+func medianAmount(amounts []int) int {
+	if len(amounts) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), amounts...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return int(math.Round(float64(sorted[mid-1]+sorted[mid]) / 2))
+}
+
+// loadSavings returns the user's savings ledger, or nothing without a repository.
+func (s *ReportService) loadSavings(userID string) ([]*models.CancellationSaving, error) {
+	if s.savings == nil {
+		return nil, nil
+	}
+	return s.savings.FindByUserID(userID)
+}
+
+// buildRealizedSavings accrues each cancellation's monthly amount day by day
+// from the cancellation date up to today, i.e. monthly × 12 × days / 365.
+// The year-to-date figure only counts days in today's calendar year.
+func buildRealizedSavings(savings []*models.CancellationSaving, today time.Time) (RealizedSavings, []RealizedSavingEntry) {
+	yearStart := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	accrue := func(monthly int, from time.Time) int {
+		return accrueSaving(monthly, from, today)
+	}
+
+	summary := RealizedSavings{Cancellations: len(savings)}
+	entries := make([]RealizedSavingEntry, 0, len(savings))
+	for _, saving := range savings {
+		cancelled := truncateDate(saving.CancelledOn)
+		realized := accrue(saving.MonthlyAmount, cancelled)
+
+		ytdFrom := cancelled
+		if ytdFrom.Before(yearStart) {
+			ytdFrom = yearStart
+		}
+
+		summary.Lifetime += realized
+		summary.YearToDate += accrue(saving.MonthlyAmount, ytdFrom)
+		summary.MonthlyRunRate += saving.MonthlyAmount
+		entries = append(entries, RealizedSavingEntry{CancellationSaving: saving, Realized: realized})
+	}
+	return summary, entries
+}
+
+// accrueSaving returns the amount a monthly saving accrues over the days in
+// [from, to), i.e. monthly × 12 × days / 365.
+func accrueSaving(monthly int, from, to time.Time) int {
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(monthly) * 12 * float64(days) / 365))
+}
+
 // buildCategoryBreakdown calculates per-category spending breakdown for active subscriptions.
 func (s *ReportService) buildCategoryBreakdown(activeSubs []*models.Subscription, shareMap map[string]*models.SubscriptionShare) []CategoryBreakdown {
 	type catGroup struct {
@@ -185,49 +429,73 @@ func (s *ReportService) buildCategoryBreakdown(activeSubs []*models.Subscription
 	return breakdown
 }
 
-// buildMonthlyTrend calculates the cost trend for the last 12 months.
-// A subscription is considered active in a month if:
-//   - StartDate <= last day of that month
-//   - Status is active or paused
-type subWithCostEntry struct {
-	sub            *models.Subscription
-	personalAmount int
+// buildMonthlyTrend rebuilds the spending of the last 12 months, including
+// the current one, from the histories of the user's subscriptions.
+func buildMonthlyTrend(histories []*subscriptionHistory, today time.Time) []MonthlyTrend {
+	return monthlySpending(histories, trendStart(today), monthStart(today), today)
 }
 
-func (s *ReportService) buildMonthlyTrend(subCosts []subWithCostEntry) []MonthlyTrend {
-	now := time.Now()
-	trends := make([]MonthlyTrend, 12)
+// trendStart returns the first day of the monthly trend window.
+func trendStart(today time.Time) time.Time {
+	return monthStart(today).AddDate(0, -11, 0)
+}
 
-	for i := 0; i < 12; i++ {
-		// Calculate target month: from 11 months ago to current month.
-		offset := 11 - i
-		targetDate := now.AddDate(0, -offset, 0)
-		year := targetDate.Year()
-		month := int(targetDate.Month())
+// totalSpent sums the lifetime spend of every subscription the user has held,
+// including deleted ones. The trend histories only carry recent changes and
+// deletions, so the deleted subscriptions and the complete price and status
+// history are loaded separately.
+func (s *ReportService) totalSpent(userID string, histories []*subscriptionHistory, shareMap map[string]*models.SubscriptionShare, today time.Time) (int, error) {
+	subs := make([]*models.Subscription, 0, len(histories))
+	for _, h := range histories {
+		if h.end.IsZero() {
+			subs = append(subs, h.sub)
+		}
+	}
+	deleted, err := s.subRepo.FindDeletedByUserIDSince(userID, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+	subs = append(subs, deleted...)
 
-		// Last day of the target month.
-		lastDay := time.Date(year, targetDate.Month()+1, 0, 23, 59, 59, 0, time.UTC)
+	prices, statuses, err := loadChangesFor(s.priceRepo, s.statusRepo, subs)
+	if err != nil {
+		return 0, err
+	}
 
-		totalAmount := 0
-		count := 0
+	total := 0
+	for _, h := range newSubscriptionHistories(subs, shareMap, prices, statuses, s.clock.Location(userID)) {
+		total += h.lifetimeSpend(today)
+	}
+	return total, nil
+}
 
-		for _, sc := range subCosts {
-			// Subscription was active in this month if it started on or before the last day.
-			if sc.sub.StartDate.Before(lastDay) || sc.sub.StartDate.Equal(lastDay) {
-				totalAmount += sc.personalAmount
-				count++
-			}
-		}
+// loadHistories loads every subscription of the user that existed on or
+// after since, with the price and status changes recorded since then.
+func (s *ReportService) loadHistories(userID string, shareMap map[string]*models.SubscriptionShare, since time.Time) ([]*subscriptionHistory, error) {
+	subs, err := findAllSubscriptions(s.subRepo, userID, repositories.SubscriptionFilter{})
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := s.subRepo.FindDeletedByUserIDSince(userID, since)
+	if err != nil {
+		return nil, err
+	}
+	subs = append(subs, deleted...)
 
-		trends[i] = MonthlyTrend{
-			Year:   year,
-			Month:  month,
-			Amount: totalAmount,
-			Count:  count,
+	var prices []*models.PriceChange
+	if s.priceRepo != nil {
+		if prices, err = s.priceRepo.FindByUserIDSince(userID, since); err != nil {
+			return nil, err
+		}
+	}
+	var statuses []*models.StatusChange
+	if s.statusRepo != nil {
+		if statuses, err = s.statusRepo.FindByUserIDSince(userID, since); err != nil {
+			return nil, err
 		}
 	}
 
-	return trends
+	return newSubscriptionHistories(subs, shareMap, prices, statuses, s.clock.Location(userID)), nil
 }
 
 // buildAverageCost calculates current average costs based on active subscriptions.
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/subkeep/backend/models"
 	"github.com/subkeep/backend/repositories"
@@ -33,7 +34,7 @@ func (m *mockSubRepoForReport) FindByID(id string) (*models.Subscription, error)
 func (m *mockSubRepoForReport) FindByUserID(userID string, filter repositories.SubscriptionFilter) ([]*models.Subscription, int64, error) {
 	var result []*models.Subscription
 	for _, sub := range m.subs {
-		if sub.UserID.String() != userID {
+		if sub.UserID.String() != userID || sub.DeletedAt.Valid {
 			continue
 		}
 		if filter.Status != "" && string(sub.Status) != filter.Status {
@@ -52,6 +53,19 @@ func (m *mockSubRepoForReport) FindDuplicateName(userID, name string) (bool, err
 func (m *mockSubRepoForReport) FindSimilarInCategory(userID string, categoryID string, excludeSubID string) ([]*models.Subscription, error) {
 	return nil, nil
 }
+func (m *mockSubRepoForReport) FindDeletedByUserIDSince(userID string, since time.Time) ([]*models.Subscription, error) {
+	var result []*models.Subscription
+	for _, sub := range m.subs {
+		if sub.UserID.String() == userID && sub.DeletedAt.Valid && !sub.DeletedAt.Time.Before(since) {
+			result = append(result, sub)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSubRepoForReport) FindActiveUserIDs() ([]string, error) {
+	return nil, nil
+}
 
 type mockShareRepoForReport struct {
 	shares map[string]*models.SubscriptionShare
@@ -78,6 +92,7 @@ func (m *mockShareRepoForReport) Create(share *models.SubscriptionShare) error
 func (m *mockShareRepoForReport) Update(share *models.SubscriptionShare) error  { return nil }
 func (m *mockShareRepoForReport) Delete(id string) error                        { return nil }
 func (m *mockShareRepoForReport) DeleteBySubscriptionID(subscriptionID string) error { return nil }
+func (m *mockShareRepoForReport) FindSharingUserIDs() ([]string, error) { return nil, nil }
 
 // ---------------------------------------------------------------------------
 // Helpers
@@ -126,6 +141,41 @@ func seedReportSub(
 	return sub
 }
 
+type mockStatusChangeRepo struct {
+	changes []*models.StatusChange
+}
+
+func (m *mockStatusChangeRepo) Create(change *models.StatusChange) error {
+	if change.ID == uuid.Nil {
+		change.ID = uuid.New()
+	}
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *mockStatusChangeRepo) FindBySubscriptionIDs(subIDs []string) ([]*models.StatusChange, error) {
+	var result []*models.StatusChange
+	for _, c := range m.changes {
+		for _, id := range subIDs {
+			if c.SubscriptionID.String() == id {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStatusChangeRepo) FindByUserIDSince(userID string, since time.Time) ([]*models.StatusChange, error) {
+	var result []*models.StatusChange
+	for _, c := range m.changes {
+		if c.UserID.String() == userID && !c.EffectiveDate.Before(since) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
 // ===========================================================================
 // GetOverview
 // ===========================================================================
@@ -364,6 +414,172 @@ func TestGetOverview_MonthlyTrend_NewSubscription(t *testing.T) {
 	}
 }
 
+func TestGetOverview_MonthlyTrend_UserTimezone(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	shareRepo := newMockShareRepoForReport()
+	// 2026-03-31 16:00 UTC is already April in the default Asia/Seoul zone.
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)), nil)
+	svc := NewReportServiceWithDeps(repo, shareRepo, ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "New", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	sub.StartDate = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	overview, err := svc.GetOverview(userID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := overview.MonthlyTrend[0]
+	last := overview.MonthlyTrend[len(overview.MonthlyTrend)-1]
+	if first.Year != 2025 || first.Month != 5 {
+		t.Errorf("expected trend to start at 2025-05, got %d-%02d", first.Year, first.Month)
+	}
+	if last.Year != 2026 || last.Month != 4 || last.Amount != 10000 {
+		t.Errorf("expected current month 2026-04 with 10000, got %+v", last)
+	}
+}
+
+func TestGetOverview_MonthlyTrend_YearlyPlan(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	clock := NewUserClock(fixedClock(time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "Yearly", 120000, models.BillingCycleYearly, models.SubscriptionStatusActive, nil, nil)
+	sub.StartDate = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	sub.NextBillingDate = time.Date(2027, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	overview, err := svc.GetOverview(userID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, trend := range overview.MonthlyTrend {
+		wantCash := 0
+		if trend.Year == 2026 && trend.Month == 3 {
+			wantCash = 120000
+		}
+		if trend.CashAmount != wantCash {
+			t.Errorf("%d-%02d: expected cash %d, got %d", trend.Year, trend.Month, wantCash, trend.CashAmount)
+		}
+		if trend.AmortizedAmount != 10000 || trend.Amount != 10000 {
+			t.Errorf("%d-%02d: expected amortized 10000, got %+v", trend.Year, trend.Month, trend)
+		}
+	}
+}
+
+func TestGetOverview_MonthlyTrend_DeletedSubscription(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	clock := NewUserClock(fixedClock(time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "Gone", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	sub.StartDate = time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	sub.NextBillingDate = time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)
+	sub.DeletedAt = gorm.DeletedAt{Time: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	overview, err := svc.GetOverview(userID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, trend := range overview.MonthlyTrend {
+		month := time.Date(trend.Year, time.Month(trend.Month), 1, 0, 0, 0, 0, time.UTC)
+		switch {
+		case month.Before(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)):
+			if trend.CashAmount != 10000 || trend.AmortizedAmount != 10000 || trend.Count != 1 {
+				t.Errorf("%d-%02d: expected a full month, got %+v", trend.Year, trend.Month, trend)
+			}
+		case trend.Year == 2026 && trend.Month == 1:
+			// Billed on the 5th, active for 19 of 31 days.
+			if trend.CashAmount != 10000 || trend.AmortizedAmount != 6129 || trend.Count != 1 {
+				t.Errorf("2026-01: expected cash 10000 and amortized 6129, got %+v", trend)
+			}
+		default:
+			if trend.CashAmount != 0 || trend.AmortizedAmount != 0 || trend.Count != 0 {
+				t.Errorf("%d-%02d: expected nothing after deletion, got %+v", trend.Year, trend.Month, trend)
+			}
+		}
+	}
+}
+
+func TestGetOverview_MonthlyTrend_PriceAndStatusHistory(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	prices := &mockPriceChangeRepo{}
+	statuses := &mockStatusChangeRepo{}
+	clock := NewUserClock(fixedClock(time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock, PriceRepo: prices, StatusRepo: statuses})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "Raised", 13000, models.BillingCycleMonthly, models.SubscriptionStatusPaused, nil, nil)
+	sub.StartDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sub.NextBillingDate = time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	raisedOn := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	_ = prices.Create(&models.PriceChange{
+		SubscriptionID: sub.ID, UserID: userID,
+		OldAmount: 10000, NewAmount: 13000,
+		OldBillingCycle: models.BillingCycleMonthly, NewBillingCycle: models.BillingCycleMonthly,
+		EffectiveDate: raisedOn, CreatedAt: raisedOn,
+	})
+	_ = statuses.Create(&models.StatusChange{
+		SubscriptionID: sub.ID, UserID: userID,
+		OldStatus: models.SubscriptionStatusActive, NewStatus: models.SubscriptionStatusPaused,
+		EffectiveDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	overview, err := svc.GetOverview(userID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, trend := range overview.MonthlyTrend {
+		want := 10000
+		switch {
+		case trend.Year == 2026 && (trend.Month == 3 || trend.Month == 4):
+			want = 13000
+		case trend.Year == 2026 && trend.Month >= 5:
+			want = 0
+		}
+		if trend.CashAmount != want || trend.AmortizedAmount != want {
+			t.Errorf("%d-%02d: expected %d, got %+v", trend.Year, trend.Month, want, trend)
+		}
+	}
+}
+
+func TestReportService_Savings(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	shareRepo := newMockShareRepoForReport()
+	savings := &mockCancellationSavingRepo{}
+	clock := NewUserClock(fixedClock(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)), nil)
+	svc := NewReportServiceWithDeps(repo, shareRepo, ReportDeps{Clock: clock, Savings: savings})
+	userID := uuid.New()
+
+	// Cancelled 365 days ago: a full year of 10,000원/month, 60 days of it this year.
+	_ = savings.Create(&models.CancellationSaving{
+		UserID: userID, ServiceName: "Old", MonthlyAmount: 10000,
+		CancelledOn: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+	})
+	// Cancelled today: nothing realized yet.
+	_ = savings.Create(&models.CancellationSaving{
+		UserID: userID, ServiceName: "New", MonthlyAmount: 5000,
+		CancelledOn: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+	})
+
+	report, err := svc.GetSavings(userID.String())
+	assertNil(t, err)
+	assertEqual(t, report.Summary, RealizedSavings{
+		Lifetime:       120000,
+		YearToDate:     19726,
+		MonthlyRunRate: 15000,
+		Cancellations:  2,
+	})
+	assertEqual(t, report.Entries[0].Realized, 120000)
+	assertEqual(t, report.Entries[1].Realized, 0)
+
+	overview, err := svc.GetOverview(userID.String())
+	assertNil(t, err)
+	assertEqual(t, overview.Savings, report.Summary)
+}
+
 func TestGetOverview_AverageCost_Monthly(t *testing.T) {
 	repo := newMockSubRepoForReport()
 	shareRepo := newMockShareRepoForReport()
@@ -579,3 +795,32 @@ func TestGetOverview_WeeklyAverageCost(t *testing.T) {
 		t.Errorf("expected weekly %d, got %d", expectedWeekly, overview.AverageCost.Weekly)
 	}
 }
+
+func TestGetOverview_TotalSpent(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	prices := &mockPriceChangeRepo{}
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock, PriceRepo: prices})
+	userID := uuid.New()
+
+	music := seedReportSub(repo, userID, "Music", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	music.StartDate = ymd(2026, 1, 5)
+	music.NextBillingDate = ymd(2026, 7, 5)
+	_ = prices.Create(&models.PriceChange{
+		SubscriptionID: music.ID, UserID: userID,
+		OldAmount: 8000, NewAmount: 10000,
+		OldBillingCycle: models.BillingCycleMonthly, NewBillingCycle: models.BillingCycleMonthly,
+		EffectiveDate: ymd(2026, 4, 5), CreatedAt: ymd(2026, 4, 5),
+	})
+
+	// Deleted long before the trend window, but still part of the total.
+	gone := seedReportSub(repo, userID, "Gone", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	gone.StartDate = ymd(2025, 1, 10)
+	gone.NextBillingDate = ymd(2025, 4, 10)
+	gone.DeletedAt = gorm.DeletedAt{Time: ymd(2025, 3, 20), Valid: true}
+
+	report, err := svc.GetOverview(userID.String())
+	assertNil(t, err)
+	// Music: 3 x 8000 + 3 x 10000; Gone: 3 x 5000.
+	assertEqual(t, report.Summary.TotalSpent, 69000)
+}
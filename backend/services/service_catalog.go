@@ -0,0 +1,92 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/subkeep/backend/models"
+)
+
+// bundledServiceCatalog holds the list of well-known services shipped with the binary.
+//
+//go:embed data/service_catalog.json
+var bundledServiceCatalog []byte
+
+// CatalogEntry describes a well-known subscription service in a catalog data file.
+// Plans lists the standard price per billing cycle, when known.
+type CatalogEntry struct {
+	Name    string                      `json:"name"`
+	Aliases []string                    `json:"aliases"`
+	Group   models.FunctionalGroup      `json:"group"`
+	Plans   map[models.BillingCycle]int `json:"plans"`
+}
+
+// ServiceCatalog looks up well-known services by name. A nil *ServiceCatalog
+// knows no services.
+type ServiceCatalog struct {
+	entries map[string]*CatalogEntry // normalized name or alias -> entry
+}
+
+// NewServiceCatalog creates a ServiceCatalog from the given entries.
+func NewServiceCatalog(entries []CatalogEntry) (*ServiceCatalog, error) {
+	cat := &ServiceCatalog{entries: make(map[string]*CatalogEntry, len(entries))}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Group != "" && !entry.Group.IsValid() {
+			return nil, fmt.Errorf("unknown functional group %q for %q", entry.Group, entry.Name)
+		}
+		for cycle, amount := range entry.Plans {
+			if _, err := parseAlternativeCycle(string(cycle)); err != nil || amount <= 0 {
+				return nil, fmt.Errorf("invalid %s plan for %q", cycle, entry.Name)
+			}
+		}
+		cat.entries[normalizeName(entry.Name)] = entry
+		for _, alias := range entry.Aliases {
+			cat.entries[normalizeName(alias)] = entry
+		}
+	}
+	return cat, nil
+}
+
+// LoadServiceCatalog reads a service catalog data file. When path is empty the
+// bundled catalog is used.
+func LoadServiceCatalog(path string) (*ServiceCatalog, error) {
+	data := bundledServiceCatalog
+	if path != "" {
+		fileData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read service catalog: %w", err)
+		}
+		data = fileData
+	}
+
+	var entries []CatalogEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("decode service catalog: %w", err)
+	}
+	return NewServiceCatalog(entries)
+}
+
+// Lookup returns the catalog entry matching a service name, ignoring case and
+// whitespace.
+func (c *ServiceCatalog) Lookup(serviceName string) (*CatalogEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, ok := c.entries[normalizeName(serviceName)]
+	return entry, ok
+}
+
+// FunctionalGroupOf returns the subscription's functional group: the one set
+// on the subscription, otherwise the catalog's.
+func (c *ServiceCatalog) FunctionalGroupOf(sub *models.Subscription) (models.FunctionalGroup, bool) {
+	if sub.FunctionalGroup != nil && *sub.FunctionalGroup != "" {
+		return *sub.FunctionalGroup, true
+	}
+	if entry, ok := c.Lookup(sub.ServiceName); ok && entry.Group != "" {
+		return entry.Group, true
+	}
+	return "", false
+}
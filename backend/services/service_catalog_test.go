@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/subkeep/backend/models"
+)
+
+func TestLoadServiceCatalog_Bundled(t *testing.T) {
+	catalog, err := LoadServiceCatalog("")
+	assertNil(t, err)
+
+	entry, ok := catalog.Lookup("  넷플릭스 ")
+	if !ok {
+		t.Fatal("expected alias to match")
+	}
+	assertEqual(t, "Netflix", entry.Name)
+	assertEqual(t, models.FunctionalGroupVideo, entry.Group)
+
+	entry, ok = catalog.Lookup("youtube music")
+	if !ok {
+		t.Fatal("expected case-insensitive match")
+	}
+	assertEqual(t, models.FunctionalGroupMusic, entry.Group)
+
+	if _, ok := catalog.Lookup("My Local Gym"); ok {
+		t.Error("expected unknown service not to match")
+	}
+}
+
+func TestNewServiceCatalog_RejectsUnknownGroup(t *testing.T) {
+	_, err := NewServiceCatalog([]CatalogEntry{{Name: "X", Group: "unknown"}})
+	assertNotNil(t, err)
+}
+
+func TestServiceCatalog_FunctionalGroupOf(t *testing.T) {
+	catalog, err := NewServiceCatalog([]CatalogEntry{{Name: "Spotify", Group: models.FunctionalGroupMusic}})
+	assertNil(t, err)
+
+	sub := &models.Subscription{ServiceName: "Spotify"}
+	group, ok := catalog.FunctionalGroupOf(sub)
+	assertEqual(t, true, ok)
+	assertEqual(t, models.FunctionalGroupMusic, group)
+
+	manual := models.FunctionalGroupProductivity
+	sub.FunctionalGroup = &manual
+	group, _ = catalog.FunctionalGroupOf(sub)
+	assertEqual(t, models.FunctionalGroupProductivity, group)
+
+	var none *ServiceCatalog
+	_, ok = none.FunctionalGroupOf(&models.Subscription{ServiceName: "Spotify"})
+	assertEqual(t, false, ok)
+}
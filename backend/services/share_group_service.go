@@ -33,12 +33,27 @@ type UpdateShareGroupRequest struct {
 
 // ShareGroupService handles business logic for share groups.
 type ShareGroupService struct {
-	repo repositories.ShareGroupRepository
+	repo  repositories.ShareGroupRepository
+	cache *AggregateCache
 }
 
-// NewShareGroupService creates a new ShareGroupService.
+// ShareGroupDeps holds the optional dependencies of a ShareGroupService. Any
+// of them may be nil.
+type ShareGroupDeps struct {
+	Cache *AggregateCache // nil: no cached aggregates are invalidated
+}
+
+// NewShareGroupService creates a new ShareGroupService without optional dependencies.
 func NewShareGroupService(repo repositories.ShareGroupRepository) *ShareGroupService {
-	return &ShareGroupService{repo: repo}
+	return NewShareGroupServiceWithDeps(repo, ShareGroupDeps{})
+}
+
+// NewShareGroupServiceWithDeps creates a new ShareGroupService with the given optional dependencies.
+func NewShareGroupServiceWithDeps(repo repositories.ShareGroupRepository, deps ShareGroupDeps) *ShareGroupService {
+	return &ShareGroupService{
+		repo:  repo,
+		cache: deps.Cache,
+	}
 }
 
 // GetShareGroups returns all share groups owned by the user.
@@ -122,6 +137,7 @@ func (s *ShareGroupService) CreateShareGroup(userID string, req *CreateShareGrou
 		slog.Error("공유 그룹 생성 실패", "userID", userID, "error", err)
 		return nil, utils.ErrInternal("공유 그룹을 생성할 수 없습니다")
 	}
+	s.cache.InvalidateUser(userID)
 
 	// Re-fetch to preload associations.
 	created, err := s.repo.FindByID(group.ID.String())
@@ -162,6 +178,7 @@ func (s *ShareGroupService) UpdateShareGroup(userID, groupID string, req *Update
 		slog.Error("공유 그룹 수정 실패", "groupID", groupID, "error", err)
 		return nil, utils.ErrInternal("공유 그룹을 수정할 수 없습니다")
 	}
+	s.cache.InvalidateUser(userID)
 
 	// Re-fetch to preload associations.
 	updated, fetchErr := s.repo.FindByID(group.ID.String())
@@ -190,6 +207,7 @@ func (s *ShareGroupService) DeleteShareGroup(userID, groupID string) error {
 		slog.Error("공유 그룹 삭제 실패", "groupID", groupID, "error", err)
 		return utils.ErrInternal("공유 그룹을 삭제할 수 없습니다")
 	}
+	s.cache.InvalidateUser(userID)
 
 	return nil
 }
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/utils"
+)
+
+// ShareReceivablesReport works out what the other members of the user's share
+// groups owe for the shared subscriptions. A charge is owed in full except
+// for the user's own share, and the rest is split evenly between the group's
+// members other than its owner, the first members absorbing any remainder.
+// Monthly amounts are monthly equivalents at today's prices of subscriptions
+// that are active; period amounts add up the charges billed within [From, To].
+type ShareReceivablesReport struct {
+	From                    time.Time               `json:"from"`
+	To                      time.Time               `json:"to"`
+	Months                  []string                `json:"months"`
+	MonthlyAmount           int                     `json:"monthlyAmount"`
+	PeriodAmount            int                     `json:"periodAmount"`
+	UnassignedMonthlyAmount int                     `json:"unassignedMonthlyAmount"`
+	UnassignedPeriodAmount  int                     `json:"unassignedPeriodAmount"`
+	Members                 []MemberReceivable      `json:"members"`
+	Groups                  []ShareGroupReceivables `json:"groups"`
+}
+
+// MemberReceivable totals what a member owes across all groups. Members of
+// different groups are the same person when their nicknames match.
+type MemberReceivable struct {
+	Nickname      string            `json:"nickname"`
+	GroupCount    int               `json:"groupCount"`
+	MonthlyAmount int               `json:"monthlyAmount"`
+	PeriodAmount  int               `json:"periodAmount"`
+	Months        []ReceivableMonth `json:"months"`
+}
+
+// ReceivableMonth is the amount owed for charges billed within one month.
+type ReceivableMonth struct {
+	Month  string `json:"month"` // YYYY-MM
+	Amount int    `json:"amount"`
+}
+
+// ShareGroupReceivables lists what the members of one group owe. Unassigned
+// amounts are owed by others but the group has no members besides its owner
+// to collect them from.
+type ShareGroupReceivables struct {
+	ShareGroupID            string                  `json:"shareGroupId"`
+	GroupName               string                  `json:"groupName"`
+	MonthlyAmount           int                     `json:"monthlyAmount"`
+	PeriodAmount            int                     `json:"periodAmount"`
+	UnassignedMonthlyAmount int                     `json:"unassignedMonthlyAmount"`
+	UnassignedPeriodAmount  int                     `json:"unassignedPeriodAmount"`
+	Members                 []GroupMemberReceivable `json:"members"`
+}
+
+// GroupMemberReceivable is what one group member owes, per subscription.
+type GroupMemberReceivable struct {
+	MemberID      string           `json:"memberId"`
+	Nickname      string           `json:"nickname"`
+	MonthlyAmount int              `json:"monthlyAmount"`
+	PeriodAmount  int              `json:"periodAmount"`
+	Items         []ReceivableItem `json:"items"`
+}
+
+// ReceivableItem is what a member owes for one subscription.
+type ReceivableItem struct {
+	SubscriptionID string `json:"subscriptionId"`
+	ServiceName    string `json:"serviceName"`
+	MonthlyAmount  int    `json:"monthlyAmount"`
+	PeriodAmount   int    `json:"periodAmount"`
+	ChargeCount    int    `json:"chargeCount"`
+}
+
+// GetShareReceivables reports what share group members owe over [from, to].
+// Nil bounds default to the current month. Reports are cached per day, since
+// periods may run past today.
+func (s *ReportService) GetShareReceivables(userID string, from, to *time.Time) (*ShareReceivablesReport, error) {
+	today := s.clock.Today(userID)
+	start, end := monthStart(today), monthStart(today).AddDate(0, 1, -1)
+	if from != nil {
+		start = truncateDate(*from)
+	}
+	if to != nil {
+		end = truncateDate(*to)
+	}
+	if appErr := validateReportPeriod(start, end); appErr != nil {
+		return nil, appErr
+	}
+
+	key := aggregateKey(userID, "receivables",
+		start.Format("2006-01-02"), end.Format("2006-01-02"), today.Format("2006-01-02"))
+	return cachedAggregate(s.cache, key, func() (*ShareReceivablesReport, error) {
+		report, err := s.buildShareReceivables(userID, start, end, today)
+		if err != nil {
+			slog.Error("공유 정산 리포트 생성 실패", "userID", userID, "error", err)
+			return nil, utils.ErrInternal("공유 정산 리포트를 조회할 수 없습니다")
+		}
+		return report, nil
+	})
+}
+
+// buildShareReceivables splits the shared subscriptions' charges between the
+// members of their groups.
+func (s *ReportService) buildShareReceivables(userID string, from, to, today time.Time) (*ShareReceivablesReport, error) {
+	histories, err := s.loadHistories(userID, buildShareMap(s.shareRepo, userID), from)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]string, 0)
+	for m := monthStart(from); !m.After(to); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format("2006-01"))
+	}
+
+	type memberTotals struct {
+		receivable GroupMemberReceivable
+		items      map[string]*ReceivableItem
+		order      []string
+	}
+	type groupTotals struct {
+		receivable ShareGroupReceivables
+		members    []*memberTotals
+	}
+	type personTotals struct {
+		receivable MemberReceivable
+		groups     map[string]bool
+		months     map[string]int
+	}
+	groups := make(map[string]*groupTotals)
+	people := make(map[string]*personTotals)
+
+	for _, h := range histories {
+		if h.share == nil || h.share.ShareGroup.ID != h.share.ShareGroupID {
+			continue
+		}
+
+		group, ok := groups[h.share.ShareGroupID.String()]
+		if !ok {
+			group = &groupTotals{receivable: ShareGroupReceivables{
+				ShareGroupID: h.share.ShareGroupID.String(),
+				GroupName:    h.share.ShareGroup.Name,
+			}}
+			for _, m := range receivableMembers(h.share.ShareGroup.Members) {
+				group.members = append(group.members, &memberTotals{
+					receivable: GroupMemberReceivable{MemberID: m.ID.String(), Nickname: m.Nickname},
+					items:      make(map[string]*ReceivableItem),
+				})
+			}
+			groups[group.receivable.ShareGroupID] = group
+		}
+
+		subID := h.sub.ID.String()
+		item := func(m *memberTotals) *ReceivableItem {
+			it, ok := m.items[subID]
+			if !ok {
+				it = &ReceivableItem{SubscriptionID: subID, ServiceName: h.sub.ServiceName}
+				m.items[subID] = it
+				m.order = append(m.order, subID)
+			}
+			return it
+		}
+		person := func(m *memberTotals) *personTotals {
+			name := strings.TrimSpace(m.receivable.Nickname)
+			p, ok := people[name]
+			if !ok {
+				p = &personTotals{
+					receivable: MemberReceivable{Nickname: name},
+					groups:     make(map[string]bool),
+					months:     make(map[string]int),
+				}
+				people[name] = p
+			}
+			p.groups[group.receivable.ShareGroupID] = true
+			return p
+		}
+
+		if h.end.IsZero() && h.sub.Status == models.SubscriptionStatusActive {
+			owed := max(h.sub.MonthlyAmount()-personalMonthlyAmount(h.sub, h.share), 0)
+			group.receivable.MonthlyAmount += owed
+			if len(group.members) == 0 {
+				group.receivable.UnassignedMonthlyAmount += owed
+			}
+			for i, amount := range splitEvenly(owed, len(group.members)) {
+				m := group.members[i]
+				m.receivable.MonthlyAmount += amount
+				item(m).MonthlyAmount += amount
+				person(m).receivable.MonthlyAmount += amount
+			}
+		}
+
+		for _, charge := range h.charges(from, to, today) {
+			owed := max(charge.full-charge.amount, 0)
+			group.receivable.PeriodAmount += owed
+			if len(group.members) == 0 {
+				group.receivable.UnassignedPeriodAmount += owed
+			}
+			for i, amount := range splitEvenly(owed, len(group.members)) {
+				m := group.members[i]
+				m.receivable.PeriodAmount += amount
+				it := item(m)
+				it.PeriodAmount += amount
+				it.ChargeCount++
+				p := person(m)
+				p.receivable.PeriodAmount += amount
+				p.months[charge.date.Format("2006-01")] += amount
+			}
+		}
+	}
+
+	report := &ShareReceivablesReport{
+		From:    from,
+		To:      to,
+		Months:  months,
+		Members: make([]MemberReceivable, 0, len(people)),
+		Groups:  make([]ShareGroupReceivables, 0, len(groups)),
+	}
+	for _, group := range groups {
+		g := group.receivable
+		g.Members = make([]GroupMemberReceivable, 0, len(group.members))
+		for _, m := range group.members {
+			member := m.receivable
+			member.Items = make([]ReceivableItem, 0, len(m.order))
+			for _, subID := range m.order {
+				member.Items = append(member.Items, *m.items[subID])
+			}
+			sort.SliceStable(member.Items, func(i, j int) bool {
+				return member.Items[i].ServiceName < member.Items[j].ServiceName
+			})
+			g.Members = append(g.Members, member)
+		}
+		report.MonthlyAmount += g.MonthlyAmount
+		report.PeriodAmount += g.PeriodAmount
+		report.UnassignedMonthlyAmount += g.UnassignedMonthlyAmount
+		report.UnassignedPeriodAmount += g.UnassignedPeriodAmount
+		report.Groups = append(report.Groups, g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].GroupName != report.Groups[j].GroupName {
+			return report.Groups[i].GroupName < report.Groups[j].GroupName
+		}
+		return report.Groups[i].ShareGroupID < report.Groups[j].ShareGroupID
+	})
+
+	for _, p := range people {
+		member := p.receivable
+		member.GroupCount = len(p.groups)
+		member.Months = make([]ReceivableMonth, 0, len(months))
+		for _, month := range months {
+			member.Months = append(member.Months, ReceivableMonth{Month: month, Amount: p.months[month]})
+		}
+		report.Members = append(report.Members, member)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		if report.Members[i].PeriodAmount != report.Members[j].PeriodAmount {
+			return report.Members[i].PeriodAmount > report.Members[j].PeriodAmount
+		}
+		return report.Members[i].Nickname < report.Members[j].Nickname
+	})
+
+	return report, nil
+}
+
+// receivableMembers returns the members other than the owner, in the order
+// they joined.
+func receivableMembers(members []models.ShareMember) []models.ShareMember {
+	result := make([]models.ShareMember, 0, len(members))
+	for _, m := range members {
+		if !m.IsOwner {
+			result = append(result, m)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].Nickname < result[j].Nickname
+	})
+	return result
+}
+
+// splitEvenly divides amount into n parts that add up to it, the first parts
+// taking one more when it does not divide evenly.
+func splitEvenly(amount, n int) []int {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]int, n)
+	for i := range parts {
+		parts[i] = amount / n
+		if i < amount%n {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+// ExportShareReceivables returns the share receivables report over the period
+// as a file to be written. Nil bounds default to the current month.
+func (e *ReportExporter) ExportShareReceivables(userID, format string, from, to *time.Time) (*ReportFile, error) {
+	contentType, appErr := e.contentType(format)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report, err := e.reports.GetShareReceivables(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportFile{
+		Filename: fmt.Sprintf("subkeep-receivables-%s_%s.%s",
+			report.From.Format("2006-01-02"), report.To.Format("2006-01-02"), format),
+		ContentType: contentType,
+		title:       "SubKeep 공유 정산 리포트",
+		sections:    receivableSections(report),
+		format:      format,
+		font:        e.font,
+	}, nil
+}
+
+// receivableSections lays out the receivables report as tables.
+func receivableSections(report *ShareReceivablesReport) []reportSection {
+	summary := reportSection{
+		title:  "요약",
+		header: []string{"항목", "값"},
+		rows: [][]any{
+			{"기간", report.From.Format("2006-01-02") + " ~ " + report.To.Format("2006-01-02")},
+			{"월 청구 금액", report.MonthlyAmount},
+			{"기간 청구 금액", report.PeriodAmount},
+			{"미배정 월 금액", report.UnassignedMonthlyAmount},
+			{"미배정 기간 금액", report.UnassignedPeriodAmount},
+		},
+	}
+
+	members := reportSection{
+		title:  "멤버별 합계",
+		header: append([]string{"멤버", "그룹 수", "월 청구 금액", "기간 청구 금액"}, report.Months...),
+	}
+	for _, m := range report.Members {
+		row := []any{m.Nickname, m.GroupCount, m.MonthlyAmount, m.PeriodAmount}
+		for _, month := range m.Months {
+			row = append(row, month.Amount)
+		}
+		members.rows = append(members.rows, row)
+	}
+
+	details := reportSection{
+		title:  "그룹별 상세",
+		header: []string{"그룹", "멤버", "서비스", "월 청구 금액", "기간 청구 금액", "결제 횟수"},
+	}
+	for _, g := range report.Groups {
+		for _, m := range g.Members {
+			for _, it := range m.Items {
+				details.rows = append(details.rows, []any{
+					g.GroupName, m.Nickname, it.ServiceName, it.MonthlyAmount, it.PeriodAmount, it.ChargeCount,
+				})
+			}
+		}
+		if g.UnassignedMonthlyAmount > 0 || g.UnassignedPeriodAmount > 0 {
+			details.rows = append(details.rows, []any{
+				g.GroupName, "미배정", "", g.UnassignedMonthlyAmount, g.UnassignedPeriodAmount, 0,
+			})
+		}
+	}
+
+	return []reportSection{summary, members, details}
+}
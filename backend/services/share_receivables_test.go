@@ -0,0 +1,184 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/subkeep/backend/models"
+)
+
+func rcvMakeGroup(name string, nicknames ...string) models.ShareGroup {
+	group := models.ShareGroup{ID: uuid.New(), Name: name}
+	group.Members = append(group.Members, models.ShareMember{
+		ID: uuid.New(), ShareGroupID: group.ID, Nickname: "나", IsOwner: true, CreatedAt: ymd(2025, 1, 1),
+	})
+	for i, nickname := range nicknames {
+		group.Members = append(group.Members, models.ShareMember{
+			ID: uuid.New(), ShareGroupID: group.ID, Nickname: nickname, CreatedAt: ymd(2025, 1, 2+i),
+		})
+	}
+	return group
+}
+
+func rcvShare(shareRepo *mockShareRepoForReport, sub *models.Subscription, group models.ShareGroup, share models.SubscriptionShare) {
+	share.ID = uuid.New()
+	share.SubscriptionID = sub.ID
+	share.ShareGroupID = group.ID
+	share.ShareGroup = group
+	shareRepo.shares[share.ID.String()] = &share
+}
+
+func newShareReceivablesFixture() (*ReportService, uuid.UUID) {
+	repo := newMockSubRepoForReport()
+	shareRepo := newMockShareRepoForReport()
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewReportServiceWithDeps(repo, shareRepo, ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	family := rcvMakeGroup("Family", "A", "B")
+	friends := rcvMakeGroup("Friends", "C", "A")
+	solo := rcvMakeGroup("Solo")
+
+	netflix := seedReportSub(repo, userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	netflix.StartDate = ymd(2025, 1, 10)
+	netflix.NextBillingDate = ymd(2026, 7, 10)
+	rcvShare(shareRepo, netflix, family, models.SubscriptionShare{SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 3})
+
+	youtube := seedReportSub(repo, userID, "YouTube", 14900, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	youtube.StartDate = ymd(2025, 1, 1)
+	youtube.NextBillingDate = ymd(2026, 7, 1)
+	rcvShare(shareRepo, youtube, friends, models.SubscriptionShare{
+		SplitType: models.SplitTypeCustomAmount, MyShareAmount: intPtr(4900), TotalMembersSnapshot: 3,
+	})
+
+	cloud := seedReportSub(repo, userID, "Cloud", 120000, models.BillingCycleYearly, models.SubscriptionStatusActive, nil, nil)
+	cloud.StartDate = ymd(2025, 6, 20)
+	cloud.NextBillingDate = ymd(2026, 6, 20)
+	rcvShare(shareRepo, cloud, solo, models.SubscriptionShare{SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 2})
+
+	private := seedReportSub(repo, userID, "Private", 9900, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	private.StartDate = ymd(2025, 1, 1)
+	private.NextBillingDate = ymd(2026, 7, 1)
+
+	return svc, userID
+}
+
+func TestGetShareReceivables(t *testing.T) {
+	svc, userID := newShareReceivablesFixture()
+	from, to := ymd(2026, 5, 1), ymd(2026, 6, 30)
+
+	report, err := svc.GetShareReceivables(userID.String(), &from, &to)
+	assertNil(t, err)
+
+	assertEqual(t, report.Months, []string{"2026-05", "2026-06"})
+	// Netflix 11333 + YouTube 10000 + Cloud 5000 a month.
+	assertEqual(t, report.MonthlyAmount, 26333)
+	// Two Netflix and YouTube charges, and the upcoming Cloud renewal.
+	assertEqual(t, report.PeriodAmount, 102666)
+	assertEqual(t, report.UnassignedMonthlyAmount, 5000)
+	assertEqual(t, report.UnassignedPeriodAmount, 60000)
+
+	assertEqual(t, report.Members, []MemberReceivable{
+		{Nickname: "A", GroupCount: 2, MonthlyAmount: 10667, PeriodAmount: 21334, Months: []ReceivableMonth{
+			{Month: "2026-05", Amount: 10667}, {Month: "2026-06", Amount: 10667},
+		}},
+		{Nickname: "B", GroupCount: 1, MonthlyAmount: 5666, PeriodAmount: 11332, Months: []ReceivableMonth{
+			{Month: "2026-05", Amount: 5666}, {Month: "2026-06", Amount: 5666},
+		}},
+		{Nickname: "C", GroupCount: 1, MonthlyAmount: 5000, PeriodAmount: 10000, Months: []ReceivableMonth{
+			{Month: "2026-05", Amount: 5000}, {Month: "2026-06", Amount: 5000},
+		}},
+	})
+
+	assertEqual(t, len(report.Groups), 3)
+	family := report.Groups[0]
+	assertEqual(t, family.GroupName, "Family")
+	assertEqual(t, family.MonthlyAmount, 11333)
+	assertEqual(t, family.PeriodAmount, 22666)
+	assertEqual(t, len(family.Members), 2)
+	assertEqual(t, family.Members[0].Nickname, "A")
+	assertEqual(t, family.Members[0].Items, []ReceivableItem{
+		{SubscriptionID: family.Members[0].Items[0].SubscriptionID, ServiceName: "Netflix", MonthlyAmount: 5667, PeriodAmount: 11334, ChargeCount: 2},
+	})
+
+	friends := report.Groups[1]
+	assertEqual(t, friends.Members[0].Nickname, "C")
+	assertEqual(t, friends.Members[1].PeriodAmount, 10000)
+
+	solo := report.Groups[2]
+	assertEqual(t, len(solo.Members), 0)
+	assertEqual(t, solo.UnassignedPeriodAmount, 60000)
+}
+
+func TestGetShareReceivables_DefaultsToCurrentMonth(t *testing.T) {
+	svc, userID := newShareReceivablesFixture()
+
+	report, err := svc.GetShareReceivables(userID.String(), nil, nil)
+	assertNil(t, err)
+	assertEqual(t, report.From, ymd(2026, 6, 1))
+	assertEqual(t, report.To, ymd(2026, 6, 30))
+	assertEqual(t, report.Members[0].PeriodAmount, 10667)
+}
+
+func TestGetShareReceivables_InvalidPeriod(t *testing.T) {
+	svc, userID := newShareReceivablesFixture()
+	from, to := ymd(2026, 6, 1), ymd(2026, 5, 1)
+
+	_, err := svc.GetShareReceivables(userID.String(), &from, &to)
+	assertAppErrorCode(t, err, 400)
+}
+
+func TestSplitEvenly(t *testing.T) {
+	assertEqual(t, splitEvenly(11333, 2), []int{5667, 5666})
+	assertEqual(t, splitEvenly(10, 3), []int{4, 3, 3})
+	assertEqual(t, splitEvenly(100, 0), []int(nil))
+}
+
+func TestExportShareReceivables(t *testing.T) {
+	svc, userID := newShareReceivablesFixture()
+	exporter := newTestReportExporter(t, svc)
+
+	write := func(format string) []byte {
+		t.Helper()
+		file, err := exporter.ExportShareReceivables(userID.String(), format, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if file.Filename != "subkeep-receivables-2026-06-01_2026-06-30."+format {
+			t.Errorf("unexpected filename %q", file.Filename)
+		}
+		var buf bytes.Buffer
+		if err := file.Write(&buf); err != nil {
+			t.Fatalf("write %s: %v", format, err)
+		}
+		return buf.Bytes()
+	}
+
+	csv := string(write(ReportExportCSV))
+	for _, want := range []string{"멤버별 합계", "멤버,그룹 수,월 청구 금액,기간 청구 금액,2026-06", "A,2,10667,10667,10667", "Solo,미배정,,5000,60000,0"} {
+		if !strings.Contains(csv, want) {
+			t.Errorf("expected CSV to contain %q", want)
+		}
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(write(ReportExportXLSX)))
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer f.Close()
+	if sheets := strings.Join(f.GetSheetList(), ","); sheets != "요약,멤버별 합계,그룹별 상세" {
+		t.Errorf("unexpected sheets %v", sheets)
+	}
+
+	if out := write(ReportExportPDF); !bytes.HasPrefix(out, []byte("%PDF")) {
+		t.Errorf("expected PDF header, got %q", out[:min(len(out), 8)])
+	}
+
+	if _, err := exporter.ExportShareReceivables(userID.String(), "docx", nil, nil); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -41,11 +41,14 @@ type SimulationResult struct {
 	MonthlyDifference     int                 `json:"monthlyDifference"`
 	AnnualDifference      int                 `json:"annualDifference"`
 	CategoryBreakdown     []CategoryBreakdown `json:"categoryBreakdown"`
+	BudgetWarnings        []BudgetWarning     `json:"budgetWarnings,omitempty"`
 }
 
-// undoEntry stores the subscription IDs that were soft-deleted by ApplySimulation.
+// undoEntry stores the subscription IDs that were soft-deleted by ApplySimulation
+// and the savings ledger entries recorded for them.
 type undoEntry struct {
 	subscriptionIDs []string
+	savingIDs       []string
 	expiresAt       time.Time
 }
 
@@ -55,14 +58,39 @@ type SimulationService struct {
 	shareRepo repositories.SubscriptionShareRepository
 	undoStore map[string]*undoEntry // key: userID
 	undoMu    sync.Mutex
+	clock     *UserClock
+	events    EventPublisher
+	budgets   repositories.BudgetRepository
+	savings   repositories.CancellationSavingRepository
+	cache     *AggregateCache
 }
 
-// NewSimulationService creates a new SimulationService.
+// SimulationDeps holds the optional dependencies of a SimulationService. Any
+// of them may be nil.
+type SimulationDeps struct {
+	Clock   *UserClock                                // nil: the system clock and default time zone are used
+	Events  EventPublisher                            // nil: no lifecycle events are published
+	Budgets repositories.BudgetRepository             // nil: SimulateAdd does not check budgets
+	Savings repositories.CancellationSavingRepository // nil: applied cancellations are not recorded
+	Cache   *AggregateCache                           // nil: no cached aggregates are invalidated
+}
+
+// NewSimulationService creates a new SimulationService without optional dependencies.
 func NewSimulationService(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository) *SimulationService {
+	return NewSimulationServiceWithDeps(subRepo, shareRepo, SimulationDeps{})
+}
+
+// NewSimulationServiceWithDeps creates a new SimulationService with the given optional dependencies.
+func NewSimulationServiceWithDeps(subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, deps SimulationDeps) *SimulationService {
 	return &SimulationService{
 		subRepo:   subRepo,
 		shareRepo: shareRepo,
 		undoStore: make(map[string]*undoEntry),
+		clock:     deps.Clock,
+		events:    deps.Events,
+		budgets:   deps.Budgets,
+		savings:   deps.Savings,
+		cache:     deps.Cache,
 	}
 }
 
@@ -139,7 +167,8 @@ func (s *SimulationService) SimulateCancel(userID string, req *CancelSimulationR
 	}, nil
 }
 
-// SimulateAdd simulates adding a new subscription and returns the impact.
+// SimulateAdd simulates adding a new subscription and returns the impact,
+// warning about every budget the addition would exceed.
 func (s *SimulationService) SimulateAdd(userID string, req *AddSimulationRequest) (*SimulationResult, error) {
 	if appErr := utils.ValidateStruct(req); appErr != nil {
 		return nil, appErr
@@ -205,22 +234,40 @@ func (s *SimulationService) SimulateAdd(userID string, req *AddSimulationRequest
 
 	breakdown := buildCategoryBreakdown(categoryMap, simulatedTotal)
 
+	var warnings []BudgetWarning
+	if s.budgets != nil {
+		budgets, err := s.budgets.FindByUserID(userID)
+		if err != nil {
+			slog.Error("시뮬레이션 예산 조회 실패", "userID", userID, "error", err)
+			return nil, utils.ErrInternal("시뮬레이션 데이터를 조회할 수 없습니다")
+		}
+		categoryID := ""
+		if req.CategoryID != nil {
+			categoryID = *req.CategoryID
+		}
+		warnings = budgetWarningsForAdd(budgets, activeSubs, shareMap, categoryID, virtualMonthly)
+	}
+
 	return &SimulationResult{
 		CurrentMonthlyTotal:   currentTotal,
 		SimulatedMonthlyTotal: simulatedTotal,
 		MonthlyDifference:     diff,
 		AnnualDifference:      diff * 12,
 		CategoryBreakdown:     breakdown,
+		BudgetWarnings:        warnings,
 	}, nil
 }
 
 // ApplySimulation applies a simulation by actually performing the action (cancel).
+// Each cancellation is recorded in the savings ledger with the personal monthly
+// amount it removed.
 func (s *SimulationService) ApplySimulation(userID string, req *ApplySimulationRequest) error {
 	if appErr := utils.ValidateStruct(req); appErr != nil {
 		return appErr
 	}
 
 	// Validate all subscription IDs belong to the user.
+	cancelled := make([]*models.Subscription, 0, len(req.SubscriptionIDs))
 	for _, id := range req.SubscriptionIDs {
 		sub, err := s.subRepo.FindByID(id)
 		if err != nil {
@@ -233,23 +280,34 @@ func (s *SimulationService) ApplySimulation(userID string, req *ApplySimulationR
 		if sub.UserID.String() != userID {
 			return utils.ErrForbidden("해당 구독에 대한 접근 권한이 없습니다")
 		}
+		cancelled = append(cancelled, sub)
 	}
 
 	// 적용 전 상태를 undo 스토어에 저장 (30초 TTL).
 	s.undoMu.Lock()
 	s.undoStore[userID] = &undoEntry{
 		subscriptionIDs: make([]string, len(req.SubscriptionIDs)),
-		expiresAt:       time.Now().Add(30 * time.Second),
+		expiresAt:       s.clock.now().Add(30 * time.Second),
 	}
 	copy(s.undoStore[userID].subscriptionIDs, req.SubscriptionIDs)
 	s.undoMu.Unlock()
 
+	var shareMap map[string]*models.SubscriptionShare
+	if s.savings != nil {
+		shareMap = buildShareMap(s.shareRepo, userID)
+	}
+	today := s.clock.Today(userID)
+
 	// Soft-delete all selected subscriptions.
-	for _, id := range req.SubscriptionIDs {
+	defer s.cache.InvalidateUser(userID)
+	for _, sub := range cancelled {
+		id := sub.ID.String()
 		if err := s.subRepo.Delete(id); err != nil {
 			slog.Error("시뮬레이션 적용 구독 삭제 실패", "subID", id, "error", err)
 			return utils.ErrInternal("구독 해지에 실패했습니다: " + id)
 		}
+		publishEvent(s.events, userID, models.WebhookEventSubscriptionCancelled, newWebhookSubscriptionData(sub))
+		s.recordSaving(userID, sub, shareMap, today)
 	}
 
 	slog.Info("시뮬레이션 적용 완료", "userID", userID, "action", req.Action, "count", len(req.SubscriptionIDs))
@@ -269,21 +327,57 @@ func (s *SimulationService) UndoSimulation(userID string) error {
 		return utils.ErrNotFound("실행 취소할 작업이 없습니다")
 	}
 
-	if time.Now().After(entry.expiresAt) {
+	if s.clock.now().After(entry.expiresAt) {
 		return utils.ErrBadRequest("실행 취소 기간이 만료되었습니다")
 	}
 
+	defer s.cache.InvalidateUser(userID)
 	for _, id := range entry.subscriptionIDs {
 		if err := s.subRepo.Restore(id); err != nil {
 			slog.Error("시뮬레이션 실행 취소 복원 실패", "subID", id, "error", err)
 			return utils.ErrInternal("실행 취소에 실패했습니다: " + id)
 		}
 	}
+	for _, id := range entry.savingIDs {
+		if err := s.savings.Delete(id); err != nil {
+			slog.Error("절약 기록 삭제 실패", "savingID", id, "error", err)
+		}
+	}
 
 	slog.Info("시뮬레이션 실행 취소 완료", "userID", userID, "count", len(entry.subscriptionIDs))
 	return nil
 }
 
+// recordSaving adds a cancelled subscription to the savings ledger and remembers
+// the entry so that UndoSimulation can remove it again. Failures are logged
+// because the cancellation itself has already succeeded.
+func (s *SimulationService) recordSaving(userID string, sub *models.Subscription, shareMap map[string]*models.SubscriptionShare, today time.Time) {
+	if s.savings == nil {
+		return
+	}
+	monthly := sub.MonthlyAmount()
+	if share, ok := shareMap[sub.ID.String()]; ok {
+		monthly = share.PersonalAmount(monthly)
+	}
+	saving := &models.CancellationSaving{
+		UserID:         sub.UserID,
+		SubscriptionID: sub.ID,
+		ServiceName:    sub.ServiceName,
+		MonthlyAmount:  monthly,
+		CancelledOn:    today,
+	}
+	if err := s.savings.Create(saving); err != nil {
+		slog.Error("절약 기록 저장 실패", "subID", sub.ID, "error", err)
+		return
+	}
+
+	s.undoMu.Lock()
+	if entry, ok := s.undoStore[userID]; ok {
+		entry.savingIDs = append(entry.savingIDs, saving.ID.String())
+	}
+	s.undoMu.Unlock()
+}
+
 // categoryGroupData is a helper for grouping subscriptions by category.
 type categoryGroupData struct {
 	categoryID   string
@@ -12,6 +12,42 @@ import (
 	"github.com/subkeep/backend/repositories"
 )
 
+// ---------------------------------------------------------------------------
+// Mock CancellationSavingRepository
+// ---------------------------------------------------------------------------
+
+type mockCancellationSavingRepo struct {
+	savings []*models.CancellationSaving
+}
+
+func (m *mockCancellationSavingRepo) FindByUserID(userID string) ([]*models.CancellationSaving, error) {
+	var result []*models.CancellationSaving
+	for _, s := range m.savings {
+		if s.UserID.String() == userID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCancellationSavingRepo) Create(saving *models.CancellationSaving) error {
+	if saving.ID == uuid.Nil {
+		saving.ID = uuid.New()
+	}
+	m.savings = append(m.savings, saving)
+	return nil
+}
+
+func (m *mockCancellationSavingRepo) Delete(id string) error {
+	for i, s := range m.savings {
+		if s.ID.String() == id {
+			m.savings = append(m.savings[:i], m.savings[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Mock SubscriptionShareRepository for simulation tests
 // ---------------------------------------------------------------------------
@@ -45,6 +81,7 @@ func (m *mockShareRepoForSimulation) Delete(id string) error
 func (m *mockShareRepoForSimulation) DeleteBySubscriptionID(subscriptionID string) error {
 	return nil
 }
+func (m *mockShareRepoForSimulation) FindSharingUserIDs() ([]string, error) { return nil, nil }
 
 // suppress unused import warnings
 var _ repositories.SubscriptionShareRepository = (*mockShareRepoForSimulation)(nil)
@@ -335,6 +372,50 @@ func TestApplySimulation(t *testing.T) {
 		assertEqual(t, found, true)
 	})
 
+	t.Run("publishes cancelled events", func(t *testing.T) {
+		repo := newMockRepo()
+		events := &recordingPublisher{}
+		svc := NewSimulationServiceWithDeps(repo, newMockShareRepoForSim(), SimulationDeps{Events: events})
+
+		sub := repo.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+
+		err := svc.ApplySimulation(userID.String(), &ApplySimulationRequest{
+			Action:          "cancel",
+			SubscriptionIDs: []string{sub.ID.String()},
+		})
+		assertNil(t, err)
+		assertEqual(t, events.events, []models.WebhookEventType{models.WebhookEventSubscriptionCancelled})
+		assertEqual(t, events.data[0].(WebhookSubscriptionData).ServiceName, "Netflix")
+	})
+
+	t.Run("records realized savings and undo removes them", func(t *testing.T) {
+		repo := newMockRepo()
+		shareRepo := newMockShareRepoForSim()
+		savings := &mockCancellationSavingRepo{}
+		clock := NewUserClock(fixedClock(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)), nil)
+		svc := NewSimulationServiceWithDeps(repo, shareRepo, SimulationDeps{Clock: clock, Savings: savings})
+
+		netflix := repo.seedSubscriptionWithDetails(userID, "Netflix", 17000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+		yearly := repo.seedSubscriptionWithDetails(userID, "Yearly", 120000, models.BillingCycleYearly, models.SubscriptionStatusActive, nil, nil)
+		shareRepo.shares["s1"] = &models.SubscriptionShare{
+			SubscriptionID: netflix.ID, SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 2,
+		}
+
+		err := svc.ApplySimulation(userID.String(), &ApplySimulationRequest{
+			Action:          "cancel",
+			SubscriptionIDs: []string{netflix.ID.String(), yearly.ID.String()},
+		})
+		assertNil(t, err)
+		assertEqual(t, len(savings.savings), 2)
+		assertEqual(t, savings.savings[0].MonthlyAmount, 8500)
+		assertEqual(t, savings.savings[0].ServiceName, "Netflix")
+		assertEqual(t, savings.savings[1].MonthlyAmount, 10000)
+		assertEqual(t, savings.savings[1].CancelledOn, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+		assertNil(t, svc.UndoSimulation(userID.String()))
+		assertEqual(t, len(savings.savings), 0)
+	})
+
 	t.Run("returns error for non-existent subscription", func(t *testing.T) {
 		repo := newMockRepo()
 		shareRepo := newMockShareRepoForSim()
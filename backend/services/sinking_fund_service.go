@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// CreateSinkingFundContributionRequest holds the body for recording money set
+// aside for a subscription's renewal. ContributedOn defaults to today.
+type CreateSinkingFundContributionRequest struct {
+	Amount        int     `json:"amount" validate:"required,gt=0,lte=99999999"`
+	ContributedOn *string `json:"contributedOn"`
+	Note          *string `json:"note" validate:"omitempty,max=200"`
+}
+
+// SinkingFundPlan lists the funds for every long-cycle subscription and the
+// total to set aside each month.
+type SinkingFundPlan struct {
+	TotalMonthlySetAside int           `json:"totalMonthlySetAside"`
+	TotalTarget          int           `json:"totalTarget"`
+	TotalSaved           int           `json:"totalSaved"`
+	Funds                []SinkingFund `json:"funds"`
+}
+
+// SinkingFund tracks saving for the next renewal of one subscription.
+// Contributions count towards the renewal when they were made on or after
+// CycleStart, the previous renewal; older ones paid for earlier renewals.
+// MonthlySetAside spreads the remaining amount over MonthsLeft, which counts
+// the current month and every month before the renewal month.
+type SinkingFund struct {
+	SubscriptionID  string                            `json:"subscriptionId"`
+	ServiceName     string                            `json:"serviceName"`
+	BillingCycle    models.BillingCycle               `json:"billingCycle"`
+	CycleStart      time.Time                         `json:"cycleStart"`
+	NextRenewal     time.Time                         `json:"nextRenewal"`
+	TargetAmount    int                               `json:"targetAmount"`
+	SavedAmount     int                               `json:"savedAmount"`
+	RemainingAmount int                               `json:"remainingAmount"`
+	MonthsLeft      int                               `json:"monthsLeft"`
+	MonthlySetAside int                               `json:"monthlySetAside"`
+	ProgressPercent float64                           `json:"progressPercent"`
+	Contributions   []*models.SinkingFundContribution `json:"contributions"`
+}
+
+// SinkingFundService plans savings for long-cycle subscription renewals.
+type SinkingFundService struct {
+	repo      repositories.SinkingFundRepository
+	subRepo   repositories.SubscriptionRepository
+	shareRepo repositories.SubscriptionShareRepository
+	clock     *UserClock
+}
+
+// NewSinkingFundService creates a new SinkingFundService.
+// clock may be nil, in which case the system clock and default time zone are used.
+func NewSinkingFundService(repo repositories.SinkingFundRepository, subRepo repositories.SubscriptionRepository, shareRepo repositories.SubscriptionShareRepository, clock *UserClock) *SinkingFundService {
+	return &SinkingFundService{repo: repo, subRepo: subRepo, shareRepo: shareRepo, clock: clock}
+}
+
+// hasSinkingFund reports whether a subscription is billed rarely enough to
+// save towards its renewal, i.e. on a cycle longer than a month, and that
+// renewal will be charged. As in the billing schedule, a subscription that
+// does not auto-renew is still charged on its next billing date but not
+// after it, and renewals covered by a scheduled pause are not charged.
+func hasSinkingFund(sub *models.Subscription, today time.Time) bool {
+	if sub.Status != models.SubscriptionStatusActive || sub.BillingCycle != models.BillingCycleYearly {
+		return false
+	}
+	renewal := nextRenewal(sub, today)
+	if !sub.AutoRenew && !renewal.Equal(truncateDate(sub.NextBillingDate)) {
+		return false
+	}
+	return !sub.PauseScheduledOn(renewal)
+}
+
+// nextRenewal returns the first billing date of the subscription on or after
+// today.
+func nextRenewal(sub *models.Subscription, today time.Time) time.Time {
+	anchor := truncateDate(sub.NextBillingDate)
+	renewal := anchor
+	for k := 1; renewal.Before(today); k++ {
+		renewal = billingDateAt(sub.BillingCycle, anchor, k)
+	}
+	return renewal
+}
+
+// GetPlan returns the sinking funds of the user's active long-cycle
+// subscriptions, soonest renewal first.
+func (s *SinkingFundService) GetPlan(userID string) (*SinkingFundPlan, error) {
+	activeSubs, err := findAllSubscriptions(s.subRepo, userID, repositories.SubscriptionFilter{Status: "active"})
+	if err != nil {
+		slog.Error("적립 계획 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("적립 계획을 계산할 수 없습니다")
+	}
+	contributions, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("적립 내역 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("적립 내역을 조회할 수 없습니다")
+	}
+
+	bySub := make(map[string][]*models.SinkingFundContribution)
+	for _, c := range contributions {
+		bySub[c.SubscriptionID.String()] = append(bySub[c.SubscriptionID.String()], c)
+	}
+
+	shareMap := buildShareMap(s.shareRepo, userID)
+	today := s.clock.Today(userID)
+
+	plan := &SinkingFundPlan{Funds: make([]SinkingFund, 0)}
+	for _, sub := range activeSubs {
+		if !hasSinkingFund(sub, today) {
+			continue
+		}
+		fund := buildSinkingFund(sub, shareMap[sub.ID.String()], bySub[sub.ID.String()], today)
+		plan.TotalMonthlySetAside += fund.MonthlySetAside
+		plan.TotalTarget += fund.TargetAmount
+		plan.TotalSaved += fund.SavedAmount
+		plan.Funds = append(plan.Funds, fund)
+	}
+
+	sort.SliceStable(plan.Funds, func(i, j int) bool {
+		if !plan.Funds[i].NextRenewal.Equal(plan.Funds[j].NextRenewal) {
+			return plan.Funds[i].NextRenewal.Before(plan.Funds[j].NextRenewal)
+		}
+		return plan.Funds[i].ServiceName < plan.Funds[j].ServiceName
+	})
+	return plan, nil
+}
+
+// buildSinkingFund works out the saving progress and monthly set-aside for
+// the subscription's next renewal on or after today.
+func buildSinkingFund(sub *models.Subscription, share *models.SubscriptionShare, contributions []*models.SinkingFundContribution, today time.Time) SinkingFund {
+	renewal := nextRenewal(sub, today)
+	cycleStart := billingDateAt(sub.BillingCycle, renewal, -1)
+
+	priced := *sub
+	priced.Amount = sub.AmountOn(renewal)
+
+	fund := SinkingFund{
+		SubscriptionID: sub.ID.String(),
+		ServiceName:    sub.ServiceName,
+		BillingCycle:   sub.BillingCycle,
+		CycleStart:     cycleStart,
+		NextRenewal:    renewal,
+		TargetAmount:   personalChargeAmount(&priced, share),
+		Contributions:  make([]*models.SinkingFundContribution, 0),
+	}
+	for _, c := range contributions {
+		if truncateDate(c.ContributedOn).Before(cycleStart) {
+			continue
+		}
+		fund.SavedAmount += c.Amount
+		fund.Contributions = append(fund.Contributions, c)
+	}
+
+	fund.RemainingAmount = max(fund.TargetAmount-fund.SavedAmount, 0)
+	fund.MonthsLeft = max((renewal.Year()-today.Year())*12+int(renewal.Month()-today.Month()), 1)
+	fund.MonthlySetAside = int(math.Ceil(float64(fund.RemainingAmount) / float64(fund.MonthsLeft)))
+	if fund.TargetAmount > 0 {
+		fund.ProgressPercent = math.Min(math.Round(float64(fund.SavedAmount)/float64(fund.TargetAmount)*1000)/10, 100)
+	}
+	return fund
+}
+
+// AddContribution records money set aside for a long-cycle subscription.
+func (s *SinkingFundService) AddContribution(userID, subID string, req *CreateSinkingFundContributionRequest) (*models.SinkingFundContribution, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	sub, err := s.subRepo.FindByID(subID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("구독을 찾을 수 없습니다")
+		}
+		slog.Error("적립 구독 조회 실패", "subID", subID, "error", err)
+		return nil, utils.ErrInternal("구독을 조회할 수 없습니다")
+	}
+	if sub.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 구독에 대한 접근 권한이 없습니다")
+	}
+	today := s.clock.Today(userID)
+	if !hasSinkingFund(sub, today) {
+		return nil, utils.ErrValidation("갱신 결제가 예정된 연간 구독에만 적립할 수 있습니다")
+	}
+
+	contributedOn, appErr := parseOptionalDate(req.ContributedOn, "적립일")
+	if appErr != nil {
+		return nil, appErr
+	}
+	if contributedOn == nil {
+		contributedOn = &today
+	}
+	if contributedOn.After(today) {
+		return nil, utils.ErrValidation("적립일은 오늘 이후일 수 없습니다")
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	contribution := &models.SinkingFundContribution{
+		UserID:         uid,
+		SubscriptionID: sub.ID,
+		Amount:         req.Amount,
+		ContributedOn:  *contributedOn,
+		Note:           req.Note,
+	}
+	if err := s.repo.Create(contribution); err != nil {
+		slog.Error("적립 저장 실패", "subID", subID, "error", err)
+		return nil, utils.ErrInternal("적립을 저장할 수 없습니다")
+	}
+	return contribution, nil
+}
+
+// DeleteContribution validates ownership and deletes a contribution.
+func (s *SinkingFundService) DeleteContribution(userID, contributionID string) error {
+	contribution, err := s.repo.FindByID(contributionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound("적립 내역을 찾을 수 없습니다")
+		}
+		slog.Error("적립 내역 조회 실패", "contributionID", contributionID, "error", err)
+		return utils.ErrInternal("적립 내역을 조회할 수 없습니다")
+	}
+	if contribution.UserID.String() != userID {
+		return utils.ErrForbidden("해당 적립 내역에 대한 접근 권한이 없습니다")
+	}
+
+	if err := s.repo.Delete(contributionID); err != nil {
+		slog.Error("적립 내역 삭제 실패", "contributionID", contributionID, "error", err)
+		return utils.ErrInternal("적립 내역을 삭제할 수 없습니다")
+	}
+	return nil
+}
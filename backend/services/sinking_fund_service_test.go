@@ -0,0 +1,273 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock repository for sinking fund tests
+// ---------------------------------------------------------------------------
+
+type mockSinkingFundRepo struct {
+	contributions map[string]*models.SinkingFundContribution
+}
+
+func newMockSinkingFundRepo() *mockSinkingFundRepo {
+	return &mockSinkingFundRepo{contributions: make(map[string]*models.SinkingFundContribution)}
+}
+
+func (m *mockSinkingFundRepo) FindByID(id string) (*models.SinkingFundContribution, error) {
+	c, ok := m.contributions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return c, nil
+}
+
+func (m *mockSinkingFundRepo) FindByUserID(userID string) ([]*models.SinkingFundContribution, error) {
+	var result []*models.SinkingFundContribution
+	for _, c := range m.contributions {
+		if c.UserID.String() == userID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSinkingFundRepo) Create(contribution *models.SinkingFundContribution) error {
+	if contribution.ID == uuid.Nil {
+		contribution.ID = uuid.New()
+	}
+	m.contributions[contribution.ID.String()] = contribution
+	return nil
+}
+
+func (m *mockSinkingFundRepo) Delete(id string) error {
+	delete(m.contributions, id)
+	return nil
+}
+
+func (m *mockSinkingFundRepo) seedContribution(sub *models.Subscription, amount int, on time.Time) *models.SinkingFundContribution {
+	c := &models.SinkingFundContribution{
+		ID:             uuid.New(),
+		UserID:         sub.UserID,
+		SubscriptionID: sub.ID,
+		Amount:         amount,
+		ContributedOn:  on,
+	}
+	m.contributions[c.ID.String()] = c
+	return c
+}
+
+func newTestSinkingFundService(now time.Time) (*SinkingFundService, *mockSinkingFundRepo, *mockSubscriptionRepo, *mockShareRepoForCalendar) {
+	repo := newMockSinkingFundRepo()
+	subRepo := newMockRepo()
+	shareRepo := newMockShareRepoForCalendar()
+	svc := NewSinkingFundService(repo, subRepo, shareRepo, NewUserClock(fixedClock(now), nil))
+	return svc, repo, subRepo, shareRepo
+}
+
+// ===========================================================================
+// GetPlan
+// ===========================================================================
+
+func TestSinkingFundPlan(t *testing.T) {
+	svc, repo, subRepo, shareRepo := newTestSinkingFundService(time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+
+	cloud := subRepo.seedSubscription(userID, "Cloud", 120000, models.BillingCycleYearly)
+	cloud.NextBillingDate = ymd(2026, 9, 15)
+	repo.seedContribution(cloud, 20000, ymd(2026, 1, 5))
+	// Set aside for the renewal on 2025-09-15, which has already been paid.
+	repo.seedContribution(cloud, 5000, ymd(2025, 8, 1))
+
+	// A stale next billing date rolls forward to the upcoming renewal.
+	family := subRepo.seedSubscription(userID, "Family", 60000, models.BillingCycleYearly)
+	family.NextBillingDate = ymd(2025, 6, 1)
+	shareRepo.shares["s1"] = &models.SubscriptionShare{
+		SubscriptionID:       family.ID,
+		SplitType:            models.SplitTypeEqual,
+		TotalMembersSnapshot: 2,
+	}
+
+	subRepo.seedSubscription(userID, "Music", 10900, models.BillingCycleMonthly)
+	paused := subRepo.seedSubscription(userID, "Paused", 50000, models.BillingCycleYearly)
+	paused.Status = models.SubscriptionStatusPaused
+
+	plan, err := svc.GetPlan(userID.String())
+	assertNil(t, err)
+	assertEqual(t, len(plan.Funds), 2)
+
+	first := plan.Funds[0]
+	assertEqual(t, first.ServiceName, "Family")
+	assertEqual(t, first.NextRenewal, ymd(2026, 6, 1))
+	assertEqual(t, first.CycleStart, ymd(2025, 6, 1))
+	assertEqual(t, first.TargetAmount, 30000)
+	assertEqual(t, first.MonthsLeft, 3)
+	assertEqual(t, first.MonthlySetAside, 10000)
+
+	second := plan.Funds[1]
+	assertEqual(t, second.ServiceName, "Cloud")
+	assertEqual(t, second.TargetAmount, 120000)
+	assertEqual(t, second.SavedAmount, 20000)
+	assertEqual(t, second.RemainingAmount, 100000)
+	assertEqual(t, second.MonthsLeft, 6)
+	assertEqual(t, second.MonthlySetAside, 16667)
+	assertEqual(t, second.ProgressPercent, 16.7)
+	assertEqual(t, len(second.Contributions), 1)
+
+	assertEqual(t, plan.TotalMonthlySetAside, 26667)
+	assertEqual(t, plan.TotalTarget, 150000)
+	assertEqual(t, plan.TotalSaved, 20000)
+}
+
+func TestSinkingFundPlan_FullyFunded(t *testing.T) {
+	svc, repo, subRepo, _ := newTestSinkingFundService(time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+
+	sub := subRepo.seedSubscription(userID, "Cloud", 120000, models.BillingCycleYearly)
+	sub.NextBillingDate = ymd(2026, 9, 15)
+	repo.seedContribution(sub, 100000, ymd(2026, 1, 5))
+	repo.seedContribution(sub, 30000, ymd(2026, 3, 10))
+
+	plan, err := svc.GetPlan(userID.String())
+	assertNil(t, err)
+	fund := plan.Funds[0]
+	assertEqual(t, fund.SavedAmount, 130000)
+	assertEqual(t, fund.RemainingAmount, 0)
+	assertEqual(t, fund.MonthlySetAside, 0)
+	assertEqual(t, fund.ProgressPercent, 100.0)
+}
+
+func TestSinkingFundPlan_RenewalThisMonthAndPromotion(t *testing.T) {
+	svc, _, subRepo, _ := newTestSinkingFundService(time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+
+	// The promotion ends before the renewal, so the regular price is targeted.
+	sub := subRepo.seedSubscription(userID, "Cloud", 120000, models.BillingCycleYearly)
+	sub.NextBillingDate = ymd(2026, 3, 25)
+	sub.PromoAmount = intPtr(60000)
+	promoEnd := ymd(2026, 3, 20)
+	sub.PromoEndDate = &promoEnd
+
+	plan, err := svc.GetPlan(userID.String())
+	assertNil(t, err)
+	fund := plan.Funds[0]
+	assertEqual(t, fund.TargetAmount, 120000)
+	assertEqual(t, fund.MonthsLeft, 1)
+	assertEqual(t, fund.MonthlySetAside, 120000)
+}
+
+func TestSinkingFundPlan_SkipsRenewalsThatWillNotBeCharged(t *testing.T) {
+	svc, _, subRepo, _ := newTestSinkingFundService(time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+
+	// Without auto-renew the next billing date is still charged, as in the
+	// billing schedule, but nothing after it.
+	ending := subRepo.seedSubscription(userID, "Ending", 120000, models.BillingCycleYearly)
+	ending.NextBillingDate = ymd(2026, 8, 20)
+	ending.AutoRenew = false
+	assertEqual(t, expandBillingDates(ending, ymd(2026, 3, 10), ymd(2027, 12, 31), ymd(2026, 3, 10)), []time.Time{ymd(2026, 8, 20)})
+
+	ended := subRepo.seedSubscription(userID, "Ended", 120000, models.BillingCycleYearly)
+	ended.NextBillingDate = ymd(2026, 2, 15)
+	ended.AutoRenew = false
+
+	pauseStart, pauseEnd := ymd(2026, 8, 1), ymd(2026, 10, 1)
+	paused := subRepo.seedSubscription(userID, "Paused", 90000, models.BillingCycleYearly)
+	paused.NextBillingDate = ymd(2026, 9, 15)
+	paused.PauseStartDate, paused.PauseEndDate = &pauseStart, &pauseEnd
+
+	// A pause that ends before the renewal does not matter.
+	earlyStart, earlyEnd := ymd(2026, 4, 1), ymd(2026, 6, 1)
+	resumed := subRepo.seedSubscription(userID, "Resumed", 60000, models.BillingCycleYearly)
+	resumed.NextBillingDate = ymd(2026, 9, 15)
+	resumed.PauseStartDate, resumed.PauseEndDate = &earlyStart, &earlyEnd
+
+	plan, err := svc.GetPlan(userID.String())
+	assertNil(t, err)
+	assertEqual(t, len(plan.Funds), 2)
+	assertEqual(t, plan.Funds[0].ServiceName, "Ending")
+	assertEqual(t, plan.Funds[1].ServiceName, "Resumed")
+	assertEqual(t, plan.TotalTarget, 180000)
+
+	for _, sub := range []*models.Subscription{ended, paused} {
+		_, err := svc.AddContribution(userID.String(), sub.ID.String(), &CreateSinkingFundContributionRequest{Amount: 10000})
+		assertAppErrorCode(t, err, 422)
+	}
+}
+
+// ===========================================================================
+// AddContribution / DeleteContribution
+// ===========================================================================
+
+func TestAddSinkingFundContribution(t *testing.T) {
+	svc, repo, subRepo, _ := newTestSinkingFundService(time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+	yearly := subRepo.seedSubscription(userID, "Cloud", 120000, models.BillingCycleYearly)
+
+	t.Run("defaults to today", func(t *testing.T) {
+		c, err := svc.AddContribution(userID.String(), yearly.ID.String(), &CreateSinkingFundContributionRequest{Amount: 10000})
+		assertNil(t, err)
+		assertEqual(t, c.ContributedOn, ymd(2026, 3, 10))
+		assertEqual(t, c.SubscriptionID, yearly.ID)
+		assertEqual(t, len(repo.contributions), 1)
+	})
+
+	t.Run("explicit date", func(t *testing.T) {
+		c, err := svc.AddContribution(userID.String(), yearly.ID.String(), &CreateSinkingFundContributionRequest{
+			Amount:        5000,
+			ContributedOn: strPtr("2026-02-01"),
+			Note:          strPtr("bonus"),
+		})
+		assertNil(t, err)
+		assertEqual(t, c.ContributedOn, ymd(2026, 2, 1))
+	})
+
+	t.Run("future date", func(t *testing.T) {
+		_, err := svc.AddContribution(userID.String(), yearly.ID.String(), &CreateSinkingFundContributionRequest{
+			Amount:        5000,
+			ContributedOn: strPtr("2026-03-11"),
+		})
+		assertAppErrorCode(t, err, 422)
+	})
+
+	t.Run("non-positive amount", func(t *testing.T) {
+		_, err := svc.AddContribution(userID.String(), yearly.ID.String(), &CreateSinkingFundContributionRequest{Amount: 0})
+		assertError(t, err)
+	})
+
+	t.Run("monthly subscription", func(t *testing.T) {
+		monthly := subRepo.seedSubscription(userID, "Music", 10900, models.BillingCycleMonthly)
+		_, err := svc.AddContribution(userID.String(), monthly.ID.String(), &CreateSinkingFundContributionRequest{Amount: 5000})
+		assertAppErrorCode(t, err, 422)
+	})
+
+	t.Run("other user's subscription", func(t *testing.T) {
+		_, err := svc.AddContribution(uuid.New().String(), yearly.ID.String(), &CreateSinkingFundContributionRequest{Amount: 5000})
+		assertAppErrorCode(t, err, 403)
+	})
+
+	t.Run("missing subscription", func(t *testing.T) {
+		_, err := svc.AddContribution(userID.String(), uuid.New().String(), &CreateSinkingFundContributionRequest{Amount: 5000})
+		assertAppErrorCode(t, err, 404)
+	})
+}
+
+func TestDeleteSinkingFundContribution(t *testing.T) {
+	svc, repo, subRepo, _ := newTestSinkingFundService(time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC))
+	userID := uuid.New()
+	sub := subRepo.seedSubscription(userID, "Cloud", 120000, models.BillingCycleYearly)
+	c := repo.seedContribution(sub, 10000, ymd(2026, 3, 1))
+
+	assertAppErrorCode(t, svc.DeleteContribution(uuid.New().String(), c.ID.String()), 403)
+	assertAppErrorCode(t, svc.DeleteContribution(userID.String(), uuid.New().String()), 404)
+	assertNil(t, svc.DeleteContribution(userID.String(), c.ID.String()))
+	assertEqual(t, len(repo.contributions), 0)
+}
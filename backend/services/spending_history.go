@@ -0,0 +1,278 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// findAllSubscriptions loads every subscription matching the filter, page by
+// page, ignoring the filter's pagination.
+func findAllSubscriptions(repo repositories.SubscriptionRepository, userID string, filter repositories.SubscriptionFilter) ([]*models.Subscription, error) {
+	all := make([]*models.Subscription, 0)
+	filter.PerPage = 100
+	for filter.Page = 1; ; filter.Page++ {
+		subs, total, err := repo.FindByUserID(userID, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, subs...)
+		if len(subs) == 0 || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// loadChangesFor loads the complete price and status history of the given
+// subscriptions. Either repository may be nil, in which case that history is
+// left empty.
+func loadChangesFor(
+	priceRepo repositories.PriceChangeRepository,
+	statusRepo repositories.StatusChangeRepository,
+	subs []*models.Subscription,
+) ([]*models.PriceChange, []*models.StatusChange, error) {
+	ids := make([]string, len(subs))
+	for i, sub := range subs {
+		ids[i] = sub.ID.String()
+	}
+
+	var prices []*models.PriceChange
+	if priceRepo != nil {
+		var err error
+		if prices, err = priceRepo.FindBySubscriptionIDs(ids); err != nil {
+			return nil, nil, err
+		}
+	}
+	var statuses []*models.StatusChange
+	if statusRepo != nil {
+		var err error
+		if statuses, err = statusRepo.FindBySubscriptionIDs(ids); err != nil {
+			return nil, nil, err
+		}
+	}
+	return prices, statuses, nil
+}
+
+// subscriptionHistory reconstructs what a subscription cost in the past from
+// its price and status history. Changes recorded before the history window
+// are not loaded; the old values of the earliest loaded change, or the
+// current values when there is none, are assumed to hold before it.
+type subscriptionHistory struct {
+	sub      *models.Subscription
+	share    *models.SubscriptionShare
+	prices   []*models.PriceChange  // oldest first
+	statuses []*models.StatusChange // oldest first
+	start    time.Time              // first day the subscription existed
+	end      time.Time              // deletion date, zero while not deleted
+}
+
+// historyCharge is a single past or scheduled charge of a subscription.
+type historyCharge struct {
+	date   time.Time
+	amount int // personal amount
+	full   int // amount charged to the card before splitting
+}
+
+// newSubscriptionHistories groups the price and status changes by
+// subscription. Deletion times are converted to dates in loc.
+func newSubscriptionHistories(
+	subs []*models.Subscription,
+	shareMap map[string]*models.SubscriptionShare,
+	prices []*models.PriceChange,
+	statuses []*models.StatusChange,
+	loc *time.Location,
+) []*subscriptionHistory {
+	byID := make(map[string]*subscriptionHistory, len(subs))
+	histories := make([]*subscriptionHistory, 0, len(subs))
+	for _, sub := range subs {
+		h := &subscriptionHistory{
+			sub:   sub,
+			share: shareMap[sub.ID.String()],
+			start: truncateDate(sub.StartDate),
+		}
+		if sub.DeletedAt.Valid {
+			h.end = truncateDate(sub.DeletedAt.Time.In(loc))
+		}
+		byID[sub.ID.String()] = h
+		histories = append(histories, h)
+	}
+	for _, change := range prices {
+		if h, ok := byID[change.SubscriptionID.String()]; ok {
+			h.prices = append(h.prices, change)
+		}
+	}
+	for _, change := range statuses {
+		if h, ok := byID[change.SubscriptionID.String()]; ok {
+			h.statuses = append(h.statuses, change)
+		}
+	}
+	for _, h := range histories {
+		sort.SliceStable(h.prices, func(i, j int) bool {
+			return truncateDate(h.prices[i].EffectiveDate).Before(truncateDate(h.prices[j].EffectiveDate))
+		})
+		sort.SliceStable(h.statuses, func(i, j int) bool {
+			return truncateDate(h.statuses[i].EffectiveDate).Before(truncateDate(h.statuses[j].EffectiveDate))
+		})
+	}
+	return histories
+}
+
+// billedOn reports whether the subscription was active, and so billed, on d.
+func (h *subscriptionHistory) billedOn(d time.Time) bool {
+	if d.Before(h.start) || (!h.end.IsZero() && !d.Before(h.end)) {
+		return false
+	}
+	status := h.sub.Status
+	if len(h.statuses) > 0 {
+		status = h.statuses[0].OldStatus
+	}
+	for _, change := range h.statuses {
+		if truncateDate(change.EffectiveDate).After(d) {
+			break
+		}
+		status = change.NewStatus
+	}
+	return status == models.SubscriptionStatusActive
+}
+
+// priceOn returns the amount and billing cycle in effect on d.
+func (h *subscriptionHistory) priceOn(d time.Time) (int, models.BillingCycle) {
+	for _, change := range h.prices {
+		if truncateDate(change.EffectiveDate).After(d) {
+			return change.OldAmount, change.OldBillingCycle
+		}
+	}
+	return h.sub.Amount, h.sub.BillingCycle
+}
+
+// personalMonthlyOn returns the user's monthly-equivalent cost on d.
+func (h *subscriptionHistory) personalMonthlyOn(d time.Time) int {
+	amount, cycle := h.priceOn(d)
+	priced := models.Subscription{Amount: amount, BillingCycle: cycle}
+	return personalMonthlyAmount(&priced, h.share)
+}
+
+// charges returns the charges billed within [from, to]. Each price period is
+// billed on its own cycle: periods keep the billing day of the period after
+// them unless the cycle changed, in which case billing restarted on the day
+// of the change. Promotions and scheduled pauses apply as in the billing
+// schedule, and billing dates before the end of a free trial are not charged.
+// Dates on or after today are only included from the next billing date
+// onwards.
+func (h *subscriptionHistory) charges(from, to, today time.Time) []historyCharge {
+	result := make([]historyCharge, 0)
+
+	trialEnd := time.Time{}
+	if h.sub.TrialEndDate != nil {
+		trialEnd = truncateDate(*h.sub.TrialEndDate)
+	}
+	periodEnd := time.Time{}
+	anchor := truncateDate(h.sub.NextBillingDate)
+	amount, cycle := h.sub.Amount, h.sub.BillingCycle
+	autoRenew := h.sub.AutoRenew
+	for i := len(h.prices) - 1; i >= -1; i-- {
+		periodStart := h.start
+		if i >= 0 {
+			periodStart = truncateDate(h.prices[i].EffectiveDate)
+		}
+
+		priced := *h.sub
+		priced.Amount, priced.BillingCycle = amount, cycle
+		priced.NextBillingDate, priced.StartDate, priced.AutoRenew = anchor, periodStart, autoRenew
+		for _, d := range expandBillingDates(&priced, from, to, today) {
+			if d.Before(periodStart) || d.Before(trialEnd) || (!periodEnd.IsZero() && !d.Before(periodEnd)) {
+				continue
+			}
+			if h.billedOn(d) {
+				charged := pricedOn(&priced, d)
+				result = append(result, historyCharge{date: d, amount: personalChargeAmount(charged, h.share), full: charged.Amount})
+			}
+		}
+
+		if i < 0 || !periodStart.After(h.start) {
+			break
+		}
+		change := h.prices[i]
+		if change.OldBillingCycle != cycle {
+			anchor = periodStart
+		}
+		amount, cycle = change.OldAmount, change.OldBillingCycle
+		autoRenew = true
+		periodEnd = periodStart
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].date.Before(result[j].date) })
+	return result
+}
+
+// lifetimeSpend returns the personal amount charged from the first day the
+// subscription existed up to today, counting every billing cycle at the
+// price it had and skipping the time it was paused or cancelled.
+func (h *subscriptionHistory) lifetimeSpend(today time.Time) int {
+	return sumCharges(h.charges(h.start, today, today))
+}
+
+// amortizedCost spreads each monthly-equivalent cost over the days of its
+// month, counting only the days within [from, to] the subscription was billed.
+func (h *subscriptionHistory) amortizedCost(from, to time.Time) float64 {
+	total := 0.0
+	for d := truncateDate(from); !d.After(to); d = d.AddDate(0, 0, 1) {
+		if !h.billedOn(d) {
+			continue
+		}
+		daysInMonth := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		total += float64(h.personalMonthlyOn(d)) / float64(daysInMonth)
+	}
+	return total
+}
+
+// monthlySpending rebuilds the cash and amortized spending of each month in
+// [first, last] from the subscription histories. A subscription is counted in
+// a month when it was billed on any of its days.
+func monthlySpending(histories []*subscriptionHistory, first, last, today time.Time) []MonthlyTrend {
+	trends := make([]MonthlyTrend, 0)
+	for month := monthStart(first); !month.After(last); month = month.AddDate(0, 1, 0) {
+		monthEnd := month.AddDate(0, 1, -1)
+		trend := MonthlyTrend{Year: month.Year(), Month: int(month.Month())}
+
+		amortized := 0.0
+		for _, h := range histories {
+			if !h.billedOnAny(month, monthEnd) {
+				continue
+			}
+			trend.Count++
+			amortized += h.amortizedCost(month, monthEnd)
+			for _, charge := range h.charges(month, monthEnd, today) {
+				trend.CashAmount += charge.amount
+			}
+		}
+
+		trend.AmortizedAmount = int(math.Round(amortized))
+		trend.Amount = trend.AmortizedAmount
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+// billedOnAny reports whether the subscription was billed on any day in [from, to].
+func (h *subscriptionHistory) billedOnAny(from, to time.Time) bool {
+	for d := truncateDate(from); !d.After(to); d = d.AddDate(0, 0, 1) {
+		if h.billedOn(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastBilledOn returns the last day in [from, to] the subscription was billed.
+func (h *subscriptionHistory) lastBilledOn(from, to time.Time) (time.Time, bool) {
+	for d := truncateDate(to); !d.Before(from) && !d.Before(h.start); d = d.AddDate(0, 0, -1) {
+		if h.billedOn(d) {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
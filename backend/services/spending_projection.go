@@ -0,0 +1,176 @@
+package services
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// projectionMonths is the number of calendar months a spending projection covers.
+const projectionMonths = 12
+
+// projectionSpikeRatio marks a month as a spike when its cash outflow exceeds
+// the average monthly cash outflow of the projection by this factor.
+const projectionSpikeRatio = 1.5
+
+// SpendingProjection is the expected spending over the next 12 months, from
+// today to the end of the 11th month after the current one.
+type SpendingProjection struct {
+	From               time.Time                 `json:"from"`
+	To                 time.Time                 `json:"to"`
+	TotalCash          int                       `json:"totalCash"`
+	TotalAmortized     int                       `json:"totalAmortized"`
+	AverageMonthlyCash int                       `json:"averageMonthlyCash"`
+	Months             []SpendingProjectionMonth `json:"months"`
+}
+
+// SpendingProjectionMonth is the projected spending of one calendar month.
+// CashAmount is the user's share of the charges posted in the month and
+// ChargedAmount the full amount charged to the card. AmortizedAmount spreads
+// every plan's monthly-equivalent cost over the days it is in service. The
+// current month only counts from today.
+type SpendingProjectionMonth struct {
+	Year            int               `json:"year"`
+	Month           int               `json:"month"`
+	CashAmount      int               `json:"cashAmount"`
+	ChargedAmount   int               `json:"chargedAmount"`
+	AmortizedAmount int               `json:"amortizedAmount"`
+	Spike           bool              `json:"spike"`
+	Charges         []ProjectedCharge `json:"charges"`
+}
+
+// ProjectedCharge is a single expected charge. Date is the nominal billing
+// date and PostingDate the date after the business-day adjustment.
+type ProjectedCharge struct {
+	SubscriptionID string              `json:"subscriptionId"`
+	ServiceName    string              `json:"serviceName"`
+	BillingCycle   models.BillingCycle `json:"billingCycle"`
+	Date           time.Time           `json:"date"`
+	PostingDate    time.Time           `json:"postingDate"`
+	Amount         int                 `json:"amount"`
+	PersonalAmount int                 `json:"personalAmount"`
+	Promotional    bool                `json:"promotional"`
+	Final          bool                `json:"final"` // last charge before a pending cancellation
+}
+
+// GetSpendingProjection projects the user's spending over the next 12 months
+// from every active and paused subscription. Promotions bill their
+// promotional amount until they end, scheduled pauses skip charges, paused
+// subscriptions resume at the end of their scheduled pause, free trials are
+// not charged, and subscriptions that do not auto-renew end after their next
+// billing period.
+func (s *ForecastService) GetSpendingProjection(userID string) (*SpendingProjection, error) {
+	subs, err := findAllSubscriptions(s.subRepo, userID, repositories.SubscriptionFilter{})
+	if err != nil {
+		slog.Error("지출 전망 구독 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("지출 전망을 계산할 수 없습니다")
+	}
+	shareMap := buildShareMap(s.shareRepo, userID)
+
+	today := s.clock.Today(userID)
+	end := monthStart(today).AddDate(0, projectionMonths, -1)
+
+	projection := &SpendingProjection{From: today, To: end, Months: make([]SpendingProjectionMonth, projectionMonths)}
+	index := make(map[time.Time]int, projectionMonths)
+	for i := range projection.Months {
+		month := monthStart(today).AddDate(0, i, 0)
+		projection.Months[i] = SpendingProjectionMonth{Year: month.Year(), Month: int(month.Month()), Charges: make([]ProjectedCharge, 0)}
+		index[month] = i
+	}
+
+	amortized := make([]float64, projectionMonths)
+	for _, sub := range subs {
+		if sub.Status == models.SubscriptionStatusCancelled {
+			continue
+		}
+		share := shareMap[sub.ID.String()]
+
+		for d := today; !d.After(end); d = d.AddDate(0, 0, 1) {
+			if !projectionInService(sub, d) {
+				continue
+			}
+			daysInMonth := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			amortized[index[monthStart(d)]] += float64(personalMonthlyAmount(pricedOn(sub, d), share)) / float64(daysInMonth)
+		}
+
+		// Expand a little beyond the window so charges shifted into it by the
+		// business-day adjustment are not missed.
+		for _, d := range expandBillingDates(sub, today.AddDate(0, 0, -maxBusinessDayShift), end.AddDate(0, 0, maxBusinessDayShift), today) {
+			posting := s.holidays.Adjust(d, sub.BusinessDayAdjustment)
+			if posting.Before(today) || posting.After(end) || !projectionInService(sub, d) {
+				continue
+			}
+			priced := pricedOn(sub, d)
+			charge := ProjectedCharge{
+				SubscriptionID: sub.ID.String(),
+				ServiceName:    sub.ServiceName,
+				BillingCycle:   sub.BillingCycle,
+				Date:           d,
+				PostingDate:    posting,
+				Amount:         priced.Amount,
+				PersonalAmount: personalChargeAmount(priced, share),
+				Promotional:    priced.Amount != sub.Amount,
+				Final:          !sub.AutoRenew,
+			}
+
+			month := &projection.Months[index[monthStart(posting)]]
+			month.CashAmount += charge.PersonalAmount
+			month.ChargedAmount += charge.Amount
+			month.Charges = append(month.Charges, charge)
+		}
+	}
+
+	for i := range projection.Months {
+		month := &projection.Months[i]
+		month.AmortizedAmount = int(math.Round(amortized[i]))
+		sortProjectedCharges(month.Charges)
+		projection.TotalCash += month.CashAmount
+		projection.TotalAmortized += month.AmortizedAmount
+	}
+	projection.AverageMonthlyCash = int(math.Round(float64(projection.TotalCash) / projectionMonths))
+	for i := range projection.Months {
+		month := &projection.Months[i]
+		month.Spike = projection.AverageMonthlyCash > 0 &&
+			float64(month.CashAmount) > float64(projection.AverageMonthlyCash)*projectionSpikeRatio
+	}
+
+	return projection, nil
+}
+
+// projectionInService reports whether the subscription is expected to be in
+// paid service on d.
+func projectionInService(sub *models.Subscription, d time.Time) bool {
+	if d.Before(truncateDate(sub.StartDate)) {
+		return false
+	}
+	if sub.TrialEndDate != nil && d.Before(truncateDate(*sub.TrialEndDate)) {
+		return false
+	}
+	if sub.Status == models.SubscriptionStatusPaused {
+		return sub.PauseEndDate != nil && !d.Before(truncateDate(*sub.PauseEndDate))
+	}
+	if sub.PauseScheduledOn(d) {
+		return false
+	}
+	// A subscription that does not auto-renew ends with the period paid by
+	// its next charge.
+	if !sub.AutoRenew && !d.Before(billingDateAt(sub.BillingCycle, truncateDate(sub.NextBillingDate), 1)) {
+		return false
+	}
+	return true
+}
+
+// sortProjectedCharges orders charges by posting date, then by service name.
+func sortProjectedCharges(charges []ProjectedCharge) {
+	sort.SliceStable(charges, func(i, j int) bool {
+		if !charges[i].PostingDate.Equal(charges[j].PostingDate) {
+			return charges[i].PostingDate.Before(charges[j].PostingDate)
+		}
+		return charges[i].ServiceName < charges[j].ServiceName
+	})
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,31 +18,45 @@ import (
 
 // CreateSubscriptionRequest holds the body for creating a subscription.
 type CreateSubscriptionRequest struct {
-	ServiceName       string  `json:"serviceName" validate:"required,min=1,max=100"`
-	CategoryID        *string `json:"categoryId" validate:"omitempty,uuid"`
-	Amount            int     `json:"amount" validate:"required,gte=0,lte=9999999"`
-	BillingCycle      string  `json:"billingCycle" validate:"required,oneof=weekly monthly yearly"`
-	NextBillingDate   string  `json:"nextBillingDate" validate:"required"`
-	AutoRenew         *bool   `json:"autoRenew"`
-	Status            string  `json:"status" validate:"omitempty,oneof=active paused"`
-	SatisfactionScore *int    `json:"satisfactionScore" validate:"omitempty,min=1,max=5"`
-	Note              *string `json:"note" validate:"omitempty,max=500"`
-	ServiceURL        *string `json:"serviceUrl" validate:"omitempty,url,max=255"`
-	StartDate         *string `json:"startDate"`
+	ServiceName           string  `json:"serviceName" validate:"required,min=1,max=100"`
+	CategoryID            *string `json:"categoryId" validate:"omitempty,uuid"`
+	Amount                int     `json:"amount" validate:"required,gte=0,lte=9999999"`
+	BillingCycle          string  `json:"billingCycle" validate:"required,oneof=weekly monthly yearly"`
+	NextBillingDate       string  `json:"nextBillingDate" validate:"required"`
+	AutoRenew             *bool   `json:"autoRenew"`
+	Status                string  `json:"status" validate:"omitempty,oneof=active paused"`
+	SatisfactionScore     *int    `json:"satisfactionScore" validate:"omitempty,min=1,max=5"`
+	Note                  *string `json:"note" validate:"omitempty,max=500"`
+	ServiceURL            *string `json:"serviceUrl" validate:"omitempty,url,max=255"`
+	StartDate             *string `json:"startDate"`
+	TrialEndDate          *string `json:"trialEndDate"`
+	PromoAmount           *int    `json:"promoAmount" validate:"omitempty,gte=0,lte=9999999"`
+	PromoEndDate          *string `json:"promoEndDate"`
+	PauseStartDate        *string `json:"pauseStartDate"`
+	PauseEndDate          *string `json:"pauseEndDate"`
+	BusinessDayAdjustment string  `json:"businessDayAdjustment" validate:"omitempty,oneof=none next_business_day previous_business_day"`
+	FunctionalGroup       string  `json:"functionalGroup" validate:"omitempty,oneof=music video storage ai_assistant productivity news gaming education fitness shopping"`
 }
 
 // UpdateSubscriptionRequest holds the body for updating a subscription.
 type UpdateSubscriptionRequest struct {
-	ServiceName       *string `json:"serviceName" validate:"omitempty,min=1,max=100"`
-	CategoryID        *string `json:"categoryId" validate:"omitempty,uuid"`
-	Amount            *int    `json:"amount" validate:"omitempty,gte=0,lte=9999999"`
-	BillingCycle      *string `json:"billingCycle" validate:"omitempty,oneof=weekly monthly yearly"`
-	NextBillingDate   *string `json:"nextBillingDate"`
-	AutoRenew         *bool   `json:"autoRenew"`
-	Status            *string `json:"status" validate:"omitempty,oneof=active paused cancelled"`
-	SatisfactionScore *int    `json:"satisfactionScore" validate:"omitempty,min=1,max=5"`
-	Note              *string `json:"note" validate:"omitempty,max=500"`
-	ServiceURL        *string `json:"serviceUrl" validate:"omitempty,url,max=255"`
+	ServiceName           *string `json:"serviceName" validate:"omitempty,min=1,max=100"`
+	CategoryID            *string `json:"categoryId" validate:"omitempty,uuid"`
+	Amount                *int    `json:"amount" validate:"omitempty,gte=0,lte=9999999"`
+	BillingCycle          *string `json:"billingCycle" validate:"omitempty,oneof=weekly monthly yearly"`
+	NextBillingDate       *string `json:"nextBillingDate"`
+	AutoRenew             *bool   `json:"autoRenew"`
+	Status                *string `json:"status" validate:"omitempty,oneof=active paused cancelled"`
+	SatisfactionScore     *int    `json:"satisfactionScore" validate:"omitempty,min=1,max=5"`
+	Note                  *string `json:"note" validate:"omitempty,max=500"`
+	ServiceURL            *string `json:"serviceUrl" validate:"omitempty,url,max=255"`
+	TrialEndDate          *string `json:"trialEndDate"`
+	PromoAmount           *int    `json:"promoAmount" validate:"omitempty,gte=0,lte=9999999"`
+	PromoEndDate          *string `json:"promoEndDate"`   // empty clears the promotion
+	PauseStartDate        *string `json:"pauseStartDate"` // empty clears the scheduled pause
+	PauseEndDate          *string `json:"pauseEndDate"`   // empty makes the pause open-ended
+	BusinessDayAdjustment *string `json:"businessDayAdjustment" validate:"omitempty,oneof=none next_business_day previous_business_day"`
+	FunctionalGroup       *string `json:"functionalGroup"`
 }
 
 // DuplicateCheckResult holds the result of duplicate/similar subscription check.
@@ -66,18 +82,72 @@ type SimilarEntry struct {
 	Subscriptions []DuplicateEntry `json:"subscriptions"`
 }
 
+// List sort keys computed by the service rather than the database.
+const (
+	// SubscriptionSortValueScore orders subscriptions by value score.
+	SubscriptionSortValueScore = "value_score"
+	// SubscriptionSortLifetimeSpend orders subscriptions by lifetime spend.
+	SubscriptionSortLifetimeSpend = "lifetime_spend"
+)
+
 // SubscriptionService handles business logic for subscriptions.
 type SubscriptionService struct {
-	repo repositories.SubscriptionRepository
+	repo       repositories.SubscriptionRepository
+	priceRepo  repositories.PriceChangeRepository
+	clock      *UserClock
+	events     EventPublisher
+	inbox      *InboxService
+	catalog    *ServiceCatalog
+	cache      *AggregateCache
+	shareRepo  repositories.SubscriptionShareRepository
+	statusRepo repositories.StatusChangeRepository
+	scoreRepo  repositories.SatisfactionChangeRepository
+}
+
+// SubscriptionDeps holds the optional dependencies of a SubscriptionService.
+// Any of them may be nil.
+type SubscriptionDeps struct {
+	PriceRepo  repositories.PriceChangeRepository        // nil: price changes are not recorded
+	Clock      *UserClock                                // nil: the system clock and default time zone are used
+	Events     EventPublisher                            // nil: no lifecycle events are published
+	Inbox      *InboxService                             // nil: price changes are not written to the inbox
+	Catalog    *ServiceCatalog                           // nil: functional groups are only set manually
+	Cache      *AggregateCache                           // nil: no cached aggregates are invalidated
+	ShareRepo  repositories.SubscriptionShareRepository  // nil: value scores use the full price
+	StatusRepo repositories.StatusChangeRepository       // nil: status changes are not recorded
+	ScoreRepo  repositories.SatisfactionChangeRepository // nil: satisfaction changes are not recorded
 }
 
-// NewSubscriptionService creates a new SubscriptionService.
+// NewSubscriptionService creates a new SubscriptionService without optional dependencies.
 func NewSubscriptionService(repo repositories.SubscriptionRepository) *SubscriptionService {
-	return &SubscriptionService{repo: repo}
+	return NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{})
+}
+
+// NewSubscriptionServiceWithDeps creates a new SubscriptionService with the given optional dependencies.
+func NewSubscriptionServiceWithDeps(repo repositories.SubscriptionRepository, deps SubscriptionDeps) *SubscriptionService {
+	return &SubscriptionService{
+		repo:       repo,
+		priceRepo:  deps.PriceRepo,
+		clock:      deps.Clock,
+		events:     deps.Events,
+		inbox:      deps.Inbox,
+		catalog:    deps.Catalog,
+		cache:      deps.Cache,
+		shareRepo:  deps.ShareRepo,
+		statusRepo: deps.StatusRepo,
+		scoreRepo:  deps.ScoreRepo,
+	}
 }
 
 // GetSubscriptions returns a paginated, filtered list of subscriptions for the user.
 func (s *SubscriptionService) GetSubscriptions(userID string, filter repositories.SubscriptionFilter) ([]*models.Subscription, int64, error) {
+	switch filter.SortBy {
+	case SubscriptionSortValueScore:
+		return s.getSubscriptionsByComputedKey(userID, filter, s.ValueScores)
+	case SubscriptionSortLifetimeSpend:
+		return s.getSubscriptionsByComputedKey(userID, filter, s.LifetimeSpend)
+	}
+
 	subs, total, err := s.repo.FindByUserID(userID, filter)
 	if err != nil {
 		slog.Error("구독 목록 조회 실패", "userID", userID, "error", err)
@@ -86,6 +156,89 @@ func (s *SubscriptionService) GetSubscriptions(userID string, filter repositorie
 	return subs, total, nil
 }
 
+// getSubscriptionsByComputedKey loads every matching subscription, since the
+// sort key is not stored, then sorts them by the values computed by key and
+// paginates them in memory.
+func (s *SubscriptionService) getSubscriptionsByComputedKey(userID string, filter repositories.SubscriptionFilter, key func(userID string, subs []*models.Subscription) map[string]int) ([]*models.Subscription, int64, error) {
+	filter.Defaults()
+
+	query := filter
+	query.SortBy = "created_at"
+	all, err := findAllSubscriptions(s.repo, userID, query)
+	if err != nil {
+		slog.Error("구독 목록 조회 실패", "userID", userID, "error", err)
+		return nil, 0, utils.ErrInternal("구독 목록을 조회할 수 없습니다")
+	}
+
+	scores := key(userID, all)
+	sort.SliceStable(all, func(i, j int) bool {
+		si, sj := scores[all[i].ID.String()], scores[all[j].ID.String()]
+		if si == sj {
+			return all[i].ServiceName < all[j].ServiceName
+		}
+		if filter.SortOrder == "asc" {
+			return si < sj
+		}
+		return si > sj
+	})
+
+	total := int64(len(all))
+	start := (filter.Page - 1) * filter.PerPage
+	if start > len(all) {
+		start = len(all)
+	}
+	end := start + filter.PerPage
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[start:end], total, nil
+}
+
+// ValueScores returns the value for money score, from 0 to 100, of each of
+// the user's subscriptions keyed by subscription ID. The score combines
+// satisfaction, personal monthly cost, tenure and the share paid by others.
+func (s *SubscriptionService) ValueScores(userID string, subs []*models.Subscription) map[string]int {
+	shareMap := make(map[string]*models.SubscriptionShare)
+	if s.shareRepo != nil && len(subs) > 0 {
+		shareMap = buildShareMap(s.shareRepo, userID)
+	}
+	today := s.clock.Today(userID)
+
+	scores := make(map[string]int, len(subs))
+	for _, sub := range subs {
+		scores[sub.ID.String()] = valueScore(sub, shareMap[sub.ID.String()], today)
+	}
+	return scores
+}
+
+// LifetimeSpend returns the personal amount each of the given subscriptions
+// has charged since its start date, keyed by subscription ID. Every billing
+// cycle is counted at the price it had, skipping free trials and the time the
+// subscription was paused or cancelled. Only the history of the given
+// subscriptions is loaded; without any, the current values are assumed to
+// have always held.
+func (s *SubscriptionService) LifetimeSpend(userID string, subs []*models.Subscription) map[string]int {
+	spend := make(map[string]int, len(subs))
+	if len(subs) == 0 {
+		return spend
+	}
+
+	shareMap := make(map[string]*models.SubscriptionShare)
+	if s.shareRepo != nil {
+		shareMap = buildShareMap(s.shareRepo, userID)
+	}
+	prices, statuses, err := loadChangesFor(s.priceRepo, s.statusRepo, subs)
+	if err != nil {
+		slog.Error("누적 지출 이력 조회 실패", "userID", userID, "error", err)
+	}
+
+	today := s.clock.Today(userID)
+	for _, h := range newSubscriptionHistories(subs, shareMap, prices, statuses, s.clock.Location(userID)) {
+		spend[h.sub.ID.String()] = h.lifetimeSpend(today)
+	}
+	return spend
+}
+
 // GetSubscription returns a single subscription after verifying ownership.
 func (s *SubscriptionService) GetSubscription(userID, subID string) (*models.Subscription, error) {
 	sub, err := s.repo.FindByID(subID)
@@ -120,8 +273,8 @@ func (s *SubscriptionService) CreateSubscription(userID string, req *CreateSubsc
 		return nil, utils.ErrValidation("다음 결제일 형식이 올바르지 않습니다 (YYYY-MM-DD)")
 	}
 
-	// Parse start date (defaults to today).
-	startDate := time.Now().Truncate(24 * time.Hour)
+	// Parse start date (defaults to the user's local today).
+	startDate := s.clock.Today(userID)
 	if req.StartDate != nil && *req.StartDate != "" {
 		parsed, parseErr := time.Parse("2006-01-02", *req.StartDate)
 		if parseErr != nil {
@@ -130,6 +283,30 @@ func (s *SubscriptionService) CreateSubscription(userID string, req *CreateSubsc
 		startDate = parsed
 	}
 
+	// Parse optional trial end date.
+	var trialEndDate *time.Time
+	if req.TrialEndDate != nil && *req.TrialEndDate != "" {
+		parsed, parseErr := time.Parse("2006-01-02", *req.TrialEndDate)
+		if parseErr != nil {
+			return nil, utils.ErrValidation("체험 종료일 형식이 올바르지 않습니다 (YYYY-MM-DD)")
+		}
+		trialEndDate = &parsed
+	}
+
+	// Parse optional promotion and scheduled pause dates.
+	promoEndDate, appErr := parseOptionalDate(req.PromoEndDate, "프로모션 종료일")
+	if appErr != nil {
+		return nil, appErr
+	}
+	pauseStartDate, appErr := parseOptionalDate(req.PauseStartDate, "일시정지 시작일")
+	if appErr != nil {
+		return nil, appErr
+	}
+	pauseEndDate, appErr := parseOptionalDate(req.PauseEndDate, "일시정지 종료일")
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	// Warn for large amounts.
 	if req.Amount > 1000000 {
 		slog.Warn("높은 구독 금액 입력", "userID", userID, "serviceName", req.ServiceName, "amount", req.Amount)
@@ -163,20 +340,43 @@ func (s *SubscriptionService) CreateSubscription(userID string, req *CreateSubsc
 		status = models.SubscriptionStatus(req.Status)
 	}
 
+	// Determine business-day adjustment (default none).
+	adjustment := models.BusinessDayAdjustmentNone
+	if req.BusinessDayAdjustment != "" {
+		adjustment = models.BusinessDayAdjustment(req.BusinessDayAdjustment)
+	}
+
 	sub := &models.Subscription{
-		UserID:            uid,
-		ServiceName:       req.ServiceName,
-		CategoryID:        categoryID,
-		Amount:            req.Amount,
-		BillingCycle:      models.BillingCycle(req.BillingCycle),
-		Currency:          "KRW",
-		NextBillingDate:   nextBillingDate,
-		AutoRenew:         autoRenew,
-		Status:            status,
-		SatisfactionScore: req.SatisfactionScore,
-		Note:              req.Note,
-		ServiceURL:        req.ServiceURL,
-		StartDate:         startDate,
+		UserID:                uid,
+		ServiceName:           req.ServiceName,
+		CategoryID:            categoryID,
+		Amount:                req.Amount,
+		BillingCycle:          models.BillingCycle(req.BillingCycle),
+		Currency:              "KRW",
+		NextBillingDate:       nextBillingDate,
+		AutoRenew:             autoRenew,
+		Status:                status,
+		SatisfactionScore:     req.SatisfactionScore,
+		Note:                  req.Note,
+		ServiceURL:            req.ServiceURL,
+		StartDate:             startDate,
+		TrialEndDate:          trialEndDate,
+		PromoAmount:           req.PromoAmount,
+		PromoEndDate:          promoEndDate,
+		PauseStartDate:        pauseStartDate,
+		PauseEndDate:          pauseEndDate,
+		BusinessDayAdjustment: adjustment,
+	}
+	if appErr := validateScheduledChanges(sub); appErr != nil {
+		return nil, appErr
+	}
+
+	// Use the given functional group, or the catalog's for well-known services.
+	if req.FunctionalGroup != "" {
+		group := models.FunctionalGroup(req.FunctionalGroup)
+		sub.FunctionalGroup = &group
+	} else {
+		s.fillFunctionalGroup(sub)
 	}
 
 	if err := s.repo.Create(sub); err != nil {
@@ -184,6 +384,9 @@ func (s *SubscriptionService) CreateSubscription(userID string, req *CreateSubsc
 		return nil, utils.ErrInternal("구독을 생성할 수 없습니다")
 	}
 
+	publishEvent(s.events, userID, models.WebhookEventSubscriptionCreated, newWebhookSubscriptionData(sub))
+	s.cache.InvalidateUser(userID)
+
 	// Re-fetch to preload associations.
 	created, err := s.repo.FindByID(sub.ID.String())
 	if err != nil {
@@ -206,6 +409,10 @@ func (s *SubscriptionService) UpdateSubscription(userID, subID string, req *Upda
 		return nil, err
 	}
 
+	// Remember the current price, status and satisfaction to detect changes.
+	oldAmount, oldCycle, oldStatus := sub.Amount, sub.BillingCycle, sub.Status
+	oldScore := sub.SatisfactionScore
+
 	// Apply partial updates.
 	if req.ServiceName != nil {
 		trimmed := strings.TrimSpace(*req.ServiceName)
@@ -266,11 +473,91 @@ func (s *SubscriptionService) UpdateSubscription(userID, subID string, req *Upda
 		sub.ServiceURL = req.ServiceURL
 	}
 
+	if req.TrialEndDate != nil {
+		if *req.TrialEndDate == "" {
+			sub.TrialEndDate = nil
+		} else {
+			parsed, parseErr := time.Parse("2006-01-02", *req.TrialEndDate)
+			if parseErr != nil {
+				return nil, utils.ErrValidation("체험 종료일 형식이 올바르지 않습니다 (YYYY-MM-DD)")
+			}
+			sub.TrialEndDate = &parsed
+		}
+	}
+
+	if req.PromoAmount != nil {
+		sub.PromoAmount = req.PromoAmount
+	}
+	if req.PromoEndDate != nil {
+		parsed, appErr := parseOptionalDate(req.PromoEndDate, "프로모션 종료일")
+		if appErr != nil {
+			return nil, appErr
+		}
+		sub.PromoEndDate = parsed
+		if parsed == nil {
+			sub.PromoAmount = nil
+		}
+	}
+	if req.PauseStartDate != nil {
+		parsed, appErr := parseOptionalDate(req.PauseStartDate, "일시정지 시작일")
+		if appErr != nil {
+			return nil, appErr
+		}
+		sub.PauseStartDate = parsed
+		if parsed == nil {
+			sub.PauseEndDate = nil
+		}
+	}
+	if req.PauseEndDate != nil {
+		parsed, appErr := parseOptionalDate(req.PauseEndDate, "일시정지 종료일")
+		if appErr != nil {
+			return nil, appErr
+		}
+		sub.PauseEndDate = parsed
+	}
+	if appErr := validateScheduledChanges(sub); appErr != nil {
+		return nil, appErr
+	}
+
+	if req.BusinessDayAdjustment != nil {
+		sub.BusinessDayAdjustment = models.BusinessDayAdjustment(*req.BusinessDayAdjustment)
+	}
+
+	switch {
+	case req.FunctionalGroup != nil && *req.FunctionalGroup == "":
+		sub.FunctionalGroup = nil
+	case req.FunctionalGroup != nil:
+		group := models.FunctionalGroup(*req.FunctionalGroup)
+		if !group.IsValid() {
+			return nil, utils.ErrValidation("유효하지 않은 기능 그룹입니다")
+		}
+		sub.FunctionalGroup = &group
+	case req.ServiceName != nil && sub.FunctionalGroup == nil:
+		s.fillFunctionalGroup(sub)
+	}
+
 	if err := s.repo.Update(sub); err != nil {
 		slog.Error("구독 수정 실패", "subID", subID, "error", err)
 		return nil, utils.ErrInternal("구독을 수정할 수 없습니다")
 	}
 
+	if sub.Amount != oldAmount || sub.BillingCycle != oldCycle {
+		s.recordPriceChange(userID, sub, oldAmount, oldCycle)
+	}
+	if sub.Status != oldStatus {
+		s.recordStatusChange(userID, sub, oldStatus)
+	}
+	if !equalScores(sub.SatisfactionScore, oldScore) {
+		s.recordSatisfactionChange(userID, sub, oldScore)
+	}
+
+	event := models.WebhookEventSubscriptionUpdated
+	if sub.Status == models.SubscriptionStatusCancelled && oldStatus != models.SubscriptionStatusCancelled {
+		event = models.WebhookEventSubscriptionCancelled
+	}
+	publishEvent(s.events, userID, event, newWebhookSubscriptionData(sub))
+	s.cache.InvalidateUser(userID)
+
 	// Re-fetch to preload associations.
 	updated, fetchErr := s.repo.FindByID(sub.ID.String())
 	if fetchErr != nil {
@@ -280,10 +567,117 @@ func (s *SubscriptionService) UpdateSubscription(userID, subID string, req *Upda
 	return updated, nil
 }
 
+// parseOptionalDate parses an optional YYYY-MM-DD field named label. A nil
+// or empty value yields nil.
+func parseOptionalDate(value *string, label string) (*time.Time, *utils.AppError) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, utils.ErrValidation(label + " 형식이 올바르지 않습니다 (YYYY-MM-DD)")
+	}
+	return &parsed, nil
+}
+
+// validateScheduledChanges checks that a promotion has both an amount and an
+// end date, and that a scheduled pause ends after it starts.
+func validateScheduledChanges(sub *models.Subscription) *utils.AppError {
+	if (sub.PromoAmount == nil) != (sub.PromoEndDate == nil) {
+		return utils.ErrValidation("프로모션 금액과 종료일을 함께 입력해주세요")
+	}
+	if sub.PauseEndDate != nil {
+		if sub.PauseStartDate == nil {
+			return utils.ErrValidation("일시정지 시작일을 입력해주세요")
+		}
+		if !sub.PauseEndDate.After(*sub.PauseStartDate) {
+			return utils.ErrValidation("일시정지 종료일은 시작일 이후여야 합니다")
+		}
+	}
+	return nil
+}
+
+// recordPriceChange stores a price history entry. Failures are logged but do
+// not fail the update, since the subscription itself was saved.
+func (s *SubscriptionService) recordPriceChange(userID string, sub *models.Subscription, oldAmount int, oldCycle models.BillingCycle) {
+	today := s.clock.Today(userID)
+	if s.priceRepo != nil {
+		change := &models.PriceChange{
+			SubscriptionID:  sub.ID,
+			UserID:          sub.UserID,
+			OldAmount:       oldAmount,
+			NewAmount:       sub.Amount,
+			OldBillingCycle: oldCycle,
+			NewBillingCycle: sub.BillingCycle,
+			EffectiveDate:   today,
+		}
+		if err := s.priceRepo.Create(change); err != nil {
+			slog.Error("구독 가격 변경 이력 저장 실패", "subID", sub.ID, "error", err)
+		}
+	}
+
+	s.inbox.Record(sub.UserID, InboxEntry{
+		Type:  models.NotificationTypePriceChanged,
+		Title: fmt.Sprintf("%s 가격 변경", sub.ServiceName),
+		Body: fmt.Sprintf("%s 가격이 %s원(%s)에서 %s원(%s)으로 변경되었습니다.",
+			sub.ServiceName,
+			formatWon(oldAmount), billingCycleLabel(oldCycle),
+			formatWon(sub.Amount), billingCycleLabel(sub.BillingCycle)),
+		SubscriptionID: &sub.ID,
+		DedupKey: fmt.Sprintf("price:%s:%s:%d%s:%d%s", sub.ID, today.Format("2006-01-02"),
+			oldAmount, oldCycle, sub.Amount, sub.BillingCycle),
+	})
+}
+
+// recordStatusChange stores a status history entry. Failures are logged but
+// do not fail the update, since the subscription itself was saved.
+func (s *SubscriptionService) recordStatusChange(userID string, sub *models.Subscription, oldStatus models.SubscriptionStatus) {
+	if s.statusRepo == nil {
+		return
+	}
+	change := &models.StatusChange{
+		SubscriptionID: sub.ID,
+		UserID:         sub.UserID,
+		OldStatus:      oldStatus,
+		NewStatus:      sub.Status,
+		EffectiveDate:  s.clock.Today(userID),
+	}
+	if err := s.statusRepo.Create(change); err != nil {
+		slog.Error("구독 상태 변경 이력 저장 실패", "subID", sub.ID, "error", err)
+	}
+}
+
+// recordSatisfactionChange stores a satisfaction history entry. Failures are
+// logged but do not fail the update, since the subscription itself was saved.
+func (s *SubscriptionService) recordSatisfactionChange(userID string, sub *models.Subscription, oldScore *int) {
+	if s.scoreRepo == nil {
+		return
+	}
+	change := &models.SatisfactionChange{
+		SubscriptionID: sub.ID,
+		UserID:         sub.UserID,
+		OldScore:       oldScore,
+		NewScore:       sub.SatisfactionScore,
+		EffectiveDate:  s.clock.Today(userID),
+	}
+	if err := s.scoreRepo.Create(change); err != nil {
+		slog.Error("구독 만족도 변경 이력 저장 실패", "subID", sub.ID, "error", err)
+	}
+}
+
+// equalScores reports whether two optional satisfaction scores are the same.
+func equalScores(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 // DeleteSubscription validates ownership and soft-deletes a subscription.
 func (s *SubscriptionService) DeleteSubscription(userID, subID string) error {
 	// Verify ownership.
-	if _, err := s.GetSubscription(userID, subID); err != nil {
+	sub, err := s.GetSubscription(userID, subID)
+	if err != nil {
 		return err
 	}
 
@@ -292,6 +686,9 @@ func (s *SubscriptionService) DeleteSubscription(userID, subID string) error {
 		return utils.ErrInternal("구독을 삭제할 수 없습니다")
 	}
 
+	publishEvent(s.events, userID, models.WebhookEventSubscriptionCancelled, newWebhookSubscriptionData(sub))
+	s.cache.InvalidateUser(userID)
+
 	return nil
 }
 
@@ -307,12 +704,18 @@ func (s *SubscriptionService) UpdateSatisfaction(userID, subID string, score int
 		return nil, err
 	}
 
+	oldScore := sub.SatisfactionScore
 	sub.SatisfactionScore = &score
 
 	if err := s.repo.Update(sub); err != nil {
 		slog.Error("만족도 점수 수정 실패", "subID", subID, "error", err)
 		return nil, utils.ErrInternal("만족도 점수를 수정할 수 없습니다")
 	}
+	if !equalScores(sub.SatisfactionScore, oldScore) {
+		s.recordSatisfactionChange(userID, sub, oldScore)
+	}
+	publishEvent(s.events, userID, models.WebhookEventSubscriptionUpdated, newWebhookSubscriptionData(sub))
+	s.cache.InvalidateUser(userID)
 
 	// Re-fetch to preload associations.
 	updated, fetchErr := s.repo.FindByID(sub.ID.String())
@@ -323,6 +726,15 @@ func (s *SubscriptionService) UpdateSatisfaction(userID, subID string, score int
 	return updated, nil
 }
 
+// fillFunctionalGroup sets the functional group from the catalog when the
+// service is a well-known one.
+func (s *SubscriptionService) fillFunctionalGroup(sub *models.Subscription) {
+	if entry, ok := s.catalog.Lookup(sub.ServiceName); ok && entry.Group != "" {
+		group := entry.Group
+		sub.FunctionalGroup = &group
+	}
+}
+
 // normalizeName normalizes a service name by converting to lowercase and
 // removing all whitespace.
 func normalizeName(name string) string {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -179,6 +180,31 @@ func (m *mockSubscriptionRepo) FindSimilarInCategory(userID string, categoryID s
 	return nil, nil
 }
 
+func (m *mockSubscriptionRepo) FindDeletedByUserIDSince(userID string, since time.Time) ([]*models.Subscription, error) {
+	var result []*models.Subscription
+	for key, sub := range m.subs {
+		if len(key) > 8 && key[:8] == "deleted:" && sub.UserID.String() == userID && !sub.DeletedAt.Time.Before(since) {
+			result = append(result, sub)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSubscriptionRepo) FindActiveUserIDs() ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+	for key, sub := range m.subs {
+		uid := sub.UserID.String()
+		if (len(key) > 8 && key[:8] == "deleted:") || sub.Status != models.SubscriptionStatusActive || seen[uid] {
+			continue
+		}
+		seen[uid] = true
+		result = append(result, uid)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
 // seedSubscription inserts a subscription into the mock repo and returns it.
 func (m *mockSubscriptionRepo) seedSubscription(userID uuid.UUID, name string, amount int, cycle models.BillingCycle) *models.Subscription {
 	sub := &models.Subscription{
@@ -395,16 +421,16 @@ func TestCreateSubscription(t *testing.T) {
 
 	t.Run("sets startDate to today when not provided", func(t *testing.T) {
 		repo := newMockRepo()
-		svc := NewSubscriptionService(repo)
+		// 2026-03-31 16:00 UTC is already April 1st in the default Asia/Seoul zone.
+		clock := NewUserClock(fixedClock(time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)), nil)
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{Clock: clock})
 
 		req := validReq()
 		req.StartDate = nil
 
 		sub, err := svc.CreateSubscription(userID.String(), req)
 		assertNil(t, err)
-
-		today := time.Now().Truncate(24 * time.Hour)
-		assertEqual(t, sub.StartDate.Format("2006-01-02"), today.Format("2006-01-02"))
+		assertEqual(t, sub.StartDate.Format("2006-01-02"), "2026-04-01")
 	})
 
 	t.Run("uses provided startDate", func(t *testing.T) {
@@ -442,6 +468,28 @@ func TestCreateSubscription(t *testing.T) {
 		assertNil(t, err)
 		assertEqual(t, sub.Status, models.SubscriptionStatusPaused)
 	})
+
+	t.Run("fills functional group from catalog", func(t *testing.T) {
+		repo := newMockRepo()
+		catalog, _ := LoadServiceCatalog("")
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{Catalog: catalog})
+
+		sub, err := svc.CreateSubscription(userID.String(), validReq())
+		assertNil(t, err)
+		assertNotNil(t, sub.FunctionalGroup)
+		assertEqual(t, *sub.FunctionalGroup, models.FunctionalGroupVideo)
+
+		req := validReq()
+		req.FunctionalGroup = "music"
+		sub, err = svc.CreateSubscription(userID.String(), req)
+		assertNil(t, err)
+		assertEqual(t, *sub.FunctionalGroup, models.FunctionalGroupMusic)
+
+		req = validReq()
+		req.FunctionalGroup = "podcasts"
+		_, err = svc.CreateSubscription(userID.String(), req)
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
 }
 
 func TestUpdateSubscription(t *testing.T) {
@@ -475,6 +523,114 @@ func TestUpdateSubscription(t *testing.T) {
 		assertEqual(t, updated.Amount, 20000)
 	})
 
+	t.Run("records price change", func(t *testing.T) {
+		repo := newMockRepo()
+		prices := &mockPriceChangeRepo{}
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{PriceRepo: prices})
+		sub := repo.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			ServiceName: strPtr("Netflix Premium"),
+		})
+		assertNil(t, err)
+		assertEqual(t, len(prices.changes), 0)
+
+		_, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			Amount: intPtr(20000),
+		})
+		assertNil(t, err)
+		assertEqual(t, len(prices.changes), 1)
+		assertEqual(t, prices.changes[0].OldAmount, 17000)
+		assertEqual(t, prices.changes[0].NewAmount, 20000)
+	})
+
+	t.Run("records status change", func(t *testing.T) {
+		repo := newMockRepo()
+		statuses := &mockStatusChangeRepo{}
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{StatusRepo: statuses})
+		sub := repo.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			Amount: intPtr(20000),
+		})
+		assertNil(t, err)
+		assertEqual(t, len(statuses.changes), 0)
+
+		_, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			Status: strPtr("paused"),
+		})
+		assertNil(t, err)
+		assertEqual(t, len(statuses.changes), 1)
+		assertEqual(t, statuses.changes[0].OldStatus, models.SubscriptionStatusActive)
+		assertEqual(t, statuses.changes[0].NewStatus, models.SubscriptionStatusPaused)
+	})
+
+	t.Run("records satisfaction change", func(t *testing.T) {
+		repo := newMockRepo()
+		scores := &mockSatisfactionChangeRepo{}
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{ScoreRepo: scores})
+		sub := repo.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			SatisfactionScore: intPtr(4),
+		})
+		assertNil(t, err)
+		_, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			SatisfactionScore: intPtr(4),
+		})
+		assertNil(t, err)
+		assertEqual(t, len(scores.changes), 1)
+		assertEqual(t, scores.changes[0].OldScore == nil, true)
+		assertEqual(t, *scores.changes[0].NewScore, 4)
+	})
+
+	t.Run("schedules promotion and pause", func(t *testing.T) {
+		_, svc, sub := setup()
+
+		updated, err := svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			PromoAmount:    intPtr(3000),
+			PromoEndDate:   strPtr("2026-04-01"),
+			PauseStartDate: strPtr("2026-05-01"),
+			PauseEndDate:   strPtr("2026-07-01"),
+		})
+		assertNil(t, err)
+		assertEqual(t, *updated.PromoAmount, 3000)
+		assertEqual(t, updated.PauseEndDate.Format("2006-01-02"), "2026-07-01")
+
+		updated, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			PromoEndDate: strPtr(""),
+		})
+		assertNil(t, err)
+		assertEqual(t, updated.PromoAmount == nil, true)
+
+		_, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			PauseEndDate: strPtr("2026-04-01"),
+		})
+		assertNotNil(t, err)
+		_, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			PromoAmount: intPtr(1000),
+		})
+		assertNotNil(t, err)
+	})
+
+	t.Run("writes price change to inbox", func(t *testing.T) {
+		repo := newMockRepo()
+		inboxRepo := newMockNotificationRepo()
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{Inbox: NewInboxService(inboxRepo, nil)})
+		sub := repo.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			Amount: intPtr(20000),
+		})
+		assertNil(t, err)
+
+		entries := inboxRepo.byType(userID, models.NotificationTypePriceChanged)
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 inbox entry, got %d", len(entries))
+		}
+		assertEqual(t, entries[0].Body, "Netflix 가격이 17,000원(월간)에서 20,000원(월간)으로 변경되었습니다.")
+	})
+
 	t.Run("partial update keeps other fields", func(t *testing.T) {
 		_, svc, sub := setup()
 
@@ -509,6 +665,24 @@ func TestUpdateSubscription(t *testing.T) {
 		assertAppErrorCode(t, err, http.StatusNotFound)
 	})
 
+	t.Run("sets and clears functional group", func(t *testing.T) {
+		_, svc, sub := setup()
+
+		updated, err := svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			FunctionalGroup: strPtr("video"),
+		})
+		assertNil(t, err)
+		assertEqual(t, *updated.FunctionalGroup, models.FunctionalGroupVideo)
+
+		updated, err = svc.UpdateSubscription(userID.String(), sub.ID.String(), &UpdateSubscriptionRequest{
+			FunctionalGroup: strPtr(""),
+		})
+		assertNil(t, err)
+		if updated.FunctionalGroup != nil {
+			t.Error("expected functional group to be cleared")
+		}
+	})
+
 	t.Run("rejects empty service name on update", func(t *testing.T) {
 		_, svc, sub := setup()
 
@@ -561,6 +735,45 @@ func TestDeleteSubscription(t *testing.T) {
 	})
 }
 
+func TestSubscriptionLifecycleEvents(t *testing.T) {
+	userID := uuid.New()
+	repo := newMockRepo()
+	events := &recordingPublisher{}
+	svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{Events: events})
+
+	created, err := svc.CreateSubscription(userID.String(), &CreateSubscriptionRequest{
+		ServiceName:     "Netflix",
+		Amount:          17000,
+		BillingCycle:    "monthly",
+		NextBillingDate: "2026-04-15",
+	})
+	assertNil(t, err)
+
+	_, err = svc.UpdateSubscription(userID.String(), created.ID.String(), &UpdateSubscriptionRequest{Amount: intPtr(20000)})
+	assertNil(t, err)
+	_, err = svc.UpdateSubscription(userID.String(), created.ID.String(), &UpdateSubscriptionRequest{Status: strPtr("cancelled")})
+	assertNil(t, err)
+	_, err = svc.UpdateSubscription(userID.String(), created.ID.String(), &UpdateSubscriptionRequest{Note: strPtr("해지 완료")})
+	assertNil(t, err)
+	assertNil(t, svc.DeleteSubscription(userID.String(), created.ID.String()))
+
+	assertEqual(t, events.events, []models.WebhookEventType{
+		models.WebhookEventSubscriptionCreated,
+		models.WebhookEventSubscriptionUpdated,
+		models.WebhookEventSubscriptionCancelled,
+		models.WebhookEventSubscriptionUpdated,
+		models.WebhookEventSubscriptionCancelled,
+	})
+	data := events.data[1].(WebhookSubscriptionData)
+	assertEqual(t, data.ID, created.ID.String())
+	assertEqual(t, data.Amount, 20000)
+
+	// Failed operations publish nothing.
+	_, err = svc.UpdateSubscription(uuid.New().String(), created.ID.String(), &UpdateSubscriptionRequest{Amount: intPtr(1)})
+	assertError(t, err)
+	assertEqual(t, len(events.events), 5)
+}
+
 func TestUpdateSatisfaction(t *testing.T) {
 	userID := uuid.New()
 	otherUserID := uuid.New()
@@ -586,6 +799,42 @@ func TestUpdateSatisfaction(t *testing.T) {
 		}
 	})
 
+	t.Run("records satisfaction change", func(t *testing.T) {
+		repo := newMockRepo()
+		scores := &mockSatisfactionChangeRepo{}
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{ScoreRepo: scores})
+		sub := repo.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.UpdateSatisfaction(userID.String(), sub.ID.String(), 4)
+		assertNil(t, err)
+		_, err = svc.UpdateSatisfaction(userID.String(), sub.ID.String(), 4)
+		assertNil(t, err)
+		_, err = svc.UpdateSatisfaction(userID.String(), sub.ID.String(), 2)
+		assertNil(t, err)
+
+		assertEqual(t, len(scores.changes), 2)
+		assertEqual(t, scores.changes[0].OldScore == nil, true)
+		assertEqual(t, *scores.changes[0].NewScore, 4)
+		assertEqual(t, *scores.changes[1].OldScore, 4)
+		assertEqual(t, *scores.changes[1].NewScore, 2)
+	})
+
+	t.Run("publishes subscription.updated", func(t *testing.T) {
+		repo := newMockRepo()
+		events := &recordingPublisher{}
+		svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{Events: events})
+		sub := repo.seedSubscription(userID, "Netflix", 17000, models.BillingCycleMonthly)
+
+		_, err := svc.UpdateSatisfaction(userID.String(), sub.ID.String(), 4)
+		assertNil(t, err)
+		_, err = svc.UpdateSatisfaction(userID.String(), sub.ID.String(), 0)
+		assertError(t, err)
+
+		assertEqual(t, events.events, []models.WebhookEventType{models.WebhookEventSubscriptionUpdated})
+		data := events.data[0].(WebhookSubscriptionData)
+		assertEqual(t, data.ID, sub.ID.String())
+	})
+
 	t.Run("rejects score 0", func(t *testing.T) {
 		_, svc, sub := setup()
 
@@ -803,3 +1052,98 @@ func TestCheckDuplicates(t *testing.T) {
 		}
 	})
 }
+
+func TestLifetimeSpend(t *testing.T) {
+	userID := uuid.New()
+	repo := newMockRepo()
+	prices := &mockPriceChangeRepo{}
+	statuses := &mockStatusChangeRepo{}
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{PriceRepo: prices, Clock: clock, StatusRepo: statuses})
+
+	music := repo.seedSubscription(userID, "Music", 10000, models.BillingCycleMonthly)
+	music.StartDate = ymd(2026, 1, 5)
+	music.NextBillingDate = ymd(2026, 7, 5)
+	_ = prices.Create(&models.PriceChange{
+		SubscriptionID: music.ID, UserID: userID,
+		OldAmount: 8000, NewAmount: 10000,
+		OldBillingCycle: models.BillingCycleMonthly, NewBillingCycle: models.BillingCycleMonthly,
+		EffectiveDate: ymd(2026, 4, 5), CreatedAt: ymd(2026, 4, 5),
+	})
+	_ = statuses.Create(&models.StatusChange{
+		SubscriptionID: music.ID, UserID: userID,
+		OldStatus: models.SubscriptionStatusActive, NewStatus: models.SubscriptionStatusPaused,
+		EffectiveDate: ymd(2026, 5, 1),
+	})
+	_ = statuses.Create(&models.StatusChange{
+		SubscriptionID: music.ID, UserID: userID,
+		OldStatus: models.SubscriptionStatusPaused, NewStatus: models.SubscriptionStatusActive,
+		EffectiveDate: ymd(2026, 6, 1),
+	})
+
+	cloud := repo.seedSubscription(userID, "Cloud", 120000, models.BillingCycleYearly)
+	cloud.StartDate = ymd(2025, 6, 20)
+	cloud.NextBillingDate = ymd(2026, 6, 20)
+
+	fresh := repo.seedSubscription(userID, "Fresh", 5000, models.BillingCycleMonthly)
+	fresh.StartDate = ymd(2026, 6, 20)
+	fresh.NextBillingDate = ymd(2026, 6, 20)
+
+	t.Run("counts cycles at their price and skips pauses", func(t *testing.T) {
+		spend := svc.LifetimeSpend(userID.String(), []*models.Subscription{music, cloud, fresh})
+		// Three months at 8000, April at 10000, May paused, June at 10000.
+		assertEqual(t, spend[music.ID.String()], 44000)
+		// The renewal on 2026-06-20 is still to come.
+		assertEqual(t, spend[cloud.ID.String()], 120000)
+		assertEqual(t, spend[fresh.ID.String()], 0)
+	})
+
+	t.Run("sorts by lifetime spend", func(t *testing.T) {
+		subs, total, err := svc.GetSubscriptions(userID.String(), repositories.SubscriptionFilter{SortBy: SubscriptionSortLifetimeSpend})
+		assertNil(t, err)
+		assertEqual(t, total, int64(3))
+		assertEqual(t, []string{subs[0].ServiceName, subs[1].ServiceName, subs[2].ServiceName}, []string{"Cloud", "Music", "Fresh"})
+
+		subs, _, err = svc.GetSubscriptions(userID.String(), repositories.SubscriptionFilter{
+			SortBy: SubscriptionSortLifetimeSpend, SortOrder: "asc", PerPage: 1,
+		})
+		assertNil(t, err)
+		assertEqual(t, subs[0].ServiceName, "Fresh")
+	})
+}
+
+func TestLifetimeSpend_PromotionAndScheduledPause(t *testing.T) {
+	userID := uuid.New()
+	repo := newMockRepo()
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{PriceRepo: &mockPriceChangeRepo{}, Clock: clock, StatusRepo: &mockStatusChangeRepo{}})
+
+	music := repo.seedSubscription(userID, "Music", 10000, models.BillingCycleMonthly)
+	music.StartDate = ymd(2026, 1, 5)
+	music.NextBillingDate = ymd(2026, 7, 5)
+	promoEnd := ymd(2026, 3, 1)
+	pauseStart, pauseEnd := ymd(2026, 4, 1), ymd(2026, 5, 1)
+	music.PromoAmount, music.PromoEndDate = intPtr(5000), &promoEnd
+	music.PauseStartDate, music.PauseEndDate = &pauseStart, &pauseEnd
+
+	spend := svc.LifetimeSpend(userID.String(), []*models.Subscription{music})
+	// January and February at the promotional 5000, April skipped by the pause.
+	assertEqual(t, spend[music.ID.String()], 40000)
+}
+
+func TestLifetimeSpend_SkipsFreeTrial(t *testing.T) {
+	userID := uuid.New()
+	repo := newMockRepo()
+	clock := NewUserClock(fixedClock(ymd(2026, 6, 15)), nil)
+	svc := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{PriceRepo: &mockPriceChangeRepo{}, Clock: clock, StatusRepo: &mockStatusChangeRepo{}})
+
+	music := repo.seedSubscription(userID, "Music", 10000, models.BillingCycleMonthly)
+	music.StartDate = ymd(2026, 1, 5)
+	music.NextBillingDate = ymd(2026, 7, 5)
+	trialEnd := ymd(2026, 3, 5)
+	music.TrialEndDate = &trialEnd
+
+	spend := svc.LifetimeSpend(userID.String(), []*models.Subscription{music})
+	// January and February fall within the trial; billing starts on 2026-03-05.
+	assertEqual(t, spend[music.ID.String()], 40000)
+}
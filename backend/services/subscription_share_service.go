@@ -33,18 +33,26 @@ type SubscriptionShareService struct {
 	shareRepo      repositories.SubscriptionShareRepository
 	subRepo        repositories.SubscriptionRepository
 	shareGroupRepo repositories.ShareGroupRepository
+	events         EventPublisher
+	cache          *AggregateCache
 }
 
 // NewSubscriptionShareService creates a new SubscriptionShareService.
+// events may be nil, in which case no share events are published.
+// cache may be nil, in which case no cached aggregates are invalidated.
 func NewSubscriptionShareService(
 	shareRepo repositories.SubscriptionShareRepository,
 	subRepo repositories.SubscriptionRepository,
 	shareGroupRepo repositories.ShareGroupRepository,
+	events EventPublisher,
+	cache *AggregateCache,
 ) *SubscriptionShareService {
 	return &SubscriptionShareService{
 		shareRepo:      shareRepo,
 		subRepo:        subRepo,
 		shareGroupRepo: shareGroupRepo,
+		events:         events,
+		cache:          cache,
 	}
 }
 
@@ -117,6 +125,9 @@ func (s *SubscriptionShareService) LinkSubscriptionToShareGroup(userID string, r
 		return nil, utils.ErrInternal("구독 공유를 생성할 수 없습니다")
 	}
 
+	publishEvent(s.events, userID, models.WebhookEventShareLinked, newWebhookShareData(share))
+	s.cache.InvalidateUser(userID)
+
 	// Re-fetch to preload associations.
 	created, err := s.shareRepo.FindByID(share.ID.String())
 	if err != nil {
@@ -190,6 +201,9 @@ func (s *SubscriptionShareService) UpdateSubscriptionShare(userID string, shareI
 		return nil, utils.ErrInternal("구독 공유를 수정할 수 없습니다")
 	}
 
+	publishEvent(s.events, userID, models.WebhookEventShareUpdated, newWebhookShareData(share))
+	s.cache.InvalidateUser(userID)
+
 	// Re-fetch to preload associations.
 	updated, fetchErr := s.shareRepo.FindByID(share.ID.String())
 	if fetchErr != nil {
@@ -226,6 +240,9 @@ func (s *SubscriptionShareService) UnlinkSubscriptionShare(userID string, shareI
 		return utils.ErrInternal("구독 공유를 삭제할 수 없습니다")
 	}
 
+	publishEvent(s.events, userID, models.WebhookEventShareUnlinked, newWebhookShareData(share))
+	s.cache.InvalidateUser(userID)
+
 	return nil
 }
 
@@ -93,6 +93,10 @@ func (m *mockSubscriptionShareRepo) DeleteBySubscriptionID(subscriptionID string
 	return nil
 }
 
+func (m *mockSubscriptionShareRepo) FindSharingUserIDs() ([]string, error) {
+	return nil, nil
+}
+
 // ---------------------------------------------------------------------------
 // Mock Subscription repository (for ownership checks)
 // ---------------------------------------------------------------------------
@@ -131,6 +135,13 @@ func (m *mockSubRepoForShare) FindDuplicateName(userID, serviceName string) (boo
 func (m *mockSubRepoForShare) FindSimilarInCategory(userID string, categoryID string, excludeSubID string) ([]*models.Subscription, error) {
 	return nil, nil
 }
+func (m *mockSubRepoForShare) FindDeletedByUserIDSince(userID string, since time.Time) ([]*models.Subscription, error) {
+	return nil, nil
+}
+
+func (m *mockSubRepoForShare) FindActiveUserIDs() ([]string, error) {
+	return nil, nil
+}
 
 func (m *mockSubRepoForShare) seedSubscription(userID uuid.UUID, name string) *models.Subscription {
 	sub := &models.Subscription{
@@ -239,7 +250,7 @@ func newTestShareService() (*SubscriptionShareService, *mockSubscriptionShareRep
 	shareRepo := newMockSubscriptionShareRepo()
 	subRepo := newMockSubRepoForShare()
 	groupRepo := newMockShareGroupRepoForShare()
-	svc := NewSubscriptionShareService(shareRepo, subRepo, groupRepo)
+	svc := NewSubscriptionShareService(shareRepo, subRepo, groupRepo, nil, nil)
 	return svc, shareRepo, subRepo, groupRepo
 }
 
@@ -487,6 +498,37 @@ func TestUpdateSubscriptionShare_Success(t *testing.T) {
 	assertEqual(t, *updated.MyShareAmount, 7000)
 }
 
+func TestSubscriptionShareEvents(t *testing.T) {
+	userID := uuid.New()
+	shareRepo := newMockSubscriptionShareRepo()
+	subRepo := newMockSubRepoForShare()
+	groupRepo := newMockShareGroupRepoForShare()
+	events := &recordingPublisher{}
+	svc := NewSubscriptionShareService(shareRepo, subRepo, groupRepo, events, nil)
+
+	sub := subRepo.seedSubscription(userID, "넷플릭스")
+	group := groupRepo.seedGroup(userID, "넷플릭스 공유", "친구1")
+
+	share, err := svc.LinkSubscriptionToShareGroup(userID.String(), &LinkShareRequest{
+		SubscriptionID: sub.ID.String(),
+		ShareGroupID:   group.ID.String(),
+		SplitType:      "equal",
+	})
+	assertNil(t, err)
+	_, err = svc.UpdateSubscriptionShare(userID.String(), share.ID.String(), &UpdateShareRequest{MyShareRatio: float64Ptr(0.3)})
+	assertNil(t, err)
+	assertNil(t, svc.UnlinkSubscriptionShare(userID.String(), share.ID.String()))
+
+	assertEqual(t, events.events, []models.WebhookEventType{
+		models.WebhookEventShareLinked,
+		models.WebhookEventShareUpdated,
+		models.WebhookEventShareUnlinked,
+	})
+	data := events.data[0].(WebhookShareData)
+	assertEqual(t, data.SubscriptionID, sub.ID.String())
+	assertEqual(t, data.TotalMembers, 2)
+}
+
 // ---------------------------------------------------------------------------
 // Tests – GetSubscriptionShare
 // ---------------------------------------------------------------------------
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/subkeep/backend/models"
+)
+
+// Value score factor weights. They sum to one.
+const (
+	valueWeightSatisfaction = 0.45
+	valueWeightCost         = 0.30
+	valueWeightTenure       = 0.15
+	valueWeightShare        = 0.10
+)
+
+// valueCostHalfAmount is the personal monthly cost, in won, at which the cost
+// factor drops to one half.
+const valueCostHalfAmount = 10000
+
+// valueTenureFullDays is the tenure at which the tenure factor reaches one.
+const valueTenureFullDays = 365
+
+// valueUnratedSatisfaction is the satisfaction factor of unrated subscriptions.
+const valueUnratedSatisfaction = 0.5
+
+// personalMonthlyAmount returns the user's own monthly cost of a subscription.
+// share may be nil for subscriptions that are not shared.
+func personalMonthlyAmount(sub *models.Subscription, share *models.SubscriptionShare) int {
+	monthly := sub.MonthlyAmount()
+	if share != nil {
+		return share.PersonalAmount(monthly)
+	}
+	return monthly
+}
+
+// valueScore rates a subscription's value for money from 0 to 100. It
+// combines four factors between zero and one:
+//   - satisfaction: (score-1)/4, or one half when unrated
+//   - cost:         1/(1+personal/valueCostHalfAmount), cheaper is better
+//   - tenure:       days kept over valueTenureFullDays, capped at one
+//   - share:        the fraction of the price paid by other members
+func valueScore(sub *models.Subscription, share *models.SubscriptionShare, today time.Time) int {
+	satisfaction := valueUnratedSatisfaction
+	if sub.SatisfactionScore != nil {
+		satisfaction = float64(*sub.SatisfactionScore-1) / 4
+	}
+
+	personal := personalMonthlyAmount(sub, share)
+	cost := 1 / (1 + float64(personal)/valueCostHalfAmount)
+
+	tenure := 0.0
+	if start := truncateDate(sub.StartDate); !start.IsZero() && !start.After(today) {
+		tenure = math.Min(today.Sub(start).Hours()/24/valueTenureFullDays, 1)
+	}
+
+	shared := 0.0
+	if monthly := sub.MonthlyAmount(); monthly > 0 && personal < monthly {
+		shared = 1 - float64(personal)/float64(monthly)
+	}
+
+	score := valueWeightSatisfaction*satisfaction +
+		valueWeightCost*cost +
+		valueWeightTenure*tenure +
+		valueWeightShare*shared
+	return int(math.Round(score * 100))
+}
+
+// Value quadrants of the cost-versus-satisfaction report.
+const (
+	ValueQuadrantKeep   = "keep"
+	ValueQuadrantReview = "review"
+	ValueQuadrantCancel = "cancel"
+)
+
+// valueQuadrantSatisfiedScore is the lowest satisfaction score counted as
+// satisfied in the quadrant report.
+const valueQuadrantSatisfiedScore = 4
+
+// valueQuadrant places a subscription by cost and satisfaction: satisfying
+// and cheap is kept, unsatisfying and expensive is cancelled, and the rest,
+// including unrated subscriptions, are reviewed.
+func valueQuadrant(sub *models.Subscription, expensive bool) string {
+	if sub.SatisfactionScore == nil {
+		return ValueQuadrantReview
+	}
+	satisfied := *sub.SatisfactionScore >= valueQuadrantSatisfiedScore
+	switch {
+	case satisfied && !expensive:
+		return ValueQuadrantKeep
+	case !satisfied && expensive:
+		return ValueQuadrantCancel
+	default:
+		return ValueQuadrantReview
+	}
+}
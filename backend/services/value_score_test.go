@@ -0,0 +1,154 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+func TestValueScore(t *testing.T) {
+	today := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	newSub := func(amount int, satisfaction *int, start time.Time) *models.Subscription {
+		return &models.Subscription{
+			ID:                uuid.New(),
+			ServiceName:       "Netflix",
+			Amount:            amount,
+			BillingCycle:      models.BillingCycleMonthly,
+			SatisfactionScore: satisfaction,
+			StartDate:         start,
+		}
+	}
+
+	t.Run("combines satisfaction, cost and tenure", func(t *testing.T) {
+		sub := newSub(10000, intPtr(5), today.AddDate(-2, 0, 0))
+
+		// 0.45×1 + 0.30×0.5 + 0.15×1 = 0.75
+		assertEqual(t, valueScore(sub, nil, today), 75)
+	})
+
+	t.Run("credits the share paid by other members", func(t *testing.T) {
+		sub := newSub(10000, intPtr(5), today.AddDate(-2, 0, 0))
+		share := &models.SubscriptionShare{SplitType: models.SplitTypeEqual, TotalMembersSnapshot: 2}
+
+		// 0.45×1 + 0.30×(1/1.5) + 0.15×1 + 0.10×0.5 = 0.85
+		assertEqual(t, valueScore(sub, share, today), 85)
+	})
+
+	t.Run("treats unrated subscriptions as neutral", func(t *testing.T) {
+		sub := newSub(0, nil, today)
+
+		// 0.45×0.5 + 0.30×1 = 0.525
+		assertEqual(t, valueScore(sub, nil, today), 53)
+	})
+
+	t.Run("scales tenure up to a year", func(t *testing.T) {
+		half := newSub(10000, intPtr(1), today.AddDate(0, 0, -73))
+		future := newSub(10000, intPtr(1), today.AddDate(0, 1, 0))
+
+		// 0.30×0.5 + 0.15×0.2 = 0.18
+		assertEqual(t, valueScore(half, nil, today), 18)
+		assertEqual(t, valueScore(future, nil, today), 15)
+	})
+}
+
+func TestGetSubscriptions_SortByValueScore(t *testing.T) {
+	userID := uuid.New()
+	repo := newMockRepo()
+	svc := NewSubscriptionService(repo)
+
+	repo.seedSubscriptionWithDetails(userID, "Cheap Loved", 3000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+	repo.seedSubscriptionWithDetails(userID, "Pricey Disliked", 50000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(1), nil)
+	repo.seedSubscriptionWithDetails(userID, "Middle", 15000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(3), nil)
+
+	names := func(subs []*models.Subscription) []string {
+		result := make([]string, len(subs))
+		for i, sub := range subs {
+			result[i] = sub.ServiceName
+		}
+		return result
+	}
+
+	t.Run("sorts by value score descending by default", func(t *testing.T) {
+		subs, total, err := svc.GetSubscriptions(userID.String(), repositories.SubscriptionFilter{SortBy: SubscriptionSortValueScore})
+		assertNil(t, err)
+		assertEqual(t, total, int64(3))
+		assertEqual(t, names(subs), []string{"Cheap Loved", "Middle", "Pricey Disliked"})
+	})
+
+	t.Run("sorts ascending and paginates", func(t *testing.T) {
+		subs, total, err := svc.GetSubscriptions(userID.String(), repositories.SubscriptionFilter{
+			SortBy:    SubscriptionSortValueScore,
+			SortOrder: "asc",
+			Page:      2,
+			PerPage:   2,
+		})
+		assertNil(t, err)
+		assertEqual(t, total, int64(3))
+		assertEqual(t, names(subs), []string{"Cheap Loved"})
+	})
+
+	t.Run("scores use the personal share", func(t *testing.T) {
+		shares := newMockShareRepo()
+		scored := NewSubscriptionServiceWithDeps(repo, SubscriptionDeps{ShareRepo: shares})
+		subs, _, err := scored.GetSubscriptions(userID.String(), repositories.SubscriptionFilter{SortBy: SubscriptionSortValueScore})
+		assertNil(t, err)
+		before := scored.ValueScores(userID.String(), subs)
+
+		pricey := subs[2]
+		shares.shares["s1"] = &models.SubscriptionShare{
+			SubscriptionID:       pricey.ID,
+			SplitType:            models.SplitTypeEqual,
+			TotalMembersSnapshot: 4,
+		}
+		after := scored.ValueScores(userID.String(), subs)
+
+		if after[pricey.ID.String()] <= before[pricey.ID.String()] {
+			t.Errorf("expected shared score above %d, got %d", before[pricey.ID.String()], after[pricey.ID.String()])
+		}
+	})
+}
+
+func TestGetValueQuadrant(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	svc := NewReportService(repo, newMockShareRepoForReport())
+	userID := uuid.New()
+
+	seedReportSub(repo, userID, "Cheap Loved", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+	seedReportSub(repo, userID, "Pricey Disliked", 30000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(2), nil)
+	seedReportSub(repo, userID, "Pricey Loved", 30000, models.BillingCycleMonthly, models.SubscriptionStatusActive, intPtr(5), nil)
+	seedReportSub(repo, userID, "Unrated", 5000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	seedReportSub(repo, userID, "Paused", 90000, models.BillingCycleMonthly, models.SubscriptionStatusPaused, intPtr(1), nil)
+
+	report, err := svc.GetValueQuadrant(userID.String())
+	assertNil(t, err)
+
+	assertEqual(t, report.CostThreshold, 17500)
+	assertEqual(t, report.SatisfactionThreshold, 4)
+
+	quadrantNames := func(items []ValueQuadrantItem) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.ServiceName
+		}
+		return result
+	}
+	assertEqual(t, quadrantNames(report.Keep), []string{"Cheap Loved"})
+	assertEqual(t, quadrantNames(report.Review), []string{"Pricey Loved", "Unrated"})
+	assertEqual(t, quadrantNames(report.Cancel), []string{"Pricey Disliked"})
+
+	cancel := report.Cancel[0]
+	assertEqual(t, cancel.Expensive, true)
+	assertEqual(t, cancel.Quadrant, ValueQuadrantCancel)
+	assertEqual(t, cancel.MonthlyAmount, 30000)
+}
+
+func TestMedianAmount(t *testing.T) {
+	assertEqual(t, medianAmount(nil), 0)
+	assertEqual(t, medianAmount([]int{9000}), 9000)
+	assertEqual(t, medianAmount([]int{30000, 5000, 10000}), 10000)
+	assertEqual(t, medianAmount([]int{5000, 10001}), 7501)
+}
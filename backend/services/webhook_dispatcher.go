@@ -0,0 +1,542 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// Headers sent with every webhook delivery. The signature covers
+// "<timestamp>.<body>" so receivers can reject replayed requests.
+const (
+	WebhookHeaderEvent     = "X-SubKeep-Event"
+	WebhookHeaderDelivery  = "X-SubKeep-Delivery"
+	WebhookHeaderTimestamp = "X-SubKeep-Timestamp"
+	WebhookHeaderSignature = "X-SubKeep-Signature"
+)
+
+const (
+	// webhookBatchSize bounds the number of deliveries attempted per poll.
+	webhookBatchSize = 50
+	// webhookResponseDrainLimit bounds the response body read, and discarded,
+	// so the connection can be reused.
+	webhookResponseDrainLimit = 64 << 10
+)
+
+// EventPublisher receives subscription lifecycle events from services.
+type EventPublisher interface {
+	Publish(userID string, eventType models.WebhookEventType, data interface{})
+}
+
+// publishEvent forwards an event to p, ignoring a nil publisher.
+func publishEvent(p EventPublisher, userID string, eventType models.WebhookEventType, data interface{}) {
+	if p == nil {
+		return
+	}
+	p.Publish(userID, eventType, data)
+}
+
+// WebhookEvent is the JSON envelope delivered to webhook endpoints.
+// Redeliveries carry the same ID so receivers can deduplicate.
+type WebhookEvent struct {
+	ID        string                  `json:"id"`
+	Type      models.WebhookEventType `json:"type"`
+	CreatedAt time.Time               `json:"createdAt"`
+	UserID    string                  `json:"userId"`
+	Data      interface{}             `json:"data"`
+}
+
+// WebhookSubscriptionData is the subscription snapshot sent with subscription events.
+type WebhookSubscriptionData struct {
+	ID              string  `json:"id"`
+	ServiceName     string  `json:"serviceName"`
+	CategoryID      *string `json:"categoryId"`
+	Amount          int     `json:"amount"`
+	BillingCycle    string  `json:"billingCycle"`
+	Currency        string  `json:"currency"`
+	NextBillingDate string  `json:"nextBillingDate"`
+	AutoRenew       bool    `json:"autoRenew"`
+	Status          string  `json:"status"`
+}
+
+// WebhookBillingData is the payload of a subscription.billed event.
+type WebhookBillingData struct {
+	Subscription   WebhookSubscriptionData `json:"subscription"`
+	BillingDate    string                  `json:"billingDate"`
+	ChargeDate     string                  `json:"chargeDate"`
+	Amount         int                     `json:"amount"`
+	PersonalAmount int                     `json:"personalAmount"`
+}
+
+// WebhookShareData is the payload of share events.
+type WebhookShareData struct {
+	ID             string   `json:"id"`
+	SubscriptionID string   `json:"subscriptionId"`
+	ShareGroupID   string   `json:"shareGroupId"`
+	SplitType      string   `json:"splitType"`
+	MyShareAmount  *int     `json:"myShareAmount"`
+	MyShareRatio   *float64 `json:"myShareRatio"`
+	TotalMembers   int      `json:"totalMembers"`
+}
+
+// newWebhookSubscriptionData converts a subscription into its webhook payload.
+func newWebhookSubscriptionData(sub *models.Subscription) WebhookSubscriptionData {
+	data := WebhookSubscriptionData{
+		ID:              sub.ID.String(),
+		ServiceName:     sub.ServiceName,
+		Amount:          sub.Amount,
+		BillingCycle:    string(sub.BillingCycle),
+		Currency:        sub.Currency,
+		NextBillingDate: sub.NextBillingDate.Format("2006-01-02"),
+		AutoRenew:       sub.AutoRenew,
+		Status:          string(sub.Status),
+	}
+	if sub.CategoryID != nil {
+		cid := sub.CategoryID.String()
+		data.CategoryID = &cid
+	}
+	return data
+}
+
+// newWebhookShareData converts a subscription share into its webhook payload.
+func newWebhookShareData(share *models.SubscriptionShare) WebhookShareData {
+	return WebhookShareData{
+		ID:             share.ID.String(),
+		SubscriptionID: share.SubscriptionID.String(),
+		ShareGroupID:   share.ShareGroupID.String(),
+		SplitType:      string(share.SplitType),
+		MyShareAmount:  share.MyShareAmount,
+		MyShareRatio:   share.MyShareRatio,
+		TotalMembers:   share.TotalMembersSnapshot,
+	}
+}
+
+// SignWebhookPayload returns the X-SubKeep-Signature value for a payload:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature is valid for the payload.
+func VerifyWebhookSignature(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := SignWebhookPayload(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// WebhookDispatcher queues lifecycle events for each matching endpoint and
+// delivers them as signed JSON POST requests.
+//
+// Every delivery is persisted before it is attempted, so pending deliveries
+// survive restarts. A failed attempt is rescheduled after
+// RetryBackoff × 2^(attempt-1), capped at MaxBackoff, until MaxAttempts is
+// reached. Billing events are produced by a periodic scan and keyed by
+// subscription and charge date, so each charge is announced once.
+type WebhookDispatcher struct {
+	endpointRepo repositories.WebhookEndpointRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	subRepo      repositories.SubscriptionRepository
+	shareRepo    repositories.SubscriptionShareRepository
+	holidays     *HolidayCalendar
+	clock        *UserClock
+	client       *http.Client
+	cfg          config.WebhookConfig
+	wake         chan struct{}
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher.
+// A nil client uses a client with cfg.Timeout that refuses to connect to
+// loopback, private and link-local addresses.
+func NewWebhookDispatcher(
+	endpointRepo repositories.WebhookEndpointRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	subRepo repositories.SubscriptionRepository,
+	shareRepo repositories.SubscriptionShareRepository,
+	holidays *HolidayCalendar,
+	clock *UserClock,
+	client *http.Client,
+	cfg config.WebhookConfig,
+) *WebhookDispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = webhookTimeout
+	}
+	if client == nil {
+		client = newGuardedHTTPClient(cfg.Timeout)
+	}
+	return &WebhookDispatcher{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		subRepo:      subRepo,
+		shareRepo:    shareRepo,
+		holidays:     holidays,
+		clock:        clock,
+		client:       client,
+		cfg:          cfg,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Start delivers due webhooks every cfg.PollInterval, or sooner when new
+// events are published, and scans for billing events every
+// cfg.BillingScanInterval until ctx is cancelled.
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	pollInterval := d.cfg.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 15 * time.Second
+	}
+	scanInterval := d.cfg.BillingScanInterval
+	if scanInterval <= 0 {
+		scanInterval = time.Hour
+	}
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	scan := time.NewTicker(scanInterval)
+	defer scan.Stop()
+
+	d.scanBilling(ctx)
+	for {
+		if _, err := d.ProcessDue(ctx); err != nil {
+			slog.Error("웹훅 발송 처리 실패", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-scan.C:
+			d.scanBilling(ctx)
+		case <-poll.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// scanBilling runs ScanBillingEvents and logs failures.
+func (d *WebhookDispatcher) scanBilling(ctx context.Context) {
+	if _, err := d.ScanBillingEvents(ctx); err != nil {
+		slog.Error("웹훅 결제 이벤트 스캔 실패", "error", err)
+	}
+}
+
+// Publish queues an event for every enabled endpoint of the user that
+// subscribes to it. Failures are logged and never reach the caller, so a
+// broken webhook cannot fail the originating request. Nothing is queued
+// while webhooks are disabled.
+func (d *WebhookDispatcher) Publish(userID string, eventType models.WebhookEventType, data interface{}) {
+	if !d.cfg.Enabled {
+		return
+	}
+	endpoints, err := d.endpointRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("웹훅 엔드포인트 조회 실패", "userID", userID, "error", err)
+		return
+	}
+	event := &WebhookEvent{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: d.clock.now(),
+		UserID:    userID,
+		Data:      data,
+	}
+	if d.enqueue(endpoints, event, false) > 0 {
+		d.notify()
+	}
+}
+
+// enqueue creates a pending delivery of event for each matching endpoint and
+// returns the number created. With dedup set, endpoints that already have a
+// delivery for the event ID are skipped.
+func (d *WebhookDispatcher) enqueue(endpoints []*models.WebhookEndpoint, event *WebhookEvent, dedup bool) int {
+	var payload []byte
+	queued := 0
+	for _, endpoint := range endpoints {
+		if !endpoint.Enabled || !endpoint.Subscribes(event.Type) {
+			continue
+		}
+		if dedup {
+			exists, err := d.deliveryRepo.ExistsForEvent(endpoint.ID.String(), event.ID)
+			if err != nil {
+				slog.Error("웹훅 발송 중복 확인 실패", "endpointID", endpoint.ID, "eventID", event.ID, "error", err)
+				continue
+			}
+			if exists {
+				continue
+			}
+		}
+		if payload == nil {
+			var err error
+			if payload, err = json.Marshal(event); err != nil {
+				slog.Error("웹훅 페이로드 생성 실패", "eventType", event.Type, "error", err)
+				return queued
+			}
+		}
+
+		now := d.clock.now()
+		delivery := &models.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			UserID:        endpoint.UserID,
+			EventID:       uuid.MustParse(event.ID),
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+		if err := d.deliveryRepo.Create(delivery); err != nil {
+			slog.Error("웹훅 발송 기록 생성 실패", "endpointID", endpoint.ID, "eventType", event.Type, "error", err)
+			continue
+		}
+		queued++
+	}
+	return queued
+}
+
+// notify wakes the worker without blocking.
+func (d *WebhookDispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ScanBillingEvents queues a subscription.billed event for every charge that
+// posts today in the subscriber's time zone and returns the number of
+// deliveries queued.
+func (d *WebhookDispatcher) ScanBillingEvents(ctx context.Context) (int, error) {
+	endpoints, err := d.endpointRepo.FindEnabled()
+	if err != nil {
+		return 0, fmt.Errorf("load webhook endpoints: %w", err)
+	}
+
+	// Group endpoints by user, preserving repository order.
+	userOrder := make([]string, 0)
+	byUser := make(map[string][]*models.WebhookEndpoint)
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(models.WebhookEventSubscriptionBilled) {
+			continue
+		}
+		uid := endpoint.UserID.String()
+		if _, ok := byUser[uid]; !ok {
+			userOrder = append(userOrder, uid)
+		}
+		byUser[uid] = append(byUser[uid], endpoint)
+	}
+
+	queued := 0
+	for _, userID := range userOrder {
+		if ctx.Err() != nil {
+			return queued, ctx.Err()
+		}
+		queued += d.scanUserBilling(userID, byUser[userID])
+	}
+	if queued > 0 {
+		d.notify()
+	}
+	return queued, nil
+}
+
+// scanUserBilling queues billed events for a single user's charges today.
+func (d *WebhookDispatcher) scanUserBilling(userID string, endpoints []*models.WebhookEndpoint) int {
+	subs, _, err := d.subRepo.FindByUserID(userID, repositories.SubscriptionFilter{
+		Status:  "active",
+		Page:    1,
+		PerPage: 100,
+	})
+	if err != nil {
+		slog.Error("웹훅 결제 대상 구독 조회 실패", "userID", userID, "error", err)
+		return 0
+	}
+
+	today := d.clock.Today(userID)
+	shareMap := map[string]*models.SubscriptionShare{}
+	if d.shareRepo != nil {
+		shareMap = buildShareMap(d.shareRepo, userID)
+	}
+
+	// Widen the window so charges moved onto today by business-day
+	// adjustment are included.
+	events := expandBillingEvents(subs, shareMap,
+		today.AddDate(0, 0, -maxBusinessDayShift), today.AddDate(0, 0, maxBusinessDayShift), today, d.holidays)
+
+	queued := 0
+	for _, ev := range events {
+		if !ev.AdjustedDate.Equal(today) {
+			continue
+		}
+		billingDate := ev.Date.Format("2006-01-02")
+		eventID := uuid.NewSHA1(uuid.NameSpaceURL, []byte("subkeep:billed:"+ev.Subscription.ID.String()+":"+billingDate))
+		event := &WebhookEvent{
+			ID:        eventID.String(),
+			Type:      models.WebhookEventSubscriptionBilled,
+			CreatedAt: d.clock.now(),
+			UserID:    userID,
+			Data: WebhookBillingData{
+				Subscription:   newWebhookSubscriptionData(ev.Subscription),
+				BillingDate:    billingDate,
+				ChargeDate:     ev.AdjustedDate.Format("2006-01-02"),
+				Amount:         ev.Amount,
+				PersonalAmount: ev.PersonalAmount,
+			},
+		}
+		queued += d.enqueue(endpoints, event, true)
+	}
+	return queued
+}
+
+// ProcessDue attempts every pending delivery whose next attempt is due and
+// returns the number delivered successfully.
+func (d *WebhookDispatcher) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := d.deliveryRepo.FindDue(d.clock.now(), webhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("load due webhook deliveries: %w", err)
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+		if d.attempt(ctx, delivery) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// Redeliver queues a copy of a delivery with the same event and payload and
+// attempts it immediately. Further retries follow the normal backoff policy.
+func (d *WebhookDispatcher) Redeliver(ctx context.Context, original *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	now := d.clock.now()
+	originalID := original.ID
+	delivery := &models.WebhookDelivery{
+		EndpointID:    original.EndpointID,
+		UserID:        original.UserID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &originalID,
+	}
+	if err := d.deliveryRepo.Create(delivery); err != nil {
+		return nil, fmt.Errorf("create webhook redelivery: %w", err)
+	}
+	d.attempt(ctx, delivery)
+	return delivery, nil
+}
+
+// attempt sends a delivery once, records the outcome and schedules a retry
+// on failure. It returns true when the endpoint accepted the delivery.
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) bool {
+	endpoint, err := d.endpointRepo.FindByID(delivery.EndpointID.String())
+	if err != nil || !endpoint.Enabled {
+		msg := "webhook endpoint is missing or disabled"
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = &msg
+		delivery.NextAttemptAt = nil
+		d.saveDelivery(delivery)
+		return false
+	}
+
+	delivery.Attempts++
+	status, sendErr := d.send(ctx, endpoint, delivery)
+	delivery.ResponseStatus = status
+
+	now := d.clock.now()
+	if sendErr == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = nil
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		d.saveDelivery(delivery)
+		return true
+	}
+
+	msg := sendErr.Error()
+	delivery.LastError = &msg
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		slog.Warn("웹훅 발송 최종 실패", "deliveryID", delivery.ID, "attempts", delivery.Attempts, "error", sendErr)
+	} else {
+		next := now.Add(d.backoff(delivery.Attempts))
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.NextAttemptAt = &next
+		slog.Warn("웹훅 발송 실패, 재시도 예약", "deliveryID", delivery.ID, "attempts", delivery.Attempts, "nextAttemptAt", next, "error", sendErr)
+	}
+	d.saveDelivery(delivery)
+	return false
+}
+
+// saveDelivery persists a delivery and logs failures.
+func (d *WebhookDispatcher) saveDelivery(delivery *models.WebhookDelivery) {
+	if err := d.deliveryRepo.Update(delivery); err != nil {
+		slog.Error("웹훅 발송 기록 갱신 실패", "deliveryID", delivery.ID, "error", err)
+	}
+}
+
+// backoff returns the wait before the next attempt after the given number of
+// failed attempts: RetryBackoff × 2^(attempts-1), capped at MaxBackoff.
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	wait := d.cfg.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if d.cfg.MaxBackoff > 0 && wait >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	if d.cfg.MaxBackoff > 0 && wait > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+	return wait
+}
+
+// send posts the signed payload to the endpoint and returns the response
+// status. Any non-2xx response is an error. The response body is discarded so
+// endpoints cannot be used to read internal services through the delivery log.
+func (d *WebhookDispatcher) send(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery) (*int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := d.clock.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SubKeep-Webhook/1.0")
+	req.Header.Set(WebhookHeaderEvent, string(delivery.EventType))
+	req.Header.Set(WebhookHeaderDelivery, delivery.ID.String())
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(endpoint.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseDrainLimit))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Errorf("webhook endpoint responded with status %d", status)
+	}
+	return &status, nil
+}
@@ -0,0 +1,470 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+// ---------------------------------------------------------------------------
+// Mocks
+// ---------------------------------------------------------------------------
+
+type mockWebhookEndpointRepo struct {
+	endpoints map[string]*models.WebhookEndpoint
+}
+
+func newMockWebhookEndpointRepo() *mockWebhookEndpointRepo {
+	return &mockWebhookEndpointRepo{endpoints: make(map[string]*models.WebhookEndpoint)}
+}
+
+func (m *mockWebhookEndpointRepo) FindByID(id string) (*models.WebhookEndpoint, error) {
+	endpoint, ok := m.endpoints[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+func (m *mockWebhookEndpointRepo) FindByUserID(userID string) ([]*models.WebhookEndpoint, error) {
+	var result []*models.WebhookEndpoint
+	for _, endpoint := range m.endpoints {
+		if endpoint.UserID.String() == userID {
+			result = append(result, endpoint)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWebhookEndpointRepo) FindEnabled() ([]*models.WebhookEndpoint, error) {
+	var result []*models.WebhookEndpoint
+	for _, endpoint := range m.endpoints {
+		if endpoint.Enabled {
+			result = append(result, endpoint)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWebhookEndpointRepo) Create(endpoint *models.WebhookEndpoint) error {
+	if endpoint.ID == uuid.Nil {
+		endpoint.ID = uuid.New()
+	}
+	m.endpoints[endpoint.ID.String()] = endpoint
+	return nil
+}
+
+func (m *mockWebhookEndpointRepo) Update(endpoint *models.WebhookEndpoint) error {
+	m.endpoints[endpoint.ID.String()] = endpoint
+	return nil
+}
+
+func (m *mockWebhookEndpointRepo) Delete(id string) error {
+	delete(m.endpoints, id)
+	return nil
+}
+
+type mockWebhookDeliveryRepo struct {
+	deliveries []*models.WebhookDelivery
+}
+
+func (m *mockWebhookDeliveryRepo) FindByID(id string) (*models.WebhookDelivery, error) {
+	for _, d := range m.deliveries {
+		if d.ID.String() == id {
+			return d, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockWebhookDeliveryRepo) FindByUserID(userID string, filter repositories.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int64, error) {
+	var result []*models.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.UserID.String() != userID {
+			continue
+		}
+		if filter.EndpointID != "" && d.EndpointID.String() != filter.EndpointID {
+			continue
+		}
+		if filter.Status != "" && string(d.Status) != filter.Status {
+			continue
+		}
+		result = append(result, d)
+	}
+	return result, int64(len(result)), nil
+}
+
+func (m *mockWebhookDeliveryRepo) FindDue(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var result []*models.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.Status == models.WebhookDeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			result = append(result, d)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].NextAttemptAt.Before(*result[j].NextAttemptAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockWebhookDeliveryRepo) ExistsForEvent(endpointID, eventID string) (bool, error) {
+	for _, d := range m.deliveries {
+		if d.EndpointID.String() == endpointID && d.EventID.String() == eventID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockWebhookDeliveryRepo) Create(delivery *models.WebhookDelivery) error {
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *mockWebhookDeliveryRepo) Update(delivery *models.WebhookDelivery) error {
+	return nil
+}
+
+// stepClock is a Clock that tests can move forward.
+type stepClock struct {
+	t time.Time
+}
+
+func (c *stepClock) Now() time.Time {
+	return c.t
+}
+
+func (c *stepClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+// webhookReceiver is an httptest server that records requests and answers
+// with the queued status codes, then 200.
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	r := &webhookReceiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedWebhook{header: req.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) received() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]receivedWebhook, len(r.requests))
+	copy(out, r.requests)
+	return out
+}
+
+type webhookFixture struct {
+	dispatcher *WebhookDispatcher
+	endpoints  *mockWebhookEndpointRepo
+	deliveries *mockWebhookDeliveryRepo
+	subs       *mockSubscriptionRepo
+	clock      *stepClock
+	userID     uuid.UUID
+}
+
+// newWebhookFixture builds a dispatcher at 2026-03-10 10:00 KST.
+func newWebhookFixture() *webhookFixture {
+	f := &webhookFixture{
+		endpoints:  newMockWebhookEndpointRepo(),
+		deliveries: &mockWebhookDeliveryRepo{},
+		subs:       newMockRepo(),
+		clock:      &stepClock{t: time.Date(2026, 3, 10, 1, 0, 0, 0, time.UTC)},
+		userID:     uuid.New(),
+	}
+	// A plain client, since the default one refuses the loopback test receivers.
+	f.dispatcher = NewWebhookDispatcher(f.endpoints, f.deliveries, f.subs, nil, nil,
+		NewUserClock(f.clock, nil), &http.Client{}, config.WebhookConfig{
+			Enabled:      true,
+			MaxAttempts:  3,
+			RetryBackoff: 30 * time.Second,
+			MaxBackoff:   time.Hour,
+		})
+	return f
+}
+
+func (f *webhookFixture) addEndpoint(url string, events ...models.WebhookEventType) *models.WebhookEndpoint {
+	endpoint := &models.WebhookEndpoint{
+		ID:      uuid.New(),
+		UserID:  f.userID,
+		URL:     url,
+		Secret:  "whsec_test",
+		Enabled: true,
+	}
+	endpoint.SetEventTypes(events)
+	f.endpoints.endpoints[endpoint.ID.String()] = endpoint
+	return endpoint
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"type":"subscription.created"}`)
+	sig := SignWebhookPayload("whsec_test", 1773104400, body)
+
+	assertEqual(t, len(sig), len("sha256=")+64)
+	assertEqual(t, VerifyWebhookSignature("whsec_test", 1773104400, body, sig), true)
+	assertEqual(t, VerifyWebhookSignature("whsec_other", 1773104400, body, sig), false)
+	assertEqual(t, VerifyWebhookSignature("whsec_test", 1773104401, body, sig), false)
+	assertEqual(t, VerifyWebhookSignature("whsec_test", 1773104400, []byte(`{}`), sig), false)
+}
+
+func TestWebhookDispatcher_PublishAndDeliver(t *testing.T) {
+	f := newWebhookFixture()
+	receiver := newWebhookReceiver(t)
+	f.addEndpoint(receiver.URL)
+
+	sub := f.subs.seedSubscription(f.userID, "Netflix", 17000, models.BillingCycleMonthly)
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionCreated, newWebhookSubscriptionData(sub))
+	assertEqual(t, len(f.deliveries.deliveries), 1)
+
+	delivered, err := f.dispatcher.ProcessDue(context.Background())
+	assertNil(t, err)
+	assertEqual(t, delivered, 1)
+
+	reqs := receiver.received()
+	assertEqual(t, len(reqs), 1)
+	req := reqs[0]
+	assertEqual(t, req.header.Get(WebhookHeaderEvent), "subscription.created")
+	assertEqual(t, req.header.Get("Content-Type"), "application/json")
+
+	timestamp, err := strconv.ParseInt(req.header.Get(WebhookHeaderTimestamp), 10, 64)
+	assertNil(t, err)
+	assertEqual(t, timestamp, f.clock.t.Unix())
+	assertEqual(t, VerifyWebhookSignature("whsec_test", timestamp, req.body, req.header.Get(WebhookHeaderSignature)), true)
+
+	var event struct {
+		ID   string                  `json:"id"`
+		Type string                  `json:"type"`
+		Data WebhookSubscriptionData `json:"data"`
+	}
+	assertNil(t, json.Unmarshal(req.body, &event))
+	assertEqual(t, event.Type, "subscription.created")
+	assertEqual(t, event.Data.ServiceName, "Netflix")
+	assertEqual(t, event.Data.Amount, 17000)
+
+	delivery := f.deliveries.deliveries[0]
+	assertEqual(t, delivery.Status, models.WebhookDeliverySucceeded)
+	assertEqual(t, delivery.Attempts, 1)
+	assertEqual(t, *delivery.ResponseStatus, http.StatusOK)
+	assertEqual(t, delivery.EventID.String(), event.ID)
+	assertEqual(t, req.header.Get(WebhookHeaderDelivery), delivery.ID.String())
+	assertNil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookDispatcher_EventFilter(t *testing.T) {
+	f := newWebhookFixture()
+	shares := f.addEndpoint("https://example.com/shares", models.WebhookEventShareLinked)
+	all := f.addEndpoint("https://example.com/all")
+	disabled := f.addEndpoint("https://example.com/disabled")
+	disabled.Enabled = false
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionCreated, map[string]string{})
+	assertEqual(t, len(f.deliveries.deliveries), 1)
+	assertEqual(t, f.deliveries.deliveries[0].EndpointID, all.ID)
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventShareLinked, map[string]string{})
+	assertEqual(t, len(f.deliveries.deliveries), 3)
+	assertEqual(t, f.deliveries.deliveries[1].EndpointID == shares.ID || f.deliveries.deliveries[2].EndpointID == shares.ID, true)
+
+	// Other users' events never reach these endpoints.
+	f.dispatcher.Publish(uuid.New().String(), models.WebhookEventSubscriptionCreated, map[string]string{})
+	assertEqual(t, len(f.deliveries.deliveries), 3)
+}
+
+func TestWebhookDispatcher_DisabledConfig(t *testing.T) {
+	f := newWebhookFixture()
+	f.dispatcher.cfg.Enabled = false
+	f.addEndpoint("https://example.com/all")
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionCreated, map[string]string{})
+	assertEqual(t, len(f.deliveries.deliveries), 0)
+}
+
+func TestWebhookDispatcher_RetryWithExponentialBackoff(t *testing.T) {
+	f := newWebhookFixture()
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	f.addEndpoint(receiver.URL)
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionUpdated, map[string]string{})
+	delivery := f.deliveries.deliveries[0]
+	ctx := context.Background()
+
+	// First attempt fails; retry after 30s.
+	delivered, _ := f.dispatcher.ProcessDue(ctx)
+	assertEqual(t, delivered, 0)
+	assertEqual(t, delivery.Status, models.WebhookDeliveryPending)
+	assertEqual(t, *delivery.ResponseStatus, http.StatusInternalServerError)
+	assertEqual(t, delivery.NextAttemptAt.Sub(f.clock.t), 30*time.Second)
+
+	// Not due yet.
+	f.clock.advance(29 * time.Second)
+	delivered, _ = f.dispatcher.ProcessDue(ctx)
+	assertEqual(t, delivered, 0)
+	assertEqual(t, len(receiver.received()), 1)
+
+	// Second attempt fails; backoff doubles to 60s.
+	f.clock.advance(time.Second)
+	f.dispatcher.ProcessDue(ctx)
+	assertEqual(t, delivery.Attempts, 2)
+	assertEqual(t, delivery.NextAttemptAt.Sub(f.clock.t), 60*time.Second)
+
+	// Third attempt succeeds.
+	f.clock.advance(60 * time.Second)
+	delivered, _ = f.dispatcher.ProcessDue(ctx)
+	assertEqual(t, delivered, 1)
+	assertEqual(t, delivery.Status, models.WebhookDeliverySucceeded)
+	assertEqual(t, delivery.Attempts, 3)
+	assertNil(t, delivery.LastError)
+	assertEqual(t, len(receiver.received()), 3)
+}
+
+func TestWebhookDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	f := newWebhookFixture()
+	receiver := newWebhookReceiver(t, 500, 500, 500, 500)
+	f.addEndpoint(receiver.URL)
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionUpdated, map[string]string{})
+	delivery := f.deliveries.deliveries[0]
+
+	for i := 0; i < 5; i++ {
+		f.dispatcher.ProcessDue(context.Background())
+		f.clock.advance(time.Hour)
+	}
+
+	assertEqual(t, delivery.Status, models.WebhookDeliveryFailed)
+	assertEqual(t, delivery.Attempts, 3)
+	assertNil(t, delivery.NextAttemptAt)
+	assertNotNil(t, delivery.LastError)
+	assertEqual(t, len(receiver.received()), 3)
+}
+
+func TestWebhookDispatcher_Backoff(t *testing.T) {
+	d := &WebhookDispatcher{cfg: config.WebhookConfig{RetryBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}}
+
+	assertEqual(t, d.backoff(1), 30*time.Second)
+	assertEqual(t, d.backoff(2), time.Minute)
+	assertEqual(t, d.backoff(4), 4*time.Minute)
+	assertEqual(t, d.backoff(5), 5*time.Minute)
+	assertEqual(t, d.backoff(20), 5*time.Minute)
+}
+
+func TestWebhookDispatcher_Redeliver(t *testing.T) {
+	f := newWebhookFixture()
+	receiver := newWebhookReceiver(t)
+	f.addEndpoint(receiver.URL)
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionCancelled, map[string]string{"id": "sub-1"})
+	f.dispatcher.ProcessDue(context.Background())
+	original := f.deliveries.deliveries[0]
+
+	f.clock.advance(time.Minute)
+	redelivery, err := f.dispatcher.Redeliver(context.Background(), original)
+	assertNil(t, err)
+	assertEqual(t, redelivery.Status, models.WebhookDeliverySucceeded)
+	assertEqual(t, *redelivery.RedeliveryOf, original.ID)
+	assertEqual(t, redelivery.EventID, original.EventID)
+
+	reqs := receiver.received()
+	assertEqual(t, len(reqs), 2)
+	assertEqual(t, string(reqs[1].body), string(reqs[0].body))
+	if reqs[0].header.Get(WebhookHeaderDelivery) == reqs[1].header.Get(WebhookHeaderDelivery) {
+		t.Error("redelivery must use a new delivery id")
+	}
+}
+
+func TestWebhookDispatcher_ScanBillingEvents(t *testing.T) {
+	f := newWebhookFixture()
+	receiver := newWebhookReceiver(t)
+	f.addEndpoint(receiver.URL, models.WebhookEventSubscriptionBilled)
+
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	due := f.subs.seedSubscription(f.userID, "Netflix", 17000, models.BillingCycleMonthly)
+	due.NextBillingDate = today
+	due.StartDate = today.AddDate(-1, 0, 0)
+	later := f.subs.seedSubscription(f.userID, "Spotify", 10900, models.BillingCycleMonthly)
+	later.NextBillingDate = today.AddDate(0, 0, 5)
+	later.StartDate = today.AddDate(-1, 0, 0)
+
+	ctx := context.Background()
+	queued, err := f.dispatcher.ScanBillingEvents(ctx)
+	assertNil(t, err)
+	assertEqual(t, queued, 1)
+
+	// Repeated scans on the same day do not announce the charge again.
+	queued, _ = f.dispatcher.ScanBillingEvents(ctx)
+	assertEqual(t, queued, 0)
+
+	f.dispatcher.ProcessDue(ctx)
+	reqs := receiver.received()
+	assertEqual(t, len(reqs), 1)
+
+	var event struct {
+		Type string             `json:"type"`
+		Data WebhookBillingData `json:"data"`
+	}
+	assertNil(t, json.Unmarshal(reqs[0].body, &event))
+	assertEqual(t, event.Type, "subscription.billed")
+	assertEqual(t, event.Data.Subscription.ID, due.ID.String())
+	assertEqual(t, event.Data.BillingDate, "2026-03-10")
+	assertEqual(t, event.Data.ChargeDate, "2026-03-10")
+	assertEqual(t, event.Data.Amount, 17000)
+}
+
+// recordingPublisher captures published events for service tests.
+type recordingPublisher struct {
+	events []models.WebhookEventType
+	data   []interface{}
+}
+
+func (p *recordingPublisher) Publish(userID string, eventType models.WebhookEventType, data interface{}) {
+	p.events = append(p.events, eventType)
+	p.data = append(p.data, data)
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+	"github.com/subkeep/backend/utils"
+)
+
+// maxWebhookEndpoints limits the number of endpoints a user can register.
+const maxWebhookEndpoints = 10
+
+// CreateWebhookEndpointRequest holds the body for registering a webhook endpoint.
+// An empty Events list subscribes the endpoint to every event.
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=500"`
+	Events      []string `json:"events" validate:"omitempty,max=7,dive,oneof=subscription.created subscription.updated subscription.cancelled subscription.billed share.linked share.updated share.unlinked"`
+	Description *string  `json:"description" validate:"omitempty,max=100"`
+	Enabled     *bool    `json:"enabled"`
+}
+
+// UpdateWebhookEndpointRequest holds the body for updating a webhook endpoint.
+type UpdateWebhookEndpointRequest struct {
+	URL         *string   `json:"url" validate:"omitempty,url,max=500"`
+	Events      *[]string `json:"events" validate:"omitempty,max=7,dive,oneof=subscription.created subscription.updated subscription.cancelled subscription.billed share.linked share.updated share.unlinked"`
+	Description *string   `json:"description" validate:"omitempty,max=100"`
+	Enabled     *bool     `json:"enabled"`
+}
+
+// WebhookEndpointResponse is the API representation of a webhook endpoint.
+// Secret is only populated when the endpoint is created or its secret rotated.
+type WebhookEndpointResponse struct {
+	ID          string                    `json:"id"`
+	URL         string                    `json:"url"`
+	Events      []models.WebhookEventType `json:"events"`
+	Description *string                   `json:"description"`
+	Enabled     bool                      `json:"enabled"`
+	Secret      string                    `json:"secret,omitempty"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   time.Time                 `json:"updatedAt"`
+}
+
+// WebhookService handles business logic for webhook endpoints and their delivery log.
+type WebhookService struct {
+	endpointRepo repositories.WebhookEndpointRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	dispatcher   *WebhookDispatcher
+}
+
+// NewWebhookService creates a new WebhookService.
+func NewWebhookService(endpointRepo repositories.WebhookEndpointRepository, deliveryRepo repositories.WebhookDeliveryRepository, dispatcher *WebhookDispatcher) *WebhookService {
+	return &WebhookService{endpointRepo: endpointRepo, deliveryRepo: deliveryRepo, dispatcher: dispatcher}
+}
+
+// GetEndpoints returns all webhook endpoints of the user.
+func (s *WebhookService) GetEndpoints(userID string) ([]WebhookEndpointResponse, error) {
+	endpoints, err := s.endpointRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("웹훅 엔드포인트 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("웹훅 엔드포인트 목록을 조회할 수 없습니다")
+	}
+
+	result := make([]WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result = append(result, newWebhookEndpointResponse(endpoint, false))
+	}
+	return result, nil
+}
+
+// CreateEndpoint registers a new endpoint with a freshly generated signing secret.
+// The secret is returned only in this response.
+func (s *WebhookService) CreateEndpoint(userID string, req *CreateWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+	if appErr := validateWebhookURL(req.URL); appErr != nil {
+		return nil, appErr
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, utils.ErrBadRequest("유효하지 않은 사용자 ID입니다")
+	}
+
+	existing, err := s.endpointRepo.FindByUserID(userID)
+	if err != nil {
+		slog.Error("웹훅 엔드포인트 목록 조회 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("웹훅 엔드포인트를 등록할 수 없습니다")
+	}
+	if len(existing) >= maxWebhookEndpoints {
+		return nil, utils.ErrBadRequest("웹훅 엔드포인트는 최대 10개까지 등록할 수 있습니다")
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		slog.Error("웹훅 서명 키 생성 실패", "error", err)
+		return nil, utils.ErrInternal("웹훅 엔드포인트를 등록할 수 없습니다")
+	}
+
+	endpoint := &models.WebhookEndpoint{
+		UserID:      uid,
+		URL:         req.URL,
+		Secret:      secret,
+		Description: req.Description,
+		Enabled:     true,
+	}
+	endpoint.SetEventTypes(toWebhookEventTypes(req.Events))
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+
+	if err := s.endpointRepo.Create(endpoint); err != nil {
+		slog.Error("웹훅 엔드포인트 생성 실패", "userID", userID, "error", err)
+		return nil, utils.ErrInternal("웹훅 엔드포인트를 등록할 수 없습니다")
+	}
+
+	resp := newWebhookEndpointResponse(endpoint, true)
+	return &resp, nil
+}
+
+// UpdateEndpoint validates ownership and applies partial updates to an endpoint.
+func (s *WebhookService) UpdateEndpoint(userID, endpointID string, req *UpdateWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	if appErr := utils.ValidateStruct(req); appErr != nil {
+		return nil, appErr
+	}
+
+	endpoint, appErr := s.findOwnedEndpoint(userID, endpointID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if req.URL != nil {
+		if appErr := validateWebhookURL(*req.URL); appErr != nil {
+			return nil, appErr
+		}
+		endpoint.URL = *req.URL
+	}
+	if req.Events != nil {
+		endpoint.SetEventTypes(toWebhookEventTypes(*req.Events))
+	}
+	if req.Description != nil {
+		if *req.Description == "" {
+			endpoint.Description = nil
+		} else {
+			endpoint.Description = req.Description
+		}
+	}
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+
+	if err := s.endpointRepo.Update(endpoint); err != nil {
+		slog.Error("웹훅 엔드포인트 수정 실패", "endpointID", endpointID, "error", err)
+		return nil, utils.ErrInternal("웹훅 엔드포인트를 수정할 수 없습니다")
+	}
+
+	resp := newWebhookEndpointResponse(endpoint, false)
+	return &resp, nil
+}
+
+// RotateSecret replaces the endpoint's signing secret and returns the new one.
+func (s *WebhookService) RotateSecret(userID, endpointID string) (*WebhookEndpointResponse, error) {
+	endpoint, appErr := s.findOwnedEndpoint(userID, endpointID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		slog.Error("웹훅 서명 키 생성 실패", "error", err)
+		return nil, utils.ErrInternal("웹훅 서명 키를 재발급할 수 없습니다")
+	}
+	endpoint.Secret = secret
+
+	if err := s.endpointRepo.Update(endpoint); err != nil {
+		slog.Error("웹훅 서명 키 재발급 실패", "endpointID", endpointID, "error", err)
+		return nil, utils.ErrInternal("웹훅 서명 키를 재발급할 수 없습니다")
+	}
+
+	resp := newWebhookEndpointResponse(endpoint, true)
+	return &resp, nil
+}
+
+// DeleteEndpoint validates ownership and deletes an endpoint with its delivery log.
+func (s *WebhookService) DeleteEndpoint(userID, endpointID string) error {
+	if _, appErr := s.findOwnedEndpoint(userID, endpointID); appErr != nil {
+		return appErr
+	}
+
+	if err := s.endpointRepo.Delete(endpointID); err != nil {
+		slog.Error("웹훅 엔드포인트 삭제 실패", "endpointID", endpointID, "error", err)
+		return utils.ErrInternal("웹훅 엔드포인트를 삭제할 수 없습니다")
+	}
+	return nil
+}
+
+// GetDeliveries returns the user's webhook delivery log, newest first.
+func (s *WebhookService) GetDeliveries(userID string, filter repositories.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int64, error) {
+	if filter.EndpointID != "" {
+		if _, appErr := s.findOwnedEndpoint(userID, filter.EndpointID); appErr != nil {
+			return nil, 0, appErr
+		}
+	}
+
+	deliveries, total, err := s.deliveryRepo.FindByUserID(userID, filter)
+	if err != nil {
+		slog.Error("웹훅 발송 이력 조회 실패", "userID", userID, "error", err)
+		return nil, 0, utils.ErrInternal("웹훅 발송 이력을 조회할 수 없습니다")
+	}
+	return deliveries, total, nil
+}
+
+// Redeliver sends a logged delivery again and returns the new delivery record.
+func (s *WebhookService) Redeliver(ctx context.Context, userID, deliveryID string) (*models.WebhookDelivery, error) {
+	original, err := s.deliveryRepo.FindByID(deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("웹훅 발송 기록을 찾을 수 없습니다")
+		}
+		slog.Error("웹훅 발송 기록 조회 실패", "deliveryID", deliveryID, "error", err)
+		return nil, utils.ErrInternal("웹훅 발송 기록을 조회할 수 없습니다")
+	}
+	if original.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 웹훅 발송 기록에 대한 접근 권한이 없습니다")
+	}
+
+	endpoint, appErr := s.findOwnedEndpoint(userID, original.EndpointID.String())
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !endpoint.Enabled {
+		return nil, utils.ErrBadRequest("비활성화된 웹훅 엔드포인트에는 재발송할 수 없습니다")
+	}
+
+	delivery, err := s.dispatcher.Redeliver(ctx, original)
+	if err != nil {
+		slog.Error("웹훅 재발송 실패", "deliveryID", deliveryID, "error", err)
+		return nil, utils.ErrInternal("웹훅을 재발송할 수 없습니다")
+	}
+	return delivery, nil
+}
+
+// findOwnedEndpoint loads an endpoint and verifies it belongs to the user.
+func (s *WebhookService) findOwnedEndpoint(userID, endpointID string) (*models.WebhookEndpoint, *utils.AppError) {
+	endpoint, err := s.endpointRepo.FindByID(endpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("웹훅 엔드포인트를 찾을 수 없습니다")
+		}
+		slog.Error("웹훅 엔드포인트 조회 실패", "endpointID", endpointID, "error", err)
+		return nil, utils.ErrInternal("웹훅 엔드포인트를 조회할 수 없습니다")
+	}
+	if endpoint.UserID.String() != userID {
+		return nil, utils.ErrForbidden("해당 웹훅 엔드포인트에 대한 접근 권한이 없습니다")
+	}
+	return endpoint, nil
+}
+
+// newWebhookEndpointResponse converts an endpoint into its API representation.
+func newWebhookEndpointResponse(endpoint *models.WebhookEndpoint, withSecret bool) WebhookEndpointResponse {
+	resp := WebhookEndpointResponse{
+		ID:          endpoint.ID.String(),
+		URL:         endpoint.URL,
+		Events:      endpoint.EventTypes(),
+		Description: endpoint.Description,
+		Enabled:     endpoint.Enabled,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+	if withSecret {
+		resp.Secret = endpoint.Secret
+	}
+	return resp
+}
+
+// validateWebhookURL requires an absolute http(s) URL that does not point
+// into the server's own network.
+func validateWebhookURL(raw string) *utils.AppError {
+	if err := validateOutboundURL(raw); err != nil {
+		if errors.Is(err, errBlockedAddress) {
+			return utils.ErrValidation("내부 네트워크 주소로는 웹훅을 보낼 수 없습니다")
+		}
+		return utils.ErrValidation("웹훅 URL은 http(s) 주소여야 합니다")
+	}
+	return nil
+}
+
+// toWebhookEventTypes converts and de-duplicates requested event names.
+func toWebhookEventTypes(events []string) []models.WebhookEventType {
+	seen := make(map[string]bool, len(events))
+	types := make([]models.WebhookEventType, 0, len(events))
+	for _, e := range events {
+		if seen[e] {
+			continue
+		}
+		seen[e] = true
+		types = append(types, models.WebhookEventType(e))
+	}
+	return types
+}
+
+// generateWebhookSecret returns a random signing secret prefixed with "whsec_".
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
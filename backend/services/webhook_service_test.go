@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+func newTestWebhookService() (*WebhookService, *webhookFixture) {
+	f := newWebhookFixture()
+	return NewWebhookService(f.endpoints, f.deliveries, f.dispatcher), f
+}
+
+func TestCreateWebhookEndpoint(t *testing.T) {
+	t.Run("returns generated secret once", func(t *testing.T) {
+		svc, f := newTestWebhookService()
+
+		created, err := svc.CreateEndpoint(f.userID.String(), &CreateWebhookEndpointRequest{
+			URL:    "https://hooks.example.com/subkeep",
+			Events: []string{"subscription.created", "subscription.billed", "subscription.created"},
+		})
+		assertNil(t, err)
+		if !strings.HasPrefix(created.Secret, "whsec_") || len(created.Secret) != len("whsec_")+64 {
+			t.Errorf("unexpected secret %q", created.Secret)
+		}
+		assertEqual(t, created.Events, []models.WebhookEventType{
+			models.WebhookEventSubscriptionCreated,
+			models.WebhookEventSubscriptionBilled,
+		})
+		assertEqual(t, created.Enabled, true)
+
+		listed, err := svc.GetEndpoints(f.userID.String())
+		assertNil(t, err)
+		assertEqual(t, len(listed), 1)
+		assertEqual(t, listed[0].Secret, "")
+	})
+
+	t.Run("rejects unknown event", func(t *testing.T) {
+		svc, f := newTestWebhookService()
+		_, err := svc.CreateEndpoint(f.userID.String(), &CreateWebhookEndpointRequest{
+			URL:    "https://hooks.example.com/subkeep",
+			Events: []string{"subscription.exploded"},
+		})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects non-http url", func(t *testing.T) {
+		svc, f := newTestWebhookService()
+		_, err := svc.CreateEndpoint(f.userID.String(), &CreateWebhookEndpointRequest{URL: "ftp://hooks.example.com"})
+		assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects internal addresses", func(t *testing.T) {
+		svc, f := newTestWebhookService()
+		for _, raw := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest/meta-data", "https://[::1]/hook"} {
+			_, err := svc.CreateEndpoint(f.userID.String(), &CreateWebhookEndpointRequest{URL: raw})
+			assertAppErrorCode(t, err, http.StatusUnprocessableEntity)
+		}
+	})
+
+	t.Run("limits endpoints per user", func(t *testing.T) {
+		svc, f := newTestWebhookService()
+		for i := 0; i < maxWebhookEndpoints; i++ {
+			f.addEndpoint("https://hooks.example.com/subkeep")
+		}
+		_, err := svc.CreateEndpoint(f.userID.String(), &CreateWebhookEndpointRequest{URL: "https://hooks.example.com/one-more"})
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestUpdateAndRotateWebhookEndpoint(t *testing.T) {
+	svc, f := newTestWebhookService()
+	endpoint := f.addEndpoint("https://hooks.example.com/subkeep", models.WebhookEventShareLinked)
+
+	t.Run("other users cannot modify", func(t *testing.T) {
+		_, err := svc.UpdateEndpoint(uuid.New().String(), endpoint.ID.String(), &UpdateWebhookEndpointRequest{Enabled: boolPtr(false)})
+		assertAppErrorCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("empty event list subscribes to everything", func(t *testing.T) {
+		updated, err := svc.UpdateEndpoint(f.userID.String(), endpoint.ID.String(), &UpdateWebhookEndpointRequest{Events: &[]string{}})
+		assertNil(t, err)
+		assertEqual(t, len(updated.Events), 0)
+		assertEqual(t, f.endpoints.endpoints[endpoint.ID.String()].Subscribes(models.WebhookEventSubscriptionBilled), true)
+	})
+
+	t.Run("rotates secret", func(t *testing.T) {
+		rotated, err := svc.RotateSecret(f.userID.String(), endpoint.ID.String())
+		assertNil(t, err)
+		if rotated.Secret == "" || rotated.Secret == "whsec_test" {
+			t.Errorf("expected a new secret, got %q", rotated.Secret)
+		}
+		assertEqual(t, f.endpoints.endpoints[endpoint.ID.String()].Secret, rotated.Secret)
+	})
+
+	t.Run("deletes endpoint", func(t *testing.T) {
+		assertNil(t, svc.DeleteEndpoint(f.userID.String(), endpoint.ID.String()))
+		assertAppErrorCode(t, svc.DeleteEndpoint(f.userID.String(), endpoint.ID.String()), http.StatusNotFound)
+	})
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	svc, f := newTestWebhookService()
+	receiver := newWebhookReceiver(t, http.StatusBadGateway)
+	f.addEndpoint(receiver.URL)
+
+	f.dispatcher.Publish(f.userID.String(), models.WebhookEventSubscriptionCreated, map[string]string{})
+	original := f.deliveries.deliveries[0]
+
+	t.Run("other users cannot redeliver", func(t *testing.T) {
+		_, err := svc.Redeliver(context.Background(), uuid.New().String(), original.ID.String())
+		assertAppErrorCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("missing delivery", func(t *testing.T) {
+		_, err := svc.Redeliver(context.Background(), f.userID.String(), uuid.New().String())
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("sends again", func(t *testing.T) {
+		redelivery, err := svc.Redeliver(context.Background(), f.userID.String(), original.ID.String())
+		assertNil(t, err)
+		assertEqual(t, redelivery.EventID, original.EventID)
+		assertEqual(t, *redelivery.ResponseStatus, http.StatusBadGateway)
+		assertEqual(t, redelivery.Status, models.WebhookDeliveryPending)
+
+		deliveries, total, err := svc.GetDeliveries(f.userID.String(), repositories.WebhookDeliveryFilter{Page: 1, PerPage: 20})
+		assertNil(t, err)
+		assertEqual(t, total, int64(2))
+		assertEqual(t, len(deliveries), 2)
+	})
+}
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/repositories"
+)
+
+const (
+	// pushRecordSize is the aes128gcm record size advertised in the header.
+	pushRecordSize = 4096
+	// pushMaxPlaintext is the largest plaintext that fits in a single 4096-byte
+	// push message: 86 bytes of header, a 16-byte tag and the padding delimiter.
+	pushMaxPlaintext = 4096 - 86 - 16 - 1
+	// vapidTokenLifetime is the validity of the VAPID JWT (at most 24 hours).
+	vapidTokenLifetime = 12 * time.Hour
+)
+
+// b64url is unpadded base64url, the encoding used for every Web Push key.
+var b64url = base64.RawURLEncoding
+
+// VAPIDKeys is the application server key pair used to sign push requests.
+type VAPIDKeys struct {
+	PublicKey  string // base64url uncompressed P-256 point
+	privateKey *ecdsa.PrivateKey
+}
+
+// GenerateVAPIDKey creates a new random P-256 VAPID key pair.
+func GenerateVAPIDKey() (*models.VAPIDKey, error) {
+	priv, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate vapid key: %w", err)
+	}
+	return &models.VAPIDKey{
+		PublicKey:  b64url.EncodeToString(priv.PublicKey().Bytes()),
+		PrivateKey: b64url.EncodeToString(priv.Bytes()),
+	}, nil
+}
+
+// LoadOrCreateVAPIDKeys returns the stored VAPID key pair, generating and
+// storing one on first use.
+func LoadOrCreateVAPIDKeys(repo repositories.VAPIDKeyRepository) (*VAPIDKeys, error) {
+	stored, err := repo.FindLatest()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if stored, err = GenerateVAPIDKey(); err != nil {
+			return nil, err
+		}
+		if err = repo.Create(stored); err != nil {
+			return nil, err
+		}
+		slog.Info("VAPID 키 생성", "publicKey", stored.PublicKey)
+	}
+	return ParseVAPIDKeys(stored)
+}
+
+// ParseVAPIDKeys decodes a stored VAPID key pair.
+func ParseVAPIDKeys(key *models.VAPIDKey) (*VAPIDKeys, error) {
+	raw, err := b64url.DecodeString(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode vapid private key: %w", err)
+	}
+	priv, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse vapid private key: %w", err)
+	}
+	pub := priv.PublicKey().Bytes()
+	if b64url.EncodeToString(pub) != key.PublicKey {
+		return nil, fmt.Errorf("vapid public key does not match private key")
+	}
+
+	return &VAPIDKeys{
+		PublicKey: key.PublicKey,
+		privateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(pub[1:33]),
+				Y:     new(big.Int).SetBytes(pub[33:65]),
+			},
+			D: new(big.Int).SetBytes(raw),
+		},
+	}, nil
+}
+
+// Authorization returns the RFC 8292 "vapid" Authorization header value for a
+// push service endpoint.
+func (k *VAPIDKeys) Authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint %q", endpoint)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+		"sub": subject,
+	})
+	signed, err := token.SignedString(k.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("sign vapid token: %w", err)
+	}
+	return "vapid t=" + signed + ", k=" + k.PublicKey, nil
+}
+
+// EncryptPushPayload encrypts plaintext for a browser push subscription using
+// the aes128gcm content coding of RFC 8291. p256dh and auth are the
+// base64url-encoded keys supplied by the browser.
+func EncryptPushPayload(p256dh, auth string, plaintext []byte) ([]byte, error) {
+	ephemeral, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate ephemeral key: %w", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	return encryptPushPayload(p256dh, auth, plaintext, ephemeral, salt)
+}
+
+// encryptPushPayload performs RFC 8291 encryption with a caller-supplied
+// ephemeral key and salt so the result can be checked against test vectors.
+func encryptPushPayload(p256dh, auth string, plaintext []byte, ephemeral *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(plaintext) > pushMaxPlaintext {
+		return nil, fmt.Errorf("push payload too large: %d bytes", len(plaintext))
+	}
+
+	uaPublicRaw, err := b64url.DecodeString(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("decode p256dh: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicRaw)
+	if err != nil {
+		return nil, fmt.Errorf("parse p256dh: %w", err)
+	}
+	authSecret, err := b64url.DecodeString(auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, fmt.Errorf("auth secret must be 16 bytes")
+	}
+
+	ecdhSecret, err := ephemeral.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("derive shared secret: %w", err)
+	}
+	asPublic := ephemeral.PublicKey().Bytes()
+
+	// RFC 8291 §3.4: combine the shared secret with the auth secret.
+	keyInfo := make([]byte, 0, 14+65+65)
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublicRaw...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdfExpand(hkdfExtract(authSecret, ecdhSecret), keyInfo, 32)
+
+	// RFC 8188 §2.2: derive the content encryption key and nonce.
+	prk := hkdfExtract(salt, ikm)
+	cek := hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+
+	// A single record: plaintext followed by the last-record delimiter.
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02)
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// hkdfExtract is HKDF-Extract (RFC 5869) with SHA-256.
+func hkdfExtract(salt, ikm []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+// hkdfExpand is HKDF-Expand (RFC 5869) with SHA-256, limited to a single
+// block since every Web Push key is at most 32 bytes.
+func hkdfExpand(prk, info []byte, length int) []byte {
+	mac := hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+// PushMessage is the JSON payload delivered to the service worker.
+type PushMessage struct {
+	Title          string                      `json:"title"`
+	Body           string                      `json:"body"`
+	Event          models.NotificationRuleType `json:"event,omitempty"`
+	SubscriptionID string                      `json:"subscriptionId,omitempty"`
+	Tag            string                      `json:"tag,omitempty"`
+}
+
+// PushSendResult counts the outcome of sending to every device of a user.
+// Expired subscriptions (404/410 from the push service) are removed.
+type PushSendResult struct {
+	Delivered int `json:"delivered"`
+	Expired   int `json:"expired"`
+	Failed    int `json:"failed"`
+}
+
+// PushNotifier delivers notifications to every registered browser of a user
+// through the Web Push protocol (RFC 8030) with VAPID authentication.
+type PushNotifier struct {
+	subRepo repositories.PushSubscriptionRepository
+	keys    *VAPIDKeys
+	client  *http.Client
+	clock   Clock
+	cfg     config.PushConfig
+}
+
+// NewPushNotifier creates a new PushNotifier. client may be nil, in which case
+// a client with cfg.Timeout that refuses internal addresses is used; clock
+// defaults to the system clock.
+func NewPushNotifier(
+	subRepo repositories.PushSubscriptionRepository,
+	keys *VAPIDKeys,
+	client *http.Client,
+	clock Clock,
+	cfg config.PushConfig,
+) *PushNotifier {
+	if client == nil {
+		client = newGuardedHTTPClient(cfg.Timeout)
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &PushNotifier{subRepo: subRepo, keys: keys, client: client, clock: clock, cfg: cfg}
+}
+
+// Send pushes the notification to all of n.UserID's devices. It fails only
+// when no device received it.
+func (p *PushNotifier) Send(ctx context.Context, n *Notification) error {
+	result, err := p.SendToUser(ctx, n.UserID, PushMessage{
+		Title:          n.Subject,
+		Body:           n.Body,
+		Event:          n.Event,
+		SubscriptionID: n.SubscriptionID,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Delivered == 0 {
+		return fmt.Errorf("send push: no device accepted the notification (expired %d, failed %d)", result.Expired, result.Failed)
+	}
+	return nil
+}
+
+// SendToUser pushes msg to every device of a user.
+func (p *PushNotifier) SendToUser(ctx context.Context, userID string, msg PushMessage) (*PushSendResult, error) {
+	subs, err := p.subRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("load push subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("send push: user has no push subscriptions")
+	}
+
+	payload, err := encodePushMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PushSendResult{}
+	for _, sub := range subs {
+		status, sendErr := p.sendOne(ctx, sub, payload)
+		switch {
+		case status == http.StatusNotFound || status == http.StatusGone:
+			result.Expired++
+			if delErr := p.subRepo.Delete(sub.ID.String()); delErr != nil {
+				slog.Error("만료된 푸시 구독 삭제 실패", "pushSubscriptionID", sub.ID, "error", delErr)
+			}
+		case sendErr != nil:
+			result.Failed++
+			slog.Warn("푸시 발송 실패", "pushSubscriptionID", sub.ID, "status", status, "error", sendErr)
+		default:
+			result.Delivered++
+			usedAt := p.clock.Now()
+			sub.LastUsedAt = &usedAt
+			if updErr := p.subRepo.Update(sub); updErr != nil {
+				slog.Error("푸시 구독 갱신 실패", "pushSubscriptionID", sub.ID, "error", updErr)
+			}
+		}
+	}
+	return result, nil
+}
+
+// sendOne encrypts and posts a payload to a single push subscription. It
+// returns the push service's status code (0 if no response was received).
+func (p *PushNotifier) sendOne(ctx context.Context, sub *models.PushSubscription, payload []byte) (int, error) {
+	body, err := EncryptPushPayload(sub.P256dh, sub.Auth, payload)
+	if err != nil {
+		return 0, err
+	}
+	authorization, err := p.keys.Authorization(sub.Endpoint, p.cfg.Subject, p.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(p.cfg.TTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send push: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("send push: unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// encodePushMessage marshals msg, shortening the body until it fits in a
+// single push message.
+func encodePushMessage(msg PushMessage) ([]byte, error) {
+	for {
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			return nil, fmt.Errorf("encode push payload: %w", err)
+		}
+		if len(payload) <= pushMaxPlaintext {
+			return payload, nil
+		}
+		if msg.Body == "" {
+			return nil, fmt.Errorf("push payload too large: %d bytes", len(payload))
+		}
+		// Drop at least the overflow, on a rune boundary.
+		cut := len(msg.Body) - (len(payload) - pushMaxPlaintext) - len("…")
+		if cut < 0 {
+			cut = 0
+		}
+		for cut > 0 && !utf8.RuneStart(msg.Body[cut]) {
+			cut--
+		}
+		msg.Body = msg.Body[:cut] + "…"
+		if cut == 0 {
+			msg.Body = ""
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/config"
+	"github.com/subkeep/backend/models"
+)
+
+// ---------------------------------------------------------------------------
+// Mock PushSubscriptionRepository
+// ---------------------------------------------------------------------------
+
+type mockPushSubscriptionRepo struct {
+	subs map[string]*models.PushSubscription
+}
+
+func newMockPushSubscriptionRepo() *mockPushSubscriptionRepo {
+	return &mockPushSubscriptionRepo{subs: make(map[string]*models.PushSubscription)}
+}
+
+func (m *mockPushSubscriptionRepo) FindByID(id string) (*models.PushSubscription, error) {
+	sub, ok := m.subs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *sub
+	return &copied, nil
+}
+
+func (m *mockPushSubscriptionRepo) FindByUserID(userID string) ([]*models.PushSubscription, error) {
+	var result []*models.PushSubscription
+	for _, sub := range m.subs {
+		if sub.UserID.String() == userID {
+			copied := *sub
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPushSubscriptionRepo) FindByEndpoint(endpoint string) (*models.PushSubscription, error) {
+	for _, sub := range m.subs {
+		if sub.Endpoint == endpoint {
+			copied := *sub
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockPushSubscriptionRepo) Create(sub *models.PushSubscription) error {
+	if sub.ID == uuid.Nil {
+		sub.ID = uuid.New()
+	}
+	copied := *sub
+	m.subs[sub.ID.String()] = &copied
+	return nil
+}
+
+func (m *mockPushSubscriptionRepo) Update(sub *models.PushSubscription) error {
+	copied := *sub
+	m.subs[sub.ID.String()] = &copied
+	return nil
+}
+
+func (m *mockPushSubscriptionRepo) Delete(id string) error {
+	delete(m.subs, id)
+	return nil
+}
+
+type mockVAPIDKeyRepo struct {
+	keys []*models.VAPIDKey
+}
+
+func (m *mockVAPIDKeyRepo) FindLatest() (*models.VAPIDKey, error) {
+	if len(m.keys) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return m.keys[len(m.keys)-1], nil
+}
+
+func (m *mockVAPIDKeyRepo) Create(key *models.VAPIDKey) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Test helpers
+// ---------------------------------------------------------------------------
+
+// pushBrowser is a simulated browser holding the user-agent key pair.
+type pushBrowser struct {
+	private *ecdh.PrivateKey
+	auth    []byte
+}
+
+func newPushBrowser(t *testing.T) *pushBrowser {
+	t.Helper()
+	priv, err := ecdh.P256().GenerateKey(rand.Reader)
+	assertNil(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	assertNil(t, err)
+	return &pushBrowser{private: priv, auth: auth}
+}
+
+func (b *pushBrowser) p256dh() string  { return b64url.EncodeToString(b.private.PublicKey().Bytes()) }
+func (b *pushBrowser) authKey() string { return b64url.EncodeToString(b.auth) }
+
+// decrypt reverses RFC 8291 encryption the way a browser would.
+func (b *pushBrowser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	if len(body) < 21 {
+		t.Fatalf("push body too short: %d bytes", len(body))
+	}
+	salt := body[:16]
+	rs := binary.BigEndian.Uint32(body[16:20])
+	idLen := int(body[20])
+	asPublicRaw := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+	assertEqual(t, uint32(pushRecordSize), rs)
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicRaw)
+	assertNil(t, err)
+	secret, err := b.private.ECDH(asPublic)
+	assertNil(t, err)
+
+	keyInfo := append([]byte("WebPush: info\x00"), b.private.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicRaw...)
+	ikm := hkdfExpand(hkdfExtract(b.auth, secret), keyInfo, 32)
+	prk := hkdfExtract(salt, ikm)
+	cek := hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	assertNil(t, err)
+	gcm, err := cipher.NewGCM(block)
+	assertNil(t, err)
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	assertNil(t, err)
+
+	if len(record) == 0 || record[len(record)-1] != 0x02 {
+		t.Fatalf("expected last-record padding delimiter, got %x", record)
+	}
+	return record[:len(record)-1]
+}
+
+// pushServiceStandIn is a local push service that records requests and
+// answers with a configurable status per endpoint path.
+type pushServiceStandIn struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []*recordedPush
+	statuses map[string]int
+}
+
+type recordedPush struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newPushServiceStandIn(t *testing.T) *pushServiceStandIn {
+	t.Helper()
+	p := &pushServiceStandIn{statuses: make(map[string]int)}
+	p.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		p.mu.Lock()
+		p.requests = append(p.requests, &recordedPush{path: r.URL.Path, header: r.Header.Clone(), body: body})
+		status, ok := p.statuses[r.URL.Path]
+		p.mu.Unlock()
+		if !ok {
+			status = http.StatusCreated
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *pushServiceStandIn) received() []*recordedPush {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]*recordedPush, len(p.requests))
+	copy(out, p.requests)
+	return out
+}
+
+func newTestVAPIDKeys(t *testing.T) *VAPIDKeys {
+	t.Helper()
+	stored, err := GenerateVAPIDKey()
+	assertNil(t, err)
+	keys, err := ParseVAPIDKeys(stored)
+	assertNil(t, err)
+	return keys
+}
+
+// ===========================================================================
+// Encryption
+// ===========================================================================
+
+// TestEncryptPushPayload_RFC8291Vector checks the example in RFC 8291 Appendix A.
+func TestEncryptPushPayload_RFC8291Vector(t *testing.T) {
+	decode := func(s string) []byte {
+		b, err := b64url.DecodeString(s)
+		assertNil(t, err)
+		return b
+	}
+	asPrivate, err := ecdh.P256().NewPrivateKey(decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	assertNil(t, err)
+
+	got, err := encryptPushPayload(
+		"BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		"BTBZMqHH6r4Tts7J_aSIgg",
+		[]byte("When I grow up, I want to be a watermelon"),
+		asPrivate,
+		decode("DGv6ra1nlYgDCS1FRnbzlw"),
+	)
+	assertNil(t, err)
+
+	want := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	assertEqual(t, want, b64url.EncodeToString(got))
+}
+
+func TestEncryptPushPayload_RoundTrip(t *testing.T) {
+	browser := newPushBrowser(t)
+	plaintext := []byte(`{"title":"Netflix 결제 예정"}`)
+
+	first, err := EncryptPushPayload(browser.p256dh(), browser.authKey(), plaintext)
+	assertNil(t, err)
+	second, err := EncryptPushPayload(browser.p256dh(), browser.authKey(), plaintext)
+	assertNil(t, err)
+
+	assertEqual(t, plaintext, browser.decrypt(t, first))
+	if string(first) == string(second) {
+		t.Error("expected a fresh salt and ephemeral key per message")
+	}
+}
+
+func TestEncryptPushPayload_RejectsBadKeys(t *testing.T) {
+	browser := newPushBrowser(t)
+	_, err := EncryptPushPayload("not-a-key", browser.authKey(), []byte("x"))
+	assertError(t, err)
+	_, err = EncryptPushPayload(browser.p256dh(), b64url.EncodeToString([]byte("short")), []byte("x"))
+	assertError(t, err)
+	_, err = EncryptPushPayload(browser.p256dh(), browser.authKey(), make([]byte, pushMaxPlaintext+1))
+	assertError(t, err)
+}
+
+func TestEncodePushMessage_TruncatesLongBody(t *testing.T) {
+	payload, err := encodePushMessage(PushMessage{Title: "긴 알림", Body: strings.Repeat("가", 3000)})
+	assertNil(t, err)
+	if len(payload) > pushMaxPlaintext {
+		t.Fatalf("payload is %d bytes, limit %d", len(payload), pushMaxPlaintext)
+	}
+	var msg PushMessage
+	assertNil(t, json.Unmarshal(payload, &msg))
+	if !strings.HasSuffix(msg.Body, "…") {
+		t.Errorf("expected truncated body to end with an ellipsis, got %q", msg.Body[len(msg.Body)-6:])
+	}
+}
+
+// ===========================================================================
+// VAPID
+// ===========================================================================
+
+func TestLoadOrCreateVAPIDKeys(t *testing.T) {
+	repo := &mockVAPIDKeyRepo{}
+
+	first, err := LoadOrCreateVAPIDKeys(repo)
+	assertNil(t, err)
+	assertEqual(t, 1, len(repo.keys))
+	raw, err := b64url.DecodeString(first.PublicKey)
+	assertNil(t, err)
+	assertEqual(t, 65, len(raw))
+
+	second, err := LoadOrCreateVAPIDKeys(repo)
+	assertNil(t, err)
+	assertEqual(t, 1, len(repo.keys))
+	assertEqual(t, first.PublicKey, second.PublicKey)
+}
+
+func TestParseVAPIDKeys_RejectsMismatchedPair(t *testing.T) {
+	a, err := GenerateVAPIDKey()
+	assertNil(t, err)
+	b, err := GenerateVAPIDKey()
+	assertNil(t, err)
+	_, err = ParseVAPIDKeys(&models.VAPIDKey{PublicKey: a.PublicKey, PrivateKey: b.PrivateKey})
+	assertError(t, err)
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	keys := newTestVAPIDKeys(t)
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	header, err := keys.Authorization("https://push.example.com/send/abc?x=1", "mailto:ops@subkeep.app", now)
+	assertNil(t, err)
+
+	if !strings.HasPrefix(header, "vapid t=") || !strings.HasSuffix(header, ", k="+keys.PublicKey) {
+		t.Fatalf("unexpected header %q", header)
+	}
+	token := strings.TrimSuffix(strings.TrimPrefix(header, "vapid t="), ", k="+keys.PublicKey)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return &keys.privateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithTimeFunc(func() time.Time { return now }))
+	assertNil(t, err)
+	assertEqual(t, "https://push.example.com", claims["aud"])
+	assertEqual(t, "mailto:ops@subkeep.app", claims["sub"])
+	assertEqual(t, float64(now.Add(12*time.Hour).Unix()), claims["exp"])
+}
+
+// ===========================================================================
+// PushNotifier
+// ===========================================================================
+
+func TestPushNotifier_DeliversToEveryDevice(t *testing.T) {
+	service := newPushServiceStandIn(t)
+	repo := newMockPushSubscriptionRepo()
+	keys := newTestVAPIDKeys(t)
+	userID := uuid.New()
+
+	phone, laptop := newPushBrowser(t), newPushBrowser(t)
+	for path, b := range map[string]*pushBrowser{"/phone": phone, "/laptop": laptop} {
+		assertNil(t, repo.Create(&models.PushSubscription{
+			UserID: userID, Endpoint: service.server.URL + path, P256dh: b.p256dh(), Auth: b.authKey(),
+		}))
+	}
+
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	notifier := NewPushNotifier(repo, keys, service.server.Client(), fixedClock(now),
+		config.PushConfig{Subject: "mailto:ops@subkeep.app", TTL: time.Hour})
+
+	err := notifier.Send(context.Background(), &Notification{
+		UserID:         userID.String(),
+		SubscriptionID: "sub-1",
+		Event:          models.NotificationRuleBillingUpcoming,
+		Subject:        "[SubKeep] Netflix 결제 예정 알림",
+		Body:           "Netflix 구독료 17,000원이 결제될 예정입니다.",
+	})
+	assertNil(t, err)
+
+	received := service.received()
+	assertEqual(t, 2, len(received))
+	for _, req := range received {
+		assertEqual(t, "aes128gcm", req.header.Get("Content-Encoding"))
+		assertEqual(t, "3600", req.header.Get("TTL"))
+		if !strings.HasPrefix(req.header.Get("Authorization"), "vapid t=") {
+			t.Errorf("missing VAPID authorization: %q", req.header.Get("Authorization"))
+		}
+
+		browser := phone
+		if req.path == "/laptop" {
+			browser = laptop
+		}
+		var msg PushMessage
+		assertNil(t, json.Unmarshal(browser.decrypt(t, req.body), &msg))
+		assertEqual(t, "[SubKeep] Netflix 결제 예정 알림", msg.Title)
+		assertEqual(t, models.NotificationRuleBillingUpcoming, msg.Event)
+		assertEqual(t, "sub-1", msg.SubscriptionID)
+	}
+
+	for _, sub := range repo.subs {
+		assertNotNil(t, sub.LastUsedAt)
+		assertEqual(t, now, *sub.LastUsedAt)
+	}
+}
+
+func TestPushNotifier_RemovesExpiredSubscriptions(t *testing.T) {
+	service := newPushServiceStandIn(t)
+	service.statuses["/gone"] = http.StatusGone
+	service.statuses["/broken"] = http.StatusInternalServerError
+	repo := newMockPushSubscriptionRepo()
+	userID := uuid.New()
+
+	add := func(path string) *models.PushSubscription {
+		b := newPushBrowser(t)
+		sub := &models.PushSubscription{UserID: userID, Endpoint: service.server.URL + path, P256dh: b.p256dh(), Auth: b.authKey()}
+		assertNil(t, repo.Create(sub))
+		return sub
+	}
+	gone := add("/gone")
+	broken := add("/broken")
+
+	notifier := NewPushNotifier(repo, newTestVAPIDKeys(t), service.server.Client(), nil, config.PushConfig{})
+
+	result, err := notifier.SendToUser(context.Background(), userID.String(), PushMessage{Title: "t"})
+	assertNil(t, err)
+	assertEqual(t, PushSendResult{Delivered: 0, Expired: 1, Failed: 1}, *result)
+
+	if _, ok := repo.subs[gone.ID.String()]; ok {
+		t.Error("expected the expired subscription to be removed")
+	}
+	if _, ok := repo.subs[broken.ID.String()]; !ok {
+		t.Error("expected the failing subscription to be kept")
+	}
+
+	// Send reports an error when no device accepted the notification.
+	assertError(t, notifier.Send(context.Background(), &Notification{UserID: userID.String(), Subject: "t"}))
+}
+
+func TestPushNotifier_NoSubscriptions(t *testing.T) {
+	notifier := NewPushNotifier(newMockPushSubscriptionRepo(), newTestVAPIDKeys(t), nil, nil, config.PushConfig{})
+	assertError(t, notifier.Send(context.Background(), &Notification{UserID: uuid.New().String()}))
+}
+
+func TestPushNotifier_DefaultClientRefusesLoopback(t *testing.T) {
+	service := newPushServiceStandIn(t)
+	repo := newMockPushSubscriptionRepo()
+	userID := uuid.New()
+	b := newPushBrowser(t)
+	assertNil(t, repo.Create(&models.PushSubscription{UserID: userID, Endpoint: service.server.URL + "/device", P256dh: b.p256dh(), Auth: b.authKey()}))
+
+	notifier := NewPushNotifier(repo, newTestVAPIDKeys(t), nil, nil, config.PushConfig{})
+	// Trust the stand-in's certificate so that only the address guard can refuse it.
+	trusted := service.server.Client().Transport.(*http.Transport).TLSClientConfig
+	notifier.client.Transport.(*http.Transport).TLSClientConfig = trusted
+	result, err := notifier.SendToUser(context.Background(), userID.String(), PushMessage{Title: "t"})
+	assertNil(t, err)
+	assertEqual(t, 0, result.Delivered)
+	assertEqual(t, 0, len(service.received()))
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/utils"
+)
+
+// YearInReview summarises a calendar year of subscriptions. Amounts are the
+// user's personal share of what was charged in the year; for the current
+// year they run up to today.
+type YearInReview struct {
+	Year                int                   `json:"year"`
+	From                time.Time             `json:"from"`
+	To                  time.Time             `json:"to"`
+	TotalSpent          int                   `json:"totalSpent"`
+	PreviousYearSpent   int                   `json:"previousYearSpent"`
+	MonthlyTrend        []MonthlyTrend        `json:"monthlyTrend"`
+	MostExpensive       *YearInReviewSpending `json:"mostExpensive"`
+	LongestHeld         *YearInReviewTenure   `json:"longestHeld"`
+	Added               []YearInReviewEvent   `json:"added"`
+	Cancelled           []YearInReviewEvent   `json:"cancelled"`
+	SavingsRealized     int                   `json:"savingsRealized"`
+	SatisfactionChanges []SatisfactionShift   `json:"satisfactionChanges"`
+	CategoryShifts      []CategoryShift       `json:"categoryShifts"`
+}
+
+// YearInReviewSpending is what one subscription cost over the year.
+type YearInReviewSpending struct {
+	SubscriptionID string `json:"subscriptionId"`
+	ServiceName    string `json:"serviceName"`
+	Amount         int    `json:"amount"`
+}
+
+// YearInReviewTenure is how long a subscription had been held by its last
+// billed day of the year.
+type YearInReviewTenure struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	ServiceName    string    `json:"serviceName"`
+	StartDate      time.Time `json:"startDate"`
+	Days           int       `json:"days"`
+}
+
+// YearInReviewEvent is a subscription added or cancelled during the year,
+// with its personal monthly-equivalent amount at the time.
+type YearInReviewEvent struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	ServiceName    string    `json:"serviceName"`
+	Date           time.Time `json:"date"`
+	MonthlyAmount  int       `json:"monthlyAmount"`
+}
+
+// SatisfactionShift is how a subscription's satisfaction score moved over the
+// year, from the score before its first change to the score after its last.
+// A nil score means the subscription was unrated.
+type SatisfactionShift struct {
+	SubscriptionID string `json:"subscriptionId"`
+	ServiceName    string `json:"serviceName"`
+	From           *int   `json:"from"`
+	To             *int   `json:"to"`
+}
+
+// CategoryShift compares a category's spending with the previous year.
+type CategoryShift struct {
+	CategoryID     string `json:"categoryId"`
+	CategoryName   string `json:"categoryName"`
+	Color          string `json:"color"`
+	Amount         int    `json:"amount"`
+	PreviousAmount int    `json:"previousAmount"`
+	Change         int    `json:"change"`
+}
+
+// GetYearInReview returns the year-in-review report for a calendar year,
+// rebuilt from the stored price, status and satisfaction history. Reports are
+// cached per day, since the current year runs up to today.
+func (s *ReportService) GetYearInReview(userID string, year int) (*YearInReview, error) {
+	today := s.clock.Today(userID)
+	if year > today.Year() {
+		return nil, utils.ErrBadRequest("미래 연도의 리포트는 조회할 수 없습니다")
+	}
+
+	key := aggregateKey(userID, "year-review", strconv.Itoa(year), today.Format("2006-01-02"))
+	return cachedAggregate(s.cache, key, func() (*YearInReview, error) {
+		report, err := s.buildYearInReview(userID, year, today)
+		if err != nil {
+			slog.Error("연간 리포트 생성 실패", "userID", userID, "year", year, "error", err)
+			return nil, utils.ErrInternal("연간 리포트를 조회할 수 없습니다")
+		}
+		return report, nil
+	})
+}
+
+// buildYearInReview assembles the report for year as seen on today.
+func (s *ReportService) buildYearInReview(userID string, year int, today time.Time) (*YearInReview, error) {
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, -1)
+	if to.After(today) {
+		to = today
+	}
+	prevFrom, prevTo := from.AddDate(-1, 0, 0), from.AddDate(0, 0, -1)
+
+	shareMap := buildShareMap(s.shareRepo, userID)
+	histories, err := s.loadHistories(userID, shareMap, prevFrom)
+	if err != nil {
+		return nil, err
+	}
+	savings, err := s.loadSavings(userID)
+	if err != nil {
+		return nil, err
+	}
+	var scores []*models.SatisfactionChange
+	if s.scoreRepo != nil {
+		if scores, err = s.scoreRepo.FindByUserIDSince(userID, from); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &YearInReview{
+		Year:         year,
+		From:         from,
+		To:           to,
+		MonthlyTrend: monthlySpending(histories, from, to, today),
+		Added:        make([]YearInReviewEvent, 0),
+		Cancelled:    make([]YearInReviewEvent, 0),
+	}
+
+	categories := make(map[string]*CategoryShift)
+	for _, h := range histories {
+		spent := sumCharges(h.charges(from, to, today))
+		prevSpent := sumCharges(h.charges(prevFrom, prevTo, today))
+		report.TotalSpent += spent
+		report.PreviousYearSpent += prevSpent
+
+		if spent > 0 && (report.MostExpensive == nil || spent > report.MostExpensive.Amount ||
+			(spent == report.MostExpensive.Amount && h.sub.ServiceName < report.MostExpensive.ServiceName)) {
+			report.MostExpensive = &YearInReviewSpending{
+				SubscriptionID: h.sub.ID.String(),
+				ServiceName:    h.sub.ServiceName,
+				Amount:         spent,
+			}
+		}
+
+		if spent > 0 || prevSpent > 0 {
+			addCategoryShift(categories, h.sub, spent, prevSpent)
+		}
+
+		if tenure := yearEndTenure(h, from, to); tenure != nil && (report.LongestHeld == nil ||
+			tenure.Days > report.LongestHeld.Days ||
+			(tenure.Days == report.LongestHeld.Days && tenure.ServiceName < report.LongestHeld.ServiceName)) {
+			report.LongestHeld = tenure
+		}
+
+		if !h.start.Before(from) && !h.start.After(to) {
+			report.Added = append(report.Added, yearInReviewEvent(h, h.start, h.start))
+		}
+		if event := yearCancellation(h, from, to); event != nil {
+			report.Cancelled = append(report.Cancelled, *event)
+		}
+	}
+
+	for _, saving := range savings {
+		start := truncateDate(saving.CancelledOn)
+		if start.Before(from) {
+			start = from
+		}
+		report.SavingsRealized += accrueSaving(saving.MonthlyAmount, start, to.AddDate(0, 0, 1))
+	}
+
+	report.SatisfactionChanges = satisfactionShifts(histories, scores, to)
+	report.CategoryShifts = sortedCategoryShifts(categories)
+	sortYearInReviewEvents(report.Added)
+	sortYearInReviewEvents(report.Cancelled)
+	return report, nil
+}
+
+// sumCharges adds up the amounts of charges.
+func sumCharges(charges []historyCharge) int {
+	total := 0
+	for _, charge := range charges {
+		total += charge.amount
+	}
+	return total
+}
+
+// yearEndTenure returns how long the subscription had been held by its last
+// billed day in [from, to]. Subscriptions not billed in the period yield nil.
+func yearEndTenure(h *subscriptionHistory, from, to time.Time) *YearInReviewTenure {
+	last, ok := h.lastBilledOn(from, to)
+	if !ok {
+		return nil
+	}
+	return &YearInReviewTenure{
+		SubscriptionID: h.sub.ID.String(),
+		ServiceName:    h.sub.ServiceName,
+		StartDate:      h.start,
+		Days:           int(last.Sub(h.start).Hours() / 24),
+	}
+}
+
+// yearCancellation returns the first cancellation of the subscription within
+// [from, to]: a change to the cancelled status, or the deletion of a
+// subscription that was still billed the day before.
+func yearCancellation(h *subscriptionHistory, from, to time.Time) *YearInReviewEvent {
+	for _, change := range h.statuses {
+		d := truncateDate(change.EffectiveDate)
+		if change.NewStatus == models.SubscriptionStatusCancelled && !d.Before(from) && !d.After(to) {
+			event := yearInReviewEvent(h, d, d.AddDate(0, 0, -1))
+			return &event
+		}
+	}
+	if !h.end.IsZero() && !h.end.Before(from) && !h.end.After(to) {
+		last := h.end.AddDate(0, 0, -1)
+		if h.billedOn(last) {
+			event := yearInReviewEvent(h, h.end, last)
+			return &event
+		}
+	}
+	return nil
+}
+
+// yearInReviewEvent describes the subscription on date, priced as on priceOn.
+func yearInReviewEvent(h *subscriptionHistory, date, priceOn time.Time) YearInReviewEvent {
+	return YearInReviewEvent{
+		SubscriptionID: h.sub.ID.String(),
+		ServiceName:    h.sub.ServiceName,
+		Date:           date,
+		MonthlyAmount:  h.personalMonthlyOn(priceOn),
+	}
+}
+
+// sortYearInReviewEvents orders events by date, then by service name.
+func sortYearInReviewEvents(events []YearInReviewEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].ServiceName < events[j].ServiceName
+	})
+}
+
+// addCategoryShift adds a subscription's spending to its category.
+func addCategoryShift(categories map[string]*CategoryShift, sub *models.Subscription, spent, prevSpent int) {
+	id, name, color := "", "미분류", "#B2BEC3"
+	if sub.Category != nil {
+		id, name = sub.Category.ID.String(), sub.Category.Name
+		if sub.Category.Color != nil {
+			color = *sub.Category.Color
+		}
+	}
+	shift, ok := categories[id]
+	if !ok {
+		shift = &CategoryShift{CategoryID: id, CategoryName: name, Color: color}
+		categories[id] = shift
+	}
+	shift.Amount += spent
+	shift.PreviousAmount += prevSpent
+	shift.Change = shift.Amount - shift.PreviousAmount
+}
+
+// sortedCategoryShifts orders categories by the size of their change,
+// largest first.
+func sortedCategoryShifts(categories map[string]*CategoryShift) []CategoryShift {
+	shifts := make([]CategoryShift, 0, len(categories))
+	for _, shift := range categories {
+		shifts = append(shifts, *shift)
+	}
+	sort.Slice(shifts, func(i, j int) bool {
+		a, b := abs(shifts[i].Change), abs(shifts[j].Change)
+		if a != b {
+			return a > b
+		}
+		return shifts[i].CategoryName < shifts[j].CategoryName
+	})
+	return shifts
+}
+
+// satisfactionShifts summarises the satisfaction changes made up to to for
+// each subscription, skipping subscriptions whose score ended where it began.
+func satisfactionShifts(histories []*subscriptionHistory, changes []*models.SatisfactionChange, to time.Time) []SatisfactionShift {
+	names := make(map[string]string, len(histories))
+	for _, h := range histories {
+		names[h.sub.ID.String()] = h.sub.ServiceName
+	}
+
+	byID := make(map[string]*SatisfactionShift)
+	order := make([]string, 0)
+	for _, change := range changes {
+		if truncateDate(change.EffectiveDate).After(to) {
+			continue
+		}
+		id := change.SubscriptionID.String()
+		name, ok := names[id]
+		if !ok {
+			continue
+		}
+		shift, ok := byID[id]
+		if !ok {
+			shift = &SatisfactionShift{SubscriptionID: id, ServiceName: name, From: change.OldScore}
+			byID[id] = shift
+			order = append(order, id)
+		}
+		shift.To = change.NewScore
+	}
+
+	shifts := make([]SatisfactionShift, 0, len(order))
+	for _, id := range order {
+		if shift := byID[id]; !equalScores(shift.From, shift.To) {
+			shifts = append(shifts, *shift)
+		}
+	}
+	sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].ServiceName < shifts[j].ServiceName })
+	return shifts
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
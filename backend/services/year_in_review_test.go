@@ -0,0 +1,167 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/subkeep/backend/models"
+	"github.com/subkeep/backend/utils"
+)
+
+type mockSatisfactionChangeRepo struct {
+	changes []*models.SatisfactionChange
+}
+
+func (m *mockSatisfactionChangeRepo) Create(change *models.SatisfactionChange) error {
+	if change.ID == uuid.Nil {
+		change.ID = uuid.New()
+	}
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *mockSatisfactionChangeRepo) FindByUserIDSince(userID string, since time.Time) ([]*models.SatisfactionChange, error) {
+	var result []*models.SatisfactionChange
+	for _, c := range m.changes {
+		if c.UserID.String() == userID && !c.EffectiveDate.Before(since) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func ymd(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetYearInReview_PastYear(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	statuses := &mockStatusChangeRepo{}
+	scores := &mockSatisfactionChangeRepo{}
+	savings := &mockCancellationSavingRepo{}
+	clock := NewUserClock(fixedClock(ymd(2026, 3, 1)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock, Savings: savings, StatusRepo: statuses, ScoreRepo: scores})
+	userID := uuid.New()
+
+	video := rptMakeCategory("엔터테인먼트", "#FF6B6B")
+	cloud := rptMakeCategory("클라우드", "#45B7D1")
+
+	netflix := seedReportSub(repo, userID, "Netflix", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, video)
+	netflix.StartDate = ymd(2023, 1, 10)
+	netflix.NextBillingDate = ymd(2026, 3, 10)
+
+	yearly := seedReportSub(repo, userID, "Yearly", 150000, models.BillingCycleYearly, models.SubscriptionStatusActive, nil, cloud)
+	yearly.StartDate = ymd(2025, 4, 1)
+	yearly.NextBillingDate = ymd(2026, 4, 1)
+
+	dropped := seedReportSub(repo, userID, "Dropped", 5000, models.BillingCycleMonthly, models.SubscriptionStatusCancelled, nil, nil)
+	dropped.StartDate = ymd(2024, 1, 1)
+	dropped.NextBillingDate = ymd(2026, 4, 1)
+	_ = statuses.Create(&models.StatusChange{
+		SubscriptionID: dropped.ID, UserID: userID,
+		OldStatus: models.SubscriptionStatusActive, NewStatus: models.SubscriptionStatusCancelled,
+		EffectiveDate: ymd(2025, 7, 1),
+	})
+	_ = savings.Create(&models.CancellationSaving{
+		UserID: userID, SubscriptionID: dropped.ID, ServiceName: "Dropped", MonthlyAmount: 5000,
+		CancelledOn: ymd(2025, 7, 1),
+	})
+
+	for _, c := range []struct {
+		sub      *models.Subscription
+		old, new *int
+		on       time.Time
+	}{
+		{netflix, nil, rptIntPtr(3), ymd(2025, 2, 1)},
+		{netflix, rptIntPtr(3), rptIntPtr(5), ymd(2025, 9, 1)},
+		{dropped, rptIntPtr(2), rptIntPtr(4), ymd(2025, 3, 1)},
+		{dropped, rptIntPtr(4), rptIntPtr(2), ymd(2025, 5, 1)},
+		{yearly, nil, rptIntPtr(1), ymd(2026, 2, 1)}, // after the year
+	} {
+		_ = scores.Create(&models.SatisfactionChange{
+			SubscriptionID: c.sub.ID, UserID: userID, OldScore: c.old, NewScore: c.new, EffectiveDate: c.on,
+		})
+	}
+
+	report, err := svc.GetYearInReview(userID.String(), 2025)
+	assertNil(t, err)
+
+	assertEqual(t, report.To, ymd(2025, 12, 31))
+	assertEqual(t, len(report.MonthlyTrend), 12)
+	assertEqual(t, report.TotalSpent, 120000+150000+30000)
+	assertEqual(t, report.PreviousYearSpent, 120000+60000)
+	assertEqual(t, *report.MostExpensive, YearInReviewSpending{SubscriptionID: yearly.ID.String(), ServiceName: "Yearly", Amount: 150000})
+	assertEqual(t, *report.LongestHeld, YearInReviewTenure{
+		SubscriptionID: netflix.ID.String(), ServiceName: "Netflix", StartDate: ymd(2023, 1, 10), Days: 1086,
+	})
+	assertEqual(t, report.Added, []YearInReviewEvent{
+		{SubscriptionID: yearly.ID.String(), ServiceName: "Yearly", Date: ymd(2025, 4, 1), MonthlyAmount: 12500},
+	})
+	assertEqual(t, report.Cancelled, []YearInReviewEvent{
+		{SubscriptionID: dropped.ID.String(), ServiceName: "Dropped", Date: ymd(2025, 7, 1), MonthlyAmount: 5000},
+	})
+	// 184 days from July 1 to the end of the year.
+	assertEqual(t, report.SavingsRealized, 30247)
+	assertEqual(t, report.SatisfactionChanges, []SatisfactionShift{
+		{SubscriptionID: netflix.ID.String(), ServiceName: "Netflix", From: nil, To: rptIntPtr(5)},
+	})
+	assertEqual(t, report.CategoryShifts, []CategoryShift{
+		{CategoryID: cloud.ID.String(), CategoryName: "클라우드", Color: "#45B7D1", Amount: 150000, PreviousAmount: 0, Change: 150000},
+		{CategoryID: "", CategoryName: "미분류", Color: "#B2BEC3", Amount: 30000, PreviousAmount: 60000, Change: -30000},
+		{CategoryID: video.ID.String(), CategoryName: "엔터테인먼트", Color: "#FF6B6B", Amount: 120000, PreviousAmount: 120000, Change: 0},
+	})
+}
+
+func TestGetYearInReview_CurrentYearRunsToToday(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	clock := NewUserClock(fixedClock(ymd(2026, 3, 15)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "Netflix", 10000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	sub.StartDate = ymd(2025, 11, 20)
+	sub.NextBillingDate = ymd(2026, 3, 20)
+
+	report, err := svc.GetYearInReview(userID.String(), 2026)
+	assertNil(t, err)
+	assertEqual(t, report.To, ymd(2026, 3, 15))
+	assertEqual(t, len(report.MonthlyTrend), 3)
+	assertEqual(t, report.TotalSpent, 20000)
+	assertEqual(t, report.PreviousYearSpent, 20000)
+	assertEqual(t, len(report.Added), 0)
+	assertEqual(t, report.LongestHeld.Days, 115)
+}
+
+func TestGetYearInReview_DeletionCountsAsCancellation(t *testing.T) {
+	repo := newMockSubRepoForReport()
+	clock := NewUserClock(fixedClock(ymd(2026, 3, 1)), nil)
+	svc := NewReportServiceWithDeps(repo, newMockShareRepoForReport(), ReportDeps{Clock: clock})
+	userID := uuid.New()
+
+	sub := seedReportSub(repo, userID, "Gone", 8000, models.BillingCycleMonthly, models.SubscriptionStatusActive, nil, nil)
+	sub.StartDate = ymd(2025, 1, 5)
+	sub.NextBillingDate = ymd(2025, 10, 5)
+	sub.DeletedAt = gorm.DeletedAt{Time: ymd(2025, 9, 20), Valid: true}
+
+	report, err := svc.GetYearInReview(userID.String(), 2025)
+	assertNil(t, err)
+	assertEqual(t, report.TotalSpent, 9*8000)
+	assertEqual(t, report.Cancelled, []YearInReviewEvent{
+		{SubscriptionID: sub.ID.String(), ServiceName: "Gone", Date: ymd(2025, 9, 20), MonthlyAmount: 8000},
+	})
+	assertEqual(t, report.LongestHeld.Days, int(ymd(2025, 9, 19).Sub(ymd(2025, 1, 5)).Hours()/24))
+}
+
+func TestGetYearInReview_FutureYear(t *testing.T) {
+	clock := NewUserClock(fixedClock(ymd(2026, 3, 1)), nil)
+	svc := NewReportServiceWithDeps(newMockSubRepoForReport(), newMockShareRepoForReport(), ReportDeps{Clock: clock})
+
+	_, err := svc.GetYearInReview(uuid.New().String(), 2027)
+	appErr, ok := err.(*utils.AppError)
+	if !ok || appErr.Code != 400 {
+		t.Errorf("expected bad request, got %v", err)
+	}
+}
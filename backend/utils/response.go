@@ -26,6 +26,13 @@ type PaginationMeta struct {
 	HasPrev    bool  `json:"has_prev"`
 }
 
+// CursorMeta holds cursor pagination metadata. NextCursor is empty on the last page.
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasNext    bool   `json:"has_next"`
+}
+
 // Success sends a 200 OK response with data.
 func Success(c *fiber.Ctx, data interface{}) error {
 	return c.Status(http.StatusOK).JSON(Response{
@@ -75,6 +82,19 @@ func Paginated(c *fiber.Ctx, data interface{}, page, perPage int, totalItems int
 	})
 }
 
+// CursorPaginated sends a 200 OK response with cursor-paginated data.
+func CursorPaginated(c *fiber.Ctx, data interface{}, limit int, nextCursor string) error {
+	return c.Status(http.StatusOK).JSON(Response{
+		Success: true,
+		Data:    data,
+		Meta: CursorMeta{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			HasNext:    nextCursor != "",
+		},
+	})
+}
+
 // Error sends an error response using an AppError.
 func Error(c *fiber.Ctx, err *AppError) error {
 	return c.Status(err.Code).JSON(Response{
@@ -62,6 +62,8 @@ func formatFieldError(fe validator.FieldError) string {
 		return fmt.Sprintf("%s 필드는 [%s] 중 하나여야 합니다", field, fe.Param())
 	case "url":
 		return fmt.Sprintf("%s 필드는 유효한 URL이어야 합니다", field)
+	case "timezone":
+		return fmt.Sprintf("%s 필드는 유효한 IANA 시간대여야 합니다 (예: Asia/Seoul)", field)
 	case "billing_cycle":
 		return fmt.Sprintf("%s 필드는 monthly, yearly, weekly 중 하나여야 합니다", field)
 	case "currency_krw":